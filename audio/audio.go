@@ -61,11 +61,16 @@ type Context struct {
 	playerFactory *playerFactory
 
 	sampleRate int
+	bufferSize time.Duration
 	err        error
 	ready      bool
 
 	playingPlayers map[*playerImpl]struct{}
 
+	// finishedCallbacks are the callbacks of the players that reached the end of their sources.
+	// finishedCallbacks are called at the next Update.
+	finishedCallbacks []func()
+
 	m         sync.Mutex
 	semaphore chan struct{}
 }
@@ -75,6 +80,19 @@ var (
 	theContextLock sync.Mutex
 )
 
+// ContextOptions represents options for NewContextWithOptions.
+type ContextOptions struct {
+	// BufferSize specifies the buffer size of the underlying audio device.
+	//
+	// A smaller buffer size reduces the latency, but might cause glitch noises on slow machines.
+	// A bigger buffer size is more stable, but increases the latency.
+	//
+	// If BufferSize is 0, the platform's default buffer size is used.
+	// BufferSize is rounded down to a whole number of samples.
+	// BufferSize must not be negative.
+	BufferSize time.Duration
+}
+
 // NewContext creates a new audio context with the given sample rate.
 //
 // sampleRate specifies the number of samples that should be played during one second.
@@ -83,6 +101,15 @@ var (
 //
 // NewContext panics when an audio context is already created.
 func NewContext(sampleRate int) *Context {
+	return NewContextWithOptions(sampleRate, nil)
+}
+
+// NewContextWithOptions creates a new audio context with the given sample rate and options.
+//
+// If options is nil, NewContextWithOptions is the same as NewContext.
+//
+// NewContextWithOptions panics when an audio context is already created, or when options.BufferSize is negative.
+func NewContextWithOptions(sampleRate int, options *ContextOptions) *Context {
 	theContextLock.Lock()
 	defer theContextLock.Unlock()
 
@@ -90,9 +117,21 @@ func NewContext(sampleRate int) *Context {
 		panic("audio: context is already created")
 	}
 
+	var bufferSize time.Duration
+	if options != nil {
+		if options.BufferSize < 0 {
+			panic(fmt.Sprintf("audio: BufferSize must not be negative but %s", options.BufferSize))
+		}
+		// The audio driver takes the buffer size in bytes. Round the size in the same way so that BufferSize reports
+		// the size the driver actually receives.
+		frames := int64(options.BufferSize) * int64(sampleRate) / int64(time.Second)
+		bufferSize = time.Duration(frames * int64(time.Second) / int64(sampleRate))
+	}
+
 	c := &Context{
 		sampleRate:     sampleRate,
-		playerFactory:  newPlayerFactory(sampleRate),
+		bufferSize:     bufferSize,
+		playerFactory:  newPlayerFactory(sampleRate, bufferSize),
 		playingPlayers: map[*playerImpl]struct{}{},
 		semaphore:      make(chan struct{}, 1),
 	}
@@ -143,6 +182,11 @@ func NewContext(sampleRate int) *Context {
 				c.setReady()
 			}()
 		}
+
+		// Call the finished callbacks on the game's goroutine, not on the goroutine updating the players.
+		for _, f := range c.flushFinishedCallbacks() {
+			f()
+		}
 		return nil
 	})
 
@@ -221,6 +265,21 @@ func (c *Context) removePlayingPlayer(p *playerImpl) {
 	c.m.Unlock()
 }
 
+func (c *Context) isPlayingPlayer(p *playerImpl) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	_, ok := c.playingPlayers[p]
+	return ok
+}
+
+func (c *Context) flushFinishedCallbacks() []func() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	fs := c.finishedCallbacks
+	c.finishedCallbacks = nil
+	return fs
+}
+
 func (c *Context) onSuspend() error {
 	// A Context must not call playerImpl's functions with a lock, or this causes a deadlock (#2737).
 	// Copy the playerImpls and iterate them without a lock.
@@ -275,6 +334,7 @@ func (c *Context) updatePlayers() error {
 	c.m.Unlock()
 
 	var playersToRemove []*playerImpl
+	var finishedCallbacks []func()
 
 	// Now reader players cannot call removePlayers from themselves in the current implementation.
 	// Underlying playering can be the pause state after fishing its playing,
@@ -285,6 +345,10 @@ func (c *Context) updatePlayers() error {
 			return err
 		}
 		p.updatePosition()
+		p.pauseIfFadedOut()
+		if f := p.checkFinished(); f != nil {
+			finishedCallbacks = append(finishedCallbacks, f)
+		}
 		if !p.IsPlaying() {
 			playersToRemove = append(playersToRemove, p)
 		}
@@ -294,6 +358,7 @@ func (c *Context) updatePlayers() error {
 	for _, p := range playersToRemove {
 		delete(c.playingPlayers, p)
 	}
+	c.finishedCallbacks = append(c.finishedCallbacks, finishedCallbacks...)
 	c.m.Unlock()
 
 	return nil
@@ -313,6 +378,15 @@ func (c *Context) SampleRate() int {
 	return c.sampleRate
 }
 
+// BufferSize returns the buffer size passed to the underlying audio driver.
+// The value is ContextOptions.BufferSize rounded down to a whole number of samples.
+//
+// BufferSize returns 0 if the platform's default buffer size is used.
+// The driver doesn't report the size it finally allocates, which might be bigger than the requested size on some platforms.
+func (c *Context) BufferSize() time.Duration {
+	return c.bufferSize
+}
+
 // Player is an audio player which has one stream.
 //
 // Even when all references to a Player object is gone,
@@ -493,6 +567,36 @@ func (p *Player) Pause() {
 	p.p.Pause()
 }
 
+// PlayWithFade plays the stream, ramping the volume up from zero over the given duration.
+// This avoids a click noise at the start of the playing.
+//
+// If the player is fading out by PauseWithFade, PlayWithFade cancels the pausing and ramps the volume up from the current gain.
+//
+// The fade is applied to the stream on top of the volume set by SetVolume.
+func (p *Player) PlayWithFade(duration time.Duration) {
+	p.p.PlayWithFade(duration)
+}
+
+// PauseWithFade ramps the volume down to zero over the given duration, and then pauses the playing.
+// This avoids a click noise which an abrupt Pause might cause.
+//
+// PauseWithFade doesn't block. IsPlaying keeps returning true until the fade finishes.
+// If duration is not positive, PauseWithFade is the same as Pause.
+func (p *Player) PauseWithFade(duration time.Duration) {
+	p.p.PauseWithFade(duration)
+}
+
+// SetFinishedCallback sets a function called when the player reaches the end of its source.
+//
+// f is called once on the goroutine calling Update, at the next Update after the playing finishes.
+// f is not called when the player is paused by Pause or PauseWithFade, or when the source never ends like InfiniteLoop.
+// After Rewind or SetPosition, f can be called again when the player reaches the end again.
+//
+// If f is nil, the callback is removed.
+func (p *Player) SetFinishedCallback(f func()) {
+	p.p.SetFinishedCallback(f)
+}
+
 // Position returns the current position in time.
 //
 // As long as the player continues to play, Position's returning value is increased monotonically,
@@ -16,9 +16,11 @@ package audio_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 	"runtime"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
@@ -173,3 +175,149 @@ func TestUncomparableSource(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestPauseWithFade(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(emptySource{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	p.PlayWithFade(10 * time.Millisecond)
+	if !p.IsPlaying() {
+		t.Fatal("the player must be playing")
+	}
+
+	p.PauseWithFade(10 * time.Millisecond)
+	if !p.IsPlaying() {
+		t.Fatal("the player must be playing until the fade finishes")
+	}
+
+	for i := 0; i < 50; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		if !p.IsPlaying() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("time out")
+}
+
+func TestFinishedCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, err := context.NewPlayer(bytes.NewReader(make([]byte, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	var count int
+	p.SetFinishedCallback(func() {
+		count++
+	})
+	p.Play()
+
+	for i := 0; i < 50; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		if count > 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got, want := count, 1; got != want {
+		t.Fatalf("count: got: %d, want: %d", got, want)
+	}
+
+	// The callback must not be called again for the same end.
+	for i := 0; i < 5; i++ {
+		if err := audio.UpdateForTesting(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count after updates: got: %d, want: %d", got, want)
+	}
+}
+
+func TestNewContextWithOptions(t *testing.T) {
+	for _, tc := range []struct {
+		options *audio.ContextOptions
+		want    time.Duration
+	}{
+		{nil, 0},
+		{&audio.ContextOptions{}, 0},
+		{&audio.ContextOptions{BufferSize: time.Millisecond}, time.Millisecond},
+		{&audio.ContextOptions{BufferSize: 50 * time.Millisecond}, 50 * time.Millisecond},
+		// 1 second / 48000 is about 20.83 microseconds. 100 microseconds is rounded down to 4 samples.
+		{&audio.ContextOptions{BufferSize: 100 * time.Microsecond}, 4 * time.Second / 48000},
+	} {
+		c := audio.NewContextWithOptions(48000, tc.options)
+		if got := c.BufferSize(); got != tc.want {
+			t.Errorf("BufferSize() with %v: got: %s, want: %s", tc.options, got, tc.want)
+		}
+		audio.ResetContextForTesting()
+	}
+
+	defer func() {
+		audio.ResetContextForTesting()
+		if e := recover(); e == nil {
+			t.Errorf("NewContextWithOptions with a negative buffer size must panic")
+		}
+	}()
+	audio.NewContextWithOptions(44100, &audio.ContextOptions{BufferSize: -time.Millisecond})
+}
+
+func TestFadeWithIncompleteSamples(t *testing.T) {
+	const (
+		sampleRate = 1000
+		frames     = 100
+		value      = 10000
+	)
+
+	// Fade from 1 to 0.5 over 50 frames.
+	duration := 50 * time.Second / sampleRate
+
+	src := make([]byte, 4*frames)
+	for i := 0; i < 2*frames; i++ {
+		binary.LittleEndian.PutUint16(src[2*i:], value)
+	}
+
+	// Read the stream both in big chunks and in single bytes, and the source always returns single bytes.
+	for _, oneByte := range []bool{false, true} {
+		s, err := audio.NewTimeStreamWithFadeForTesting(iotest.OneByteReader(bytes.NewReader(src)), sampleRate, 1, 0.5, duration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oneByte {
+			s = iotest.OneByteReader(s)
+		}
+		got := make([]byte, len(src))
+		if _, err := io.ReadFull(s, got); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < frames; i++ {
+			gain := 0.5
+			if i < 50 {
+				gain = 1 - 0.5*float64(i)/50
+			}
+			want := int(value * gain)
+			for c := 0; c < 2; c++ {
+				v := int(int16(binary.LittleEndian.Uint16(got[4*i+2*c:])))
+				if v < want-1 || v > want+1 {
+					t.Errorf("one byte: %t, frame %d, channel %d: got: %d, want: %d", oneByte, i, c, v, want)
+				}
+			}
+		}
+	}
+}
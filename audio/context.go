@@ -16,15 +16,17 @@ package audio
 
 import (
 	"io"
+	"time"
 
 	"github.com/ebitengine/oto/v3"
 )
 
-func newContext(sampleRate int) (context, chan struct{}, error) {
+func newContext(sampleRate int, bufferSize time.Duration) (context, chan struct{}, error) {
 	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
 		SampleRate:   sampleRate,
 		ChannelCount: channelCount,
 		Format:       oto.FormatFloat32LE,
+		BufferSize:   bufferSize,
 	})
 	err = addErrorInfo(err)
 	return &contextProxy{ctx}, ready, err
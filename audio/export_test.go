@@ -163,3 +163,13 @@ func ResetContextForTesting() {
 func (i *InfiniteLoop) SetNoBlendForTesting(value bool) {
 	i.noBlendForTesting = value
 }
+
+func NewTimeStreamWithFadeForTesting(r io.Reader, sampleRate int, gain, target float64, duration time.Duration) (io.Reader, error) {
+	s, err := newTimeStream(r, false, sampleRate, bitDepthInBytesInt16)
+	if err != nil {
+		return nil, err
+	}
+	s.setFadeGain(gain)
+	s.startFade(target, duration)
+	return s, nil
+}
@@ -15,8 +15,10 @@
 package audio
 
 import (
+	"encoding/binary"
 	"errors"
 	"io"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -48,15 +50,17 @@ type context interface {
 type playerFactory struct {
 	context    context
 	sampleRate int
+	bufferSize time.Duration
 
 	m sync.Mutex
 }
 
 var driverForTesting context
 
-func newPlayerFactory(sampleRate int) *playerFactory {
+func newPlayerFactory(sampleRate int, bufferSize time.Duration) *playerFactory {
 	f := &playerFactory{
 		sampleRate: sampleRate,
+		bufferSize: bufferSize,
 	}
 	if driverForTesting != nil {
 		f.context = driverForTesting
@@ -87,6 +91,16 @@ type playerImpl struct {
 	// stopwatch is a stopwatch to measure the time duration during the player position doesn't change while its playing.
 	stopwatch stopwatch
 
+	// pausingWithFade indicates whether the player is fading out by PauseWithFade.
+	// The player is paused when the fading out finishes.
+	pausingWithFade bool
+
+	// finishedCallback is called when the player reaches the end of its source.
+	finishedCallback func()
+
+	// finishedNotified indicates whether finishedCallback is already queued for the current end of the source.
+	finishedNotified bool
+
 	m sync.Mutex
 }
 
@@ -145,7 +159,7 @@ func (f *playerFactory) initContextIfNeeded() (<-chan struct{}, error) {
 		return nil, nil
 	}
 
-	c, ready, err := newContext(f.sampleRate)
+	c, ready, err := newContext(f.sampleRate, f.bufferSize)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +209,28 @@ func (p *playerImpl) Play() {
 		p.context.setError(err)
 		return
 	}
+	p.pausingWithFade = false
+	p.stream.resetFade()
+	p.play()
+}
+
+func (p *playerImpl) PlayWithFade(duration time.Duration) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if err := p.ensurePlayer(); err != nil {
+		p.context.setError(err)
+		return
+	}
+	p.pausingWithFade = false
+	if !p.player.IsPlaying() {
+		p.stream.setFadeGain(0)
+	}
+	p.stream.startFade(1, duration)
+	p.play()
+}
+
+func (p *playerImpl) play() {
 	if p.player.IsPlaying() {
 		return
 	}
@@ -207,9 +243,34 @@ func (p *playerImpl) Pause() {
 	p.m.Lock()
 	defer p.m.Unlock()
 
+	if p.player == nil {
+		return
+	}
+	p.pause()
+}
+
+func (p *playerImpl) PauseWithFade(duration time.Duration) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
 	if p.player == nil {
 		return
 	}
+	if !p.player.IsPlaying() {
+		return
+	}
+	if duration <= 0 {
+		p.pause()
+		return
+	}
+	p.pausingWithFade = true
+	p.stream.startFade(0, duration)
+}
+
+func (p *playerImpl) pause() {
+	p.pausingWithFade = false
+	p.stream.resetFade()
+
 	if !p.player.IsPlaying() {
 		return
 	}
@@ -219,6 +280,53 @@ func (p *playerImpl) Pause() {
 	p.stopwatch.stop()
 }
 
+// pauseIfFadedOut pauses the player if fading out by PauseWithFade finishes.
+func (p *playerImpl) pauseIfFadedOut() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil {
+		return
+	}
+	if !p.pausingWithFade {
+		return
+	}
+	if !p.stream.isFadedOut() {
+		return
+	}
+	p.pause()
+}
+
+// checkFinished returns the finished callback if the player has just reached the end of its source.
+// checkFinished returns nil otherwise, or when the callback is already returned for the same end.
+func (p *playerImpl) checkFinished() func() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.player == nil || p.finishedCallback == nil || p.finishedNotified {
+		return nil
+	}
+	if p.player.IsPlaying() {
+		return nil
+	}
+	if !p.stream.isEOF() {
+		return nil
+	}
+	// A player paused explicitly is already removed from the playing players.
+	// Do not treat it as finished even if its source was consumed.
+	if !p.context.isPlayingPlayer(p) {
+		return nil
+	}
+	p.finishedNotified = true
+	return p.finishedCallback
+}
+
+func (p *playerImpl) SetFinishedCallback(f func()) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.finishedCallback = f
+}
+
 func (p *playerImpl) IsPlaying() bool {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -296,6 +404,7 @@ func (p *playerImpl) SetPosition(offset time.Duration) error {
 		return addErrorInfo(err)
 	}
 	p.lastSamples = -1
+	p.finishedNotified = false
 	// Just after setting a position, the buffer size should be 0 as no data is sent.
 	p.adjustedPosition.Store(int64(p.stream.positionInTimeDuration()))
 	p.stopwatch.reset()
@@ -393,6 +502,24 @@ type timeStream struct {
 	pos            atomic.Int64
 	bytesPerSample int
 
+	// eof indicates whether the source returned io.EOF. eof is reset by Seek.
+	eof atomic.Bool
+
+	// fadeGain is the current gain applied to the samples.
+	fadeGain float64
+
+	// fadeTarget is the gain at the end of the current fading.
+	fadeTarget float64
+
+	// fadeDelta is the change of the gain per sample. fadeDelta is 0 when the stream is not fading.
+	fadeDelta float64
+
+	// fadeBuf is a buffer to apply the fade to whole samples, as the source might return an incomplete sample.
+	// fadeBuf[:fadeReady] is the faded bytes not returned yet, and fadeBuf[fadeReady:] is an incomplete sample
+	// carried over to the next Read.
+	fadeBuf   []byte
+	fadeReady int
+
 	// m is a mutex for this stream.
 	// All the exported functions are protected by this mutex as Read can be read from a different goroutine than Seek.
 	m sync.Mutex
@@ -404,6 +531,8 @@ func newTimeStream(r io.Reader, seekable bool, sampleRate int, bitDepthInBytes i
 		seekable:       seekable,
 		sampleRate:     sampleRate,
 		bytesPerSample: bitDepthInBytes * channelCount,
+		fadeGain:       1,
+		fadeTarget:     1,
 	}
 	if seekable {
 		// Get the current position of the source.
@@ -425,11 +554,118 @@ func (s *timeStream) Read(buf []byte) (int, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 
-	n, err := s.r.Read(buf)
-	s.pos.Add(int64(n))
+	if len(s.fadeBuf) == 0 {
+		// After fading out, emit silence without consuming the source so that the source can be resumed later.
+		if s.fadeDelta == 0 && s.fadeGain == 0 {
+			clear(buf)
+			return len(buf), nil
+		}
+
+		if s.fadeDelta == 0 && s.fadeGain == 1 {
+			n, err := s.r.Read(buf)
+			s.pos.Add(int64(n))
+			if err == io.EOF {
+				s.eof.Store(true)
+			}
+			return n, err
+		}
+	}
+
+	var err error
+	if s.fadeReady == 0 {
+		// Read at least one whole sample, following the incomplete sample from the last Read.
+		incomplete := len(s.fadeBuf)
+		size := max(len(buf), s.bytesPerSample)
+		if cap(s.fadeBuf) < size {
+			s.fadeBuf = append(make([]byte, 0, size), s.fadeBuf...)
+		}
+		var n int
+		n, err = s.r.Read(s.fadeBuf[incomplete:size])
+		s.pos.Add(int64(n))
+		if err == io.EOF {
+			s.eof.Store(true)
+		}
+		s.fadeBuf = s.fadeBuf[:incomplete+n]
+
+		s.fadeReady = len(s.fadeBuf) - len(s.fadeBuf)%s.bytesPerSample
+		s.applyFade(s.fadeBuf[:s.fadeReady])
+		if err != nil {
+			// The source doesn't continue the incomplete sample. Return it as it is.
+			s.fadeReady = len(s.fadeBuf)
+		}
+	}
+
+	n := copy(buf, s.fadeBuf[:s.fadeReady])
+	s.fadeBuf = s.fadeBuf[:copy(s.fadeBuf, s.fadeBuf[n:])]
+	s.fadeReady -= n
+	if s.fadeReady > 0 {
+		// The rest is returned at the next Read. err, if any, is reported again by the source then.
+		return n, nil
+	}
 	return n, err
 }
 
+// applyFade applies the fade to buf. The length of buf must be a multiple of bytesPerSample.
+func (s *timeStream) applyFade(buf []byte) {
+	bitDepthInBytes := s.bytesPerSample / channelCount
+	for i := 0; i < len(buf); i += s.bytesPerSample {
+		for j := i; j < i+s.bytesPerSample; j += bitDepthInBytes {
+			switch bitDepthInBytes {
+			case bitDepthInBytesInt16:
+				v := float64(int16(binary.LittleEndian.Uint16(buf[j:])))
+				binary.LittleEndian.PutUint16(buf[j:], uint16(int16(v*s.fadeGain)))
+			case bitDepthInBytesFloat32:
+				v := math.Float32frombits(binary.LittleEndian.Uint32(buf[j:]))
+				binary.LittleEndian.PutUint32(buf[j:], math.Float32bits(float32(float64(v)*s.fadeGain)))
+			}
+		}
+
+		if s.fadeDelta == 0 {
+			continue
+		}
+		s.fadeGain += s.fadeDelta
+		if (s.fadeDelta > 0 && s.fadeGain >= s.fadeTarget) || (s.fadeDelta < 0 && s.fadeGain <= s.fadeTarget) {
+			s.fadeGain = s.fadeTarget
+			s.fadeDelta = 0
+		}
+	}
+}
+
+// startFade starts changing the gain from the current gain to the target gain over the duration.
+func (s *timeStream) startFade(target float64, duration time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.fadeTarget = target
+	samples := float64(duration) * float64(s.sampleRate) / float64(time.Second)
+	if samples < 1 || s.fadeGain == target {
+		s.fadeGain = target
+		s.fadeDelta = 0
+		return
+	}
+	s.fadeDelta = (target - s.fadeGain) / samples
+}
+
+func (s *timeStream) setFadeGain(gain float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.fadeGain = gain
+	s.fadeTarget = gain
+	s.fadeDelta = 0
+}
+
+func (s *timeStream) resetFade() {
+	s.setFadeGain(1)
+}
+
+func (s *timeStream) isFadedOut() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.fadeDelta == 0 && s.fadeGain == 0
+}
+
 func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -444,6 +680,9 @@ func (s *timeStream) Seek(offset int64, whence int) (int64, error) {
 	}
 
 	s.pos.Store(pos)
+	s.eof.Store(false)
+	s.fadeBuf = s.fadeBuf[:0]
+	s.fadeReady = 0
 	return pos, nil
 }
 
@@ -457,6 +696,10 @@ func (s *timeStream) timeDurationToPos(offset time.Duration) int64 {
 	return o
 }
 
+func (s *timeStream) isEOF() bool {
+	return s.eof.Load()
+}
+
 func (s *timeStream) position() int64 {
 	return s.pos.Load()
 }
@@ -85,6 +85,27 @@ func (b Blend) internalBlend() graphicsdriver.Blend {
 	}
 }
 
+func disabledColorChannels(colorMask [4]bool) graphicsdriver.ColorChannels {
+	// The zero value means all the channels are written.
+	if colorMask == [4]bool{} {
+		return 0
+	}
+	var c graphicsdriver.ColorChannels
+	if !colorMask[0] {
+		c |= graphicsdriver.ColorChannelRed
+	}
+	if !colorMask[1] {
+		c |= graphicsdriver.ColorChannelGreen
+	}
+	if !colorMask[2] {
+		c |= graphicsdriver.ColorChannelBlue
+	}
+	if !colorMask[3] {
+		c |= graphicsdriver.ColorChannelAlpha
+	}
+	return c
+}
+
 // BlendFactor is a factor for source and destination color values.
 type BlendFactor byte
 
@@ -371,4 +392,12 @@ var (
 	//     c_out = c_src + c_dst
 	//     α_out = α_src + α_dst
 	BlendLighter = internalBlendToBlend(graphicsdriver.BlendLighter)
+
+	// BlendLighterAlpha is a preset Blend for the additive blending weighted by the source alpha.
+	// Unlike BlendLighter, the source color is scaled by the source alpha again,
+	// so that more transparent sources contribute less, which is useful for glows and particles.
+	//
+	//     c_out = c_src × α_src + c_dst
+	//     α_out = α_src + α_dst
+	BlendLighterAlpha = internalBlendToBlend(graphicsdriver.BlendLighterAlpha)
 )
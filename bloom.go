@@ -0,0 +1,129 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// bloomDownscale is the ratio of the bloom passes' resolution to the destination's resolution.
+const bloomDownscale = 2
+
+// BloomOptions represents options for ApplyBloom.
+type BloomOptions struct {
+	// Threshold is a luminance threshold in [0, 1].
+	// Only the parts brighter than Threshold glow.
+	// Threshold is clamped to [0, 1], so a negative value means 0, with which every non-black part glows.
+	// The default (zero) value means 0.8.
+	Threshold float64
+
+	// Intensity is a non-negative scale of the glow added to the image.
+	// A negative value means 0, with which no glow is added.
+	// The default (zero) value means 1.
+	Intensity float64
+
+	// BlurRadius is a radius of the glow in pixels.
+	// BlurRadius is clamped to [0, 32], so a negative value means 0, with which the bright parts are not blurred.
+	// The default (zero) value means 8.
+	BlurRadius int
+}
+
+// ApplyBloom applies a bloom effect to the image.
+//
+// ApplyBloom extracts the bright parts of the image by a threshold, blurs them with a separable Gaussian blur,
+// and adds the result to the image.
+// The extraction and the blur are done at half the resolution of the image for performance.
+// The scratch images for the passes are reused among calls.
+//
+// If options is nil, the default options are used.
+//
+// When the image i is disposed, ApplyBloom does nothing.
+func (i *Image) ApplyBloom(options *BloomOptions) {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	if options == nil {
+		options = &BloomOptions{}
+	}
+
+	threshold := options.Threshold
+	if threshold == 0 {
+		threshold = 0.8
+	}
+	threshold = min(max(threshold, 0), 1)
+	intensity := options.Intensity
+	if intensity == 0 {
+		intensity = 1
+	}
+	intensity = max(intensity, 0)
+	blurRadius := options.BlurRadius
+	if blurRadius == 0 {
+		blurRadius = 8
+	}
+	blurRadius = min(max(blurRadius, 0), bloomDownscale*builtinshader.MaxBlurRadius)
+
+	b := i.Bounds()
+	w, h := max(b.Dx()/bloomDownscale, 1), max(b.Dy()/bloomDownscale, 1)
+
+	down := theImagePool.get(w, h)
+	defer theImagePool.put(down)
+	bright := theImagePool.get(w, h)
+	defer theImagePool.put(bright)
+
+	// Downscale the image.
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(-b.Min.X), float64(-b.Min.Y))
+	op.GeoM.Scale(float64(w)/float64(b.Dx()), float64(h)/float64(b.Dy()))
+	op.Filter = FilterLinear
+	op.Blend = BlendCopy
+	down.DrawImage(i, op)
+
+	// Extract the bright parts.
+	sop := &DrawRectShaderOptions{}
+	sop.Blend = BlendCopy
+	sop.Images[0] = down
+	sop.Uniforms = map[string]any{
+		builtinshader.UniformThreshold: float32(threshold),
+	}
+	bright.DrawRectShader(w, h, effectShader("brightpass"), sop)
+
+	// Blur the bright parts horizontally and then vertically.
+	radius := float32(blurRadius) / bloomDownscale
+	blur := effectShader("blur")
+	sop.Images[0] = bright
+	sop.Uniforms = map[string]any{
+		builtinshader.UniformDirection: []float32{1, 0},
+		builtinshader.UniformRadius:    radius,
+	}
+	down.DrawRectShader(w, h, blur, sop)
+	sop.Images[0] = down
+	sop.Uniforms = map[string]any{
+		builtinshader.UniformDirection: []float32{0, 1},
+		builtinshader.UniformRadius:    radius,
+	}
+	bright.DrawRectShader(w, h, blur, sop)
+
+	// Add the glow to the image.
+	op = &DrawImageOptions{}
+	op.GeoM.Scale(float64(b.Dx())/float64(w), float64(b.Dy())/float64(h))
+	op.GeoM.Translate(float64(b.Min.X), float64(b.Min.Y))
+	op.ColorScale.ScaleAlpha(float32(intensity))
+	op.Filter = FilterLinear
+	op.Blend = BlendLighter
+	i.DrawImage(bright, op)
+}
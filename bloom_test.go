@@ -0,0 +1,107 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestImageApplyBloom(t *testing.T) {
+	dst := ebiten.NewImage(32, 32)
+	dst.Fill(color.RGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xff})
+	dst.SubImage(image.Rect(14, 14, 18, 18)).(*ebiten.Image).Fill(color.White)
+	dst.ApplyBloom(nil)
+
+	// The glow spreads around the bright part.
+	if got := dst.At(12, 16).(color.RGBA); got.R <= 0x10 {
+		t.Errorf("dst.At(12, 16): got: %v, want: brighter than the original", got)
+	}
+	// The dark parts far from the bright part don't change.
+	if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xff}); got != want {
+		t.Errorf("dst.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageApplyBloomBelowThreshold(t *testing.T) {
+	clr := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	dst := ebiten.NewImage(16, 16)
+	dst.Fill(clr)
+	dst.ApplyBloom(&ebiten.BloomOptions{
+		Threshold: 0.9,
+	})
+
+	// Nothing glows when every part is darker than the threshold.
+	for j := 0; j < 16; j++ {
+		for i := 0; i < 16; i++ {
+			if got := dst.At(i, j).(color.RGBA); got != clr {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, clr)
+			}
+		}
+	}
+}
+
+func TestImageApplyBloomNegativeOptions(t *testing.T) {
+	clr := color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}
+
+	// A negative threshold means 0, and every non-black part glows.
+	dst := ebiten.NewImage(16, 16)
+	dst.Fill(clr)
+	dst.ApplyBloom(&ebiten.BloomOptions{
+		Threshold: -1,
+	})
+	if got := dst.At(8, 8).(color.RGBA); got.R <= clr.R {
+		t.Errorf("dst.At(8, 8): got: %v, want: brighter than %v", got, clr)
+	}
+
+	// A negative intensity means 0, and no glow is added.
+	dst.Fill(clr)
+	dst.ApplyBloom(&ebiten.BloomOptions{
+		Threshold: -1,
+		Intensity: -1,
+	})
+	if got := dst.At(8, 8).(color.RGBA); got != clr {
+		t.Errorf("dst.At(8, 8): got: %v, want: %v", got, clr)
+	}
+}
+
+func TestImageApplyBloomDefaultOptions(t *testing.T) {
+	dst0 := ebiten.NewImage(32, 32)
+	dst0.SubImage(image.Rect(14, 14, 18, 18)).(*ebiten.Image).Fill(color.White)
+	dst1 := ebiten.NewImage(32, 32)
+	dst1.SubImage(image.Rect(14, 14, 18, 18)).(*ebiten.Image).Fill(color.White)
+
+	// nil options and the zero options must be the same.
+	dst0.ApplyBloom(nil)
+	dst1.ApplyBloom(&ebiten.BloomOptions{})
+
+	for j := 0; j < 32; j++ {
+		for i := 0; i < 32; i++ {
+			if got, want := dst1.At(i, j), dst0.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageApplyBloomDisposed(t *testing.T) {
+	dst := ebiten.NewImage(16, 16)
+	dst.Dispose()
+	// ApplyBloom must not panic for a disposed image.
+	dst.ApplyBloom(nil)
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package camera provides helpers for 2D cameras.
+package camera
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ShakeOptions represents options for a Shake.
+type ShakeOptions struct {
+	// MaxOffset is the maximum translation in pixels when the trauma is 1.
+	// The default (zero) value is 16.
+	MaxOffset float64
+
+	// MaxAngle is the maximum rotation in radians when the trauma is 1.
+	// The default (zero) value is 0.05.
+	MaxAngle float64
+
+	// Decay is the amount of the trauma decreased per tick.
+	// The default (zero) value is 0.02, which takes 50 ticks to settle from the trauma 1.
+	Decay float64
+
+	// Frequency is the speed of the shake in noise cycles per tick.
+	// The higher the value is, the more violent the shake is.
+	// Frequency should be less than 1. The default (zero) value is 0.25.
+	Frequency float64
+
+	// Seed is a seed of the noise.
+	// Shakes with different seeds move differently.
+	Seed uint64
+}
+
+// Shake generates camera shake offsets from a trauma value.
+//
+// The trauma is a value in [0, 1] representing the strength of the shake.
+// The trauma increases by Add, e.g. when an explosion happens, and decreases linearly by Update.
+// The offsets are proportional to the square of the trauma, so a small trauma causes a subtle shake.
+//
+// The offsets follow a smooth noise instead of random values, so the shake is not jittery.
+// A Shake is deterministic: the same options and the same calls produce the same offsets.
+type Shake struct {
+	maxOffset float64
+	maxAngle  float64
+	decay     float64
+	frequency float64
+	seed      uint64
+
+	trauma float64
+	time   float64
+
+	dx  float64
+	dy  float64
+	rot float64
+}
+
+// NewShake creates a new Shake.
+//
+// If options is nil, the default options are used.
+func NewShake(options *ShakeOptions) *Shake {
+	if options == nil {
+		options = &ShakeOptions{}
+	}
+	s := &Shake{
+		maxOffset: options.MaxOffset,
+		maxAngle:  options.MaxAngle,
+		decay:     options.Decay,
+		frequency: options.Frequency,
+		seed:      options.Seed,
+	}
+	if s.maxOffset == 0 {
+		s.maxOffset = 16
+	}
+	if s.maxAngle == 0 {
+		s.maxAngle = 0.05
+	}
+	if s.decay == 0 {
+		s.decay = 0.02
+	}
+	if s.frequency == 0 {
+		s.frequency = 0.25
+	}
+	return s
+}
+
+// Add adds the trauma.
+// The result trauma is clamped to [0, 1].
+//
+// Add doesn't update the offsets. The offsets are updated at the next Update.
+func (s *Shake) Add(trauma float64) {
+	s.trauma = min(max(s.trauma+trauma, 0), 1)
+}
+
+// Trauma returns the current trauma in [0, 1].
+func (s *Shake) Trauma() float64 {
+	return s.trauma
+}
+
+// Update advances the shake by one tick, and updates the offsets.
+//
+// Update should be called once in each Game.Update.
+func (s *Shake) Update() {
+	s.time += s.frequency
+	shake := s.trauma * s.trauma
+	s.dx = s.maxOffset * shake * noise(s.seed, 0, s.time)
+	s.dy = s.maxOffset * shake * noise(s.seed, 1, s.time)
+	s.rot = s.maxAngle * shake * noise(s.seed, 2, s.time)
+	s.trauma = max(s.trauma-s.decay, 0)
+}
+
+// Offset returns the current translation in pixels and the current rotation in radians.
+//
+// The translation is in [-MaxOffset, MaxOffset], and the rotation is in [-MaxAngle, MaxAngle].
+// All the values are 0 when the trauma was 0 at the last Update.
+func (s *Shake) Offset() (dx, dy, rot float64) {
+	return s.dx, s.dy, s.rot
+}
+
+// Apply applies the current offsets to geoM.
+// The rotation is around (originX, originY), which is usually the center of the screen.
+//
+// Apply should be called after the camera's transformation is applied to geoM.
+func (s *Shake) Apply(geoM *ebiten.GeoM, originX, originY float64) {
+	geoM.Translate(-originX, -originY)
+	geoM.Rotate(s.rot)
+	geoM.Translate(originX+s.dx, originY+s.dy)
+}
+
+// noise returns a smooth 1D value noise in [-1, 1] at x.
+// channel selects an independent noise for the same seed.
+//
+// Value noise is used instead of gradient noise, as gradient noise is always 0 at the lattice points.
+// With gradient noise, the shake would snap to the center whenever x is an integer.
+func noise(seed uint64, channel uint64, x float64) float64 {
+	x0 := math.Floor(x)
+	f := x - x0
+	i := int64(x0)
+	v0 := latticeValue(seed, channel, i)
+	v1 := latticeValue(seed, channel, i+1)
+	// Use the quintic fade curve so that the derivative is continuous at the lattice points.
+	t := f * f * f * (f*(f*6-15) + 10)
+	return v0 + (v1-v0)*t
+}
+
+// latticeValue returns a pseudo-random value in [-1, 1] for the lattice point i.
+func latticeValue(seed uint64, channel uint64, i int64) float64 {
+	// SplitMix64
+	z := seed + channel*0x9e3779b97f4a7c15 + uint64(i)*0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return float64(z>>11)/float64(1<<53)*2 - 1
+}
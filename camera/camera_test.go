@@ -0,0 +1,145 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package camera_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/camera"
+)
+
+func TestShakeDecay(t *testing.T) {
+	s := camera.NewShake(&camera.ShakeOptions{
+		Decay: 0.125,
+	})
+	if dx, dy, rot := s.Offset(); dx != 0 || dy != 0 || rot != 0 {
+		t.Errorf("Offset without trauma: got: (%v, %v, %v), want: (0, 0, 0)", dx, dy, rot)
+	}
+
+	s.Add(2)
+	if got, want := s.Trauma(), 1.0; got != want {
+		t.Errorf("Trauma: got: %v, want: %v", got, want)
+	}
+
+	var moved bool
+	for i := 0; i < 8; i++ {
+		s.Update()
+		if dx, dy, _ := s.Offset(); dx != 0 || dy != 0 {
+			moved = true
+		}
+	}
+	if !moved {
+		t.Errorf("the shake must move while the trauma is positive")
+	}
+	if got, want := s.Trauma(), 0.0; got != want {
+		t.Errorf("Trauma after 8 ticks: got: %v, want: %v", got, want)
+	}
+
+	s.Update()
+	if dx, dy, rot := s.Offset(); dx != 0 || dy != 0 || rot != 0 {
+		t.Errorf("Offset after the trauma decays: got: (%v, %v, %v), want: (0, 0, 0)", dx, dy, rot)
+	}
+}
+
+func TestShakeRangeAndSmoothness(t *testing.T) {
+	const (
+		maxOffset = 10
+		maxAngle  = 0.1
+	)
+	s := camera.NewShake(&camera.ShakeOptions{
+		MaxOffset: maxOffset,
+		MaxAngle:  maxAngle,
+		Frequency: 0.05,
+	})
+
+	var prevDX, prevDY float64
+	for i := 0; i < 1000; i++ {
+		s.Add(1)
+		s.Update()
+		dx, dy, rot := s.Offset()
+		if math.Abs(dx) > maxOffset || math.Abs(dy) > maxOffset {
+			t.Fatalf("tick %d: the translation (%v, %v) must be in [-%v, %v]", i, dx, dy, maxOffset, maxOffset)
+		}
+		if math.Abs(rot) > maxAngle {
+			t.Fatalf("tick %d: the rotation %v must be in [-%v, %v]", i, rot, maxAngle, maxAngle)
+		}
+		// The noise is smooth, so the offsets don't jump between ticks.
+		if i > 0 && (math.Abs(dx-prevDX) > maxOffset/4 || math.Abs(dy-prevDY) > maxOffset/4) {
+			t.Fatalf("tick %d: the translation jumped from (%v, %v) to (%v, %v)", i, prevDX, prevDY, dx, dy)
+		}
+		prevDX, prevDY = dx, dy
+	}
+}
+
+func TestShakeNoSnapToCenter(t *testing.T) {
+	for _, freq := range []float64{0.25, 0.5, 1, 2} {
+		s := camera.NewShake(&camera.ShakeOptions{
+			Decay:     1.0 / 1024,
+			Frequency: freq,
+		})
+		s.Add(1)
+		for i := 0; i < 64; i++ {
+			s.Update()
+			// The noise must not be 0 at the lattice points, or the shake snaps to the center periodically.
+			if dx, dy, rot := s.Offset(); dx == 0 && dy == 0 && rot == 0 {
+				t.Errorf("frequency: %v, tick: %d: the shake must not snap to the center", freq, i)
+			}
+		}
+	}
+}
+
+func TestShakeDeterministic(t *testing.T) {
+	s0 := camera.NewShake(&camera.ShakeOptions{Seed: 1})
+	s1 := camera.NewShake(&camera.ShakeOptions{Seed: 1})
+	s2 := camera.NewShake(&camera.ShakeOptions{Seed: 2})
+
+	var different bool
+	for i := 0; i < 10; i++ {
+		for _, s := range []*camera.Shake{s0, s1, s2} {
+			s.Add(0.5)
+			s.Update()
+		}
+		dx0, dy0, rot0 := s0.Offset()
+		dx1, dy1, rot1 := s1.Offset()
+		if dx0 != dx1 || dy0 != dy1 || rot0 != rot1 {
+			t.Errorf("tick %d: the shakes with the same seed must be the same: (%v, %v, %v) vs (%v, %v, %v)", i, dx0, dy0, rot0, dx1, dy1, rot1)
+		}
+		if dx2, dy2, _ := s2.Offset(); dx0 != dx2 || dy0 != dy2 {
+			different = true
+		}
+	}
+	if !different {
+		t.Errorf("the shakes with different seeds must be different")
+	}
+}
+
+func TestShakeApply(t *testing.T) {
+	s := camera.NewShake(nil)
+	s.Add(1)
+	s.Update()
+	dx, dy, _ := s.Offset()
+
+	const ox, oy = 160, 120
+	var g ebiten.GeoM
+	s.Apply(&g, ox, oy)
+
+	// The origin is not rotated, and only translated.
+	x, y := g.Apply(ox, oy)
+	if math.Abs(x-(ox+dx)) > 1e-9 || math.Abs(y-(oy+dy)) > 1e-9 {
+		t.Errorf("got: (%v, %v), want: (%v, %v)", x, y, ox+dx, oy+dy)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// ApplyColorGrade remaps the colors of the image with the given 3D color lookup table (LUT).
+//
+// lut represents a N*N*N color cube unrolled horizontally into N slices.
+// The size of lut must be (N*N, N), e.g. 256x16 for a 16x16x16 LUT.
+// The i-th slice from the left corresponds to the blue value i/(N-1).
+// In a slice, the x and y positions correspond to the red and green values respectively.
+// The colors are looked up with straight (non-premultiplied) alpha,
+// and colors between the cells are interpolated trilinearly.
+// Any N works, e.g. 16 and 32.
+//
+// intensity is a blend rate between the original colors (0) and the graded colors (1).
+//
+// If the size of lut is invalid, ApplyColorGrade panics.
+//
+// When the image i is disposed, ApplyColorGrade does nothing.
+// When lut is disposed, ApplyColorGrade panics.
+func (i *Image) ApplyColorGrade(lut *Image, intensity float64) {
+	i.copyCheck()
+
+	if lut.isDisposed() {
+		panic("ebiten: the given LUT to ApplyColorGrade must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	ls := lut.Bounds().Size()
+	if ls.Y < 2 || ls.X != ls.Y*ls.Y {
+		panic(fmt.Sprintf("ebiten: the LUT size must be (N*N, N) with N >= 2 but was (%d, %d)", ls.X, ls.Y))
+	}
+
+	b := i.Bounds()
+	tmp := theImagePool.get(b.Dx(), b.Dy())
+	defer theImagePool.put(tmp)
+
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(-b.Min.X), float64(-b.Min.Y))
+	op.Blend = BlendCopy
+	tmp.DrawImage(i, op)
+
+	shader := effectShader("colorgrade")
+	sop := &DrawTrianglesShaderOptions{}
+	sop.Blend = BlendCopy
+	sop.Images[0] = tmp
+	sop.Images[1] = lut
+	sop.Uniforms = map[string]any{
+		builtinshader.UniformIntensity: float32(intensity),
+	}
+	vs, is := quadVertices(b, tmp.Bounds())
+	i.DrawTrianglesShader(vs[:], is, shader, sop)
+}
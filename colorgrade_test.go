@@ -0,0 +1,130 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// newInvertingLUT returns a 2x2x2 LUT that inverts colors.
+func newInvertingLUT() *ebiten.Image {
+	const n = 2
+	lut := ebiten.NewImage(n*n, n)
+	for b := 0; b < n; b++ {
+		for g := 0; g < n; g++ {
+			for r := 0; r < n; r++ {
+				lut.Set(b*n+r, g, color.RGBA{R: byte(0xff * (1 - r)), G: byte(0xff * (1 - g)), B: byte(0xff * (1 - b)), A: 0xff})
+			}
+		}
+	}
+	return lut
+}
+
+// newIdentityLUT returns a n*n*n LUT that doesn't change colors.
+func newIdentityLUT(n int) *ebiten.Image {
+	lut := ebiten.NewImage(n*n, n)
+	for b := 0; b < n; b++ {
+		for g := 0; g < n; g++ {
+			for r := 0; r < n; r++ {
+				lut.Set(b*n+r, g, color.RGBA{
+					R: byte(math.Round(float64(0xff*r) / float64(n-1))),
+					G: byte(math.Round(float64(0xff*g) / float64(n-1))),
+					B: byte(math.Round(float64(0xff*b) / float64(n-1))),
+					A: 0xff,
+				})
+			}
+		}
+	}
+	return lut
+}
+
+func TestImageApplyColorGrade(t *testing.T) {
+	const w, h = 16, 16
+
+	for _, intensity := range []float64{0, 1} {
+		dst := ebiten.NewImage(w, h)
+		dst.Fill(color.RGBA{R: 0xff, A: 0xff})
+		dst.ApplyColorGrade(newInvertingLUT(), intensity)
+
+		want := color.RGBA{R: 0xff, A: 0xff}
+		if intensity == 1 {
+			want = color.RGBA{G: 0xff, B: 0xff, A: 0xff}
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				if got := dst.At(i, j).(color.RGBA); got != want {
+					t.Errorf("intensity: %v, dst.At(%d, %d): got: %v, want: %v", intensity, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageApplyColorGradeSubImage(t *testing.T) {
+	const w, h = 16, 16
+
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.RGBA{R: 0xff, A: 0xff})
+	dst.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image).ApplyColorGrade(newInvertingLUT(), 1)
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			want := color.RGBA{R: 0xff, A: 0xff}
+			if 4 <= i && i < 12 && 4 <= j && j < 12 {
+				want = color.RGBA{G: 0xff, B: 0xff, A: 0xff}
+			}
+			if got := dst.At(i, j).(color.RGBA); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageApplyColorGradeIdentity(t *testing.T) {
+	const w, h = 16, 16
+
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = byte(i * 0x11)
+			pix[idx+1] = byte(j * 0x11)
+			pix[idx+2] = byte((i*7 + j*3) % 0x100)
+			pix[idx+3] = 0xff
+		}
+	}
+
+	for _, n := range []int{16, 32} {
+		dst := ebiten.NewImage(w, h)
+		dst.WritePixels(pix)
+		dst.ApplyColorGrade(newIdentityLUT(n), 1)
+
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				idx := 4 * (j*w + i)
+				got := dst.At(i, j).(color.RGBA)
+				want := color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+				if !sameColors(got, want, 2) {
+					t.Errorf("n: %d, dst.At(%d, %d): got: %v, want: %v", n, i, j, got, want)
+				}
+			}
+		}
+	}
+}
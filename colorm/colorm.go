@@ -158,8 +158,9 @@ func uniforms(c ColorM) map[string]any {
 }
 
 type builtinShaderKey struct {
-	filter  builtinshader.Filter
-	address builtinshader.Address
+	filter       builtinshader.Filter
+	address      builtinshader.Address
+	linearColorM bool
 }
 
 var (
@@ -167,19 +168,25 @@ var (
 	builtinShadersM sync.Mutex
 )
 
-func builtinShader(filter builtinshader.Filter, address builtinshader.Address) *ebiten.Shader {
+func builtinShader(filter builtinshader.Filter, address builtinshader.Address, linearColorM bool) *ebiten.Shader {
 	builtinShadersM.Lock()
 	defer builtinShadersM.Unlock()
 
 	key := builtinShaderKey{
-		filter:  filter,
-		address: address,
+		filter:       filter,
+		address:      address,
+		linearColorM: linearColorM,
 	}
 	if s, ok := builtinShaders[key]; ok {
 		return s
 	}
 
-	src := builtinshader.ShaderSource(filter, address, true)
+	var src []byte
+	if linearColorM {
+		src = builtinshader.ShaderSourceWithLinearColorM(filter, address)
+	} else {
+		src = builtinshader.ShaderSource(filter, address, true)
+	}
 	s, err := ebiten.NewShader(src)
 	if err != nil {
 		panic(fmt.Sprintf("colorm: NewShader for a built-in shader failed: %v", err))
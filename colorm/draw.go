@@ -41,6 +41,16 @@ type DrawImageOptions struct {
 	// Filter is a type of texture filter.
 	// The default (zero) value is ebiten.FilterNearest.
 	Filter ebiten.Filter
+
+	// LinearColorSpace indicates whether the color matrix is applied in the linear color space.
+	//
+	// If LinearColorSpace is true, the source colors are decoded from sRGB to linear before the color matrix is applied,
+	// and encoded to sRGB after that.
+	// This makes adjustments like brightness, contrast and saturation more accurate.
+	// The extra conversions need power functions per pixel, and make the rendering slightly slower.
+	//
+	// The default (zero) value is false, which applies the color matrix to the sRGB colors as they are.
+	LinearColorSpace bool
 }
 
 // DrawImage draws src onto dst.
@@ -58,7 +68,7 @@ func DrawImage(dst, src *ebiten.Image, colorM ColorM, op *DrawImageOptions) {
 	opShader.Blend = op.Blend
 	opShader.Uniforms = uniforms(colorM)
 	opShader.Images[0] = src
-	s := builtinShader(builtinshader.Filter(op.Filter), builtinshader.AddressUnsafe)
+	s := builtinShader(builtinshader.Filter(op.Filter), builtinshader.AddressUnsafe, op.LinearColorSpace)
 	dst.DrawRectShader(src.Bounds().Dx(), src.Bounds().Dy(), s, opShader)
 }
 
@@ -97,6 +107,12 @@ type DrawTrianglesOptions struct {
 	//
 	// The default (zero) value is false.
 	AntiAlias bool
+
+	// LinearColorSpace indicates whether the color matrix is applied in the linear color space.
+	// See DrawImageOptions.LinearColorSpace.
+	//
+	// The default (zero) value is false.
+	LinearColorSpace bool
 }
 
 // DrawTriangles draws triangles onto dst.
@@ -125,6 +141,6 @@ func DrawTriangles(dst *ebiten.Image, vertices []ebiten.Vertex, indices []uint16
 	opShader.AntiAlias = op.AntiAlias
 	opShader.Uniforms = uniforms(colorM)
 	opShader.Images[0] = img
-	s := builtinShader(builtinshader.Filter(op.Filter), builtinshader.Address(op.Address))
+	s := builtinShader(builtinshader.Filter(op.Filter), builtinshader.Address(op.Address), op.LinearColorSpace)
 	dst.DrawTrianglesShader(vertices, indices, s, opShader)
 }
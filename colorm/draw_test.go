@@ -314,3 +314,42 @@ func TestColorScale(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestDrawImageLinearColorSpace(t *testing.T) {
+	srgbToLinear := func(c float64) float64 {
+		if c < 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	linearToSRGB := func(c float64) float64 {
+		if c < 0.0031308 {
+			return c * 12.92
+		}
+		return 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+
+	const w, h = 4, 4
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+
+	var cm colorm.ColorM
+	cm.Scale(0.5, 0.5, 0.5, 1)
+
+	for _, linear := range []bool{false, true} {
+		dst := ebiten.NewImage(w, h)
+		op := &colorm.DrawImageOptions{}
+		op.LinearColorSpace = linear
+		colorm.DrawImage(dst, src, cm, op)
+
+		v := byte(0x40)
+		if linear {
+			v = byte(math.Round(linearToSRGB(srgbToLinear(0x80/255.0)*0.5) * 0xff))
+		}
+		want := color.RGBA{R: v, G: v, B: v, A: 0xff}
+		got := dst.At(0, 0).(color.RGBA)
+		if !sameColors(got, want, 2) {
+			t.Errorf("linear: %t: got: %v, want: %v", linear, got, want)
+		}
+	}
+}
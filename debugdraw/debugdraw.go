@@ -0,0 +1,162 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debugdraw provides functions to draw simple shapes and texts for debugging,
+// e.g. to visualize collision shapes.
+//
+// All the functions draw with the same style, a 1-pixel stroke without anti-aliasing,
+// so that successive calls are batched into a small number of draw calls.
+//
+// The functions do nothing when debug drawing is disabled by SetEnabled(false).
+package debugdraw
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	strokeWidth = 1
+
+	// arrowHeadLength is the length of an arrow head in pixels.
+	arrowHeadLength = 6
+)
+
+var disabled atomic.Bool
+
+// SetEnabled enables or disables debug drawing.
+// The default value is true.
+//
+// SetEnabled is concurrent-safe.
+func SetEnabled(enabled bool) {
+	disabled.Store(!enabled)
+}
+
+// IsEnabled reports whether debug drawing is enabled.
+//
+// IsEnabled is concurrent-safe.
+func IsEnabled() bool {
+	return !disabled.Load()
+}
+
+// DrawRect draws the outline of the rectangle whose upper-left corner is (x, y).
+func DrawRect(dst *ebiten.Image, x, y, width, height float32, clr color.Color) {
+	if !IsEnabled() {
+		return
+	}
+	vector.StrokeRect(dst, x, y, width, height, strokeWidth, clr, false)
+}
+
+// DrawCircle draws the outline of the circle whose center is (cx, cy).
+func DrawCircle(dst *ebiten.Image, cx, cy, r float32, clr color.Color) {
+	if !IsEnabled() {
+		return
+	}
+	vector.StrokeCircle(dst, cx, cy, r, strokeWidth, clr, false)
+}
+
+// DrawArrow draws an arrow from (x0, y0) to (x1, y1).
+func DrawArrow(dst *ebiten.Image, x0, y0, x1, y1 float32, clr color.Color) {
+	if !IsEnabled() {
+		return
+	}
+	vector.StrokeLine(dst, x0, y0, x1, y1, strokeWidth, clr, false)
+
+	dx, dy := x1-x0, y1-y0
+	l := float32(math.Hypot(float64(dx), float64(dy)))
+	if l == 0 {
+		return
+	}
+	// Keep the head shorter than the half of the arrow.
+	h := min(arrowHeadLength, l/2)
+	ux, uy := dx/l, dy/l
+	// The head lines are 30 degrees from the shaft.
+	const (
+		cos30 = 0.8660254
+		sin30 = 0.5
+	)
+	vector.StrokeLine(dst, x1, y1, x1-h*(ux*cos30-uy*sin30), y1-h*(uy*cos30+ux*sin30), strokeWidth, clr, false)
+	vector.StrokeLine(dst, x1, y1, x1-h*(ux*cos30+uy*sin30), y1-h*(uy*cos30-ux*sin30), strokeWidth, clr, false)
+}
+
+// DrawText draws the string str at (x, y) with the debug font of ebitenutil.DebugPrintAt in the given color.
+func DrawText(dst *ebiten.Image, str string, x, y int, clr color.Color) {
+	if !IsEnabled() {
+		return
+	}
+
+	textImageM.Lock()
+	defer textImageM.Unlock()
+
+	// The debug font is white. Render the text to a scratch image once, and then draw it with the color.
+	w, h := textSize(str)
+	if w == 0 || h == 0 {
+		return
+	}
+	if textImage == nil || textImage.Bounds().Dx() < w || textImage.Bounds().Dy() < h {
+		if textImage != nil {
+			textImage.Deallocate()
+		}
+		var pw, ph int
+		if textImage != nil {
+			pw, ph = textImage.Bounds().Dx(), textImage.Bounds().Dy()
+		}
+		textImage = ebiten.NewImage(max(w, pw), max(h, ph))
+	}
+	textImage.Clear()
+	ebitenutil.DebugPrintAt(textImage, str, 0, 0)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(clr)
+	dst.DrawImage(textImage.SubImage(image.Rect(0, 0, w, h)).(*ebiten.Image), op)
+}
+
+const (
+	// debugFontWidth and debugFontHeight are the glyph size of the debug font.
+	debugFontWidth  = 6
+	debugFontHeight = 16
+)
+
+var (
+	textImage  *ebiten.Image
+	textImageM sync.Mutex
+)
+
+// textSize returns the size of the region where ebitenutil.DebugPrintAt renders str at (0, 0).
+func textSize(str string) (int, int) {
+	var maxCols, cols int
+	lines := 1
+	for _, r := range str {
+		if r == '\n' {
+			cols = 0
+			lines++
+			continue
+		}
+		cols++
+		maxCols = max(maxCols, cols)
+	}
+	if maxCols == 0 {
+		return 0, 0
+	}
+	// DebugPrintAt renders the text 1 pixel right.
+	return maxCols*debugFontWidth + 1, lines * debugFontHeight
+}
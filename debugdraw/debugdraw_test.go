@@ -0,0 +1,77 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugdraw_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/debugdraw"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func TestDrawTextColor(t *testing.T) {
+	dst := ebiten.NewImage(64, 64)
+	red := color.RGBA{R: 0xff, A: 0xff}
+	debugdraw.DrawText(dst, "#\n#", 8, 8, red)
+
+	var count int
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			if got.G != 0 || got.B != 0 {
+				t.Fatalf("dst.At(%d, %d): got: %v, want: a red or black color", i, j, got)
+			}
+			if got == red {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("no red pixels were rendered")
+	}
+
+	// The text must be rendered in the region starting at (8, 8).
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			if i >= 8 && j >= 8 && i < 8+6+1 && j < 8+2*16 {
+				continue
+			}
+			if got := dst.At(i, j).(color.RGBA); got != (color.RGBA{}) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: transparent", i, j, got)
+			}
+		}
+	}
+}
+
+func TestDrawTextDisabled(t *testing.T) {
+	debugdraw.SetEnabled(false)
+	defer debugdraw.SetEnabled(true)
+
+	dst := ebiten.NewImage(32, 32)
+	debugdraw.DrawText(dst, "#", 0, 0, color.White)
+	for j := 0; j < 32; j++ {
+		for i := 0; i < 32; i++ {
+			if got := dst.At(i, j).(color.RGBA); got != (color.RGBA{}) {
+				t.Fatalf("dst.At(%d, %d): got: %v, want: transparent", i, j, got)
+			}
+		}
+	}
+}
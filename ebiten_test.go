@@ -15,6 +15,7 @@
 package ebiten_test
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -30,3 +31,70 @@ func TestScreenSizeInFullscreen(t *testing.T) {
 		t.Errorf("h must be positive but not: %d", h)
 	}
 }
+
+func TestScreenClearColor(t *testing.T) {
+	defer ebiten.SetScreenClearColor(nil)
+
+	if got := ebiten.ScreenClearColor(); got != nil {
+		t.Errorf("got: %v, want: nil", got)
+	}
+
+	clr := color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}
+	ebiten.SetScreenClearColor(clr)
+	got := ebiten.ScreenClearColor()
+	if got == nil {
+		t.Fatalf("got: nil, want: %v", clr)
+	}
+	if got, want := color.RGBAModel.Convert(got), color.Color(clr); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	ebiten.SetScreenClearColor(nil)
+	if got := ebiten.ScreenClearColor(); got != nil {
+		t.Errorf("got: %v, want: nil", got)
+	}
+}
+
+func TestScreenClearColorBorder(t *testing.T) {
+	defer ebiten.SetScreenClearColor(nil)
+
+	const (
+		w       = 16
+		h       = 16
+		offsetX = 4
+		offsetY = 2
+	)
+
+	offscreen := ebiten.NewImage(w-2*offsetX, h-2*offsetY)
+	offscreenClr := color.RGBA{R: 0xff, A: 0xff}
+	offscreen.Fill(offscreenClr)
+
+	for _, clr := range []color.Color{nil, color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}} {
+		ebiten.SetScreenClearColor(clr)
+
+		screen := ebiten.NewImage(w, h)
+		screen.Fill(color.White)
+		ebiten.ClearScreenForTesting(screen)
+
+		var geoM ebiten.GeoM
+		geoM.Translate(offsetX, offsetY)
+		ebiten.DefaultDrawFinalScreen(screen, offscreen, geoM)
+
+		var borderClr color.RGBA
+		if clr != nil {
+			borderClr = color.RGBAModel.Convert(clr).(color.RGBA)
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := screen.At(i, j)
+				want := borderClr
+				if offsetX <= i && i < w-offsetX && offsetY <= j && j < h-offsetY {
+					want = offscreenClr
+				}
+				if got != want {
+					t.Errorf("clear color %v: screen.At(%d, %d): got: %v, want: %v", clr, i, j, got, want)
+				}
+			}
+		}
+	}
+}
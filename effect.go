@@ -0,0 +1,46 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+)
+
+// quadVertices returns vertices and indices to render the source region src to the destination region dst.
+func quadVertices(dst, src image.Rectangle) ([4]Vertex, []uint16) {
+	vs := [4]Vertex{
+		{
+			DstX: float32(dst.Min.X), DstY: float32(dst.Min.Y),
+			SrcX: float32(src.Min.X), SrcY: float32(src.Min.Y),
+			ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+		},
+		{
+			DstX: float32(dst.Max.X), DstY: float32(dst.Min.Y),
+			SrcX: float32(src.Max.X), SrcY: float32(src.Min.Y),
+			ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+		},
+		{
+			DstX: float32(dst.Min.X), DstY: float32(dst.Max.Y),
+			SrcX: float32(src.Min.X), SrcY: float32(src.Max.Y),
+			ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+		},
+		{
+			DstX: float32(dst.Max.X), DstY: float32(dst.Max.Y),
+			SrcX: float32(src.Max.X), SrcY: float32(src.Max.Y),
+			ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1,
+		},
+	}
+	return vs, []uint16{0, 1, 2, 1, 2, 3}
+}
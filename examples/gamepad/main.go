@@ -136,11 +136,12 @@ var standardButtonToString = map[ebiten.StandardGamepadButton]string{
 	ebiten.StandardGamepadButtonLeftLeft:         "LL",
 	ebiten.StandardGamepadButtonLeftTop:          "LT",
 	ebiten.StandardGamepadButtonCenterCenter:     "CC",
+	ebiten.StandardGamepadButtonTouchpad:         "TP",
 }
 
 func standardMap(id ebiten.GamepadID) string {
 	m := `       [FBL ]                    [FBR ]
-       [FTL ]                    [FTR ]
+       [FTL ]       [TP  ]       [FTR ]
 
        [LT  ]       [CC  ]       [RT  ]
     [LL  ][LR  ] [CL  ][CR  ] [RL  ][RR  ]
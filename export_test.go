@@ -14,12 +14,97 @@
 
 package ebiten
 
-import "github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+import (
+	"io"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
 
 var (
 	ImageToBytes = imageToBytes
 )
 
 func BuiltinShader(filter builtinshader.Filter, address builtinshader.Address, useColorM bool) *Shader {
-	return builtinShader(filter, address, useColorM)
+	return builtinShader(filter, address, useColorM, true)
+}
+
+func AppendInputEventForTesting(event InputEvent) {
+	theInputState.update(func(state *ui.InputState) {
+		state.AppendEvent(ui.InputEvent{
+			Type:        ui.InputEventType(event.Type),
+			Key:         ui.Key(event.Key),
+			MouseButton: ui.MouseButton(event.MouseButton),
+			Pressed:     event.Pressed,
+			Time:        event.Time,
+		})
+	})
+}
+
+func ResetInputEventsForTesting() {
+	theInputState.update(func(state *ui.InputState) {
+		state.Events = state.Events[:0]
+	})
+}
+
+func AppendCursorPositionForTesting(x, y float64) {
+	theInputState.update(func(state *ui.InputState) {
+		state.CursorHistory = append(state.CursorHistory, ui.CursorPosition{X: x, Y: y})
+	})
+}
+
+func ResetCursorPositionHistoryForTesting() {
+	theInputState.update(func(state *ui.InputState) {
+		state.CursorHistory = state.CursorHistory[:0]
+	})
+}
+
+func SetGameScreenForTesting(img *Image) (restore func()) {
+	old := gameScreenImage.Load()
+	gameScreenImage.Store(img.image)
+	return func() {
+		gameScreenImage.Store(old)
+	}
+}
+
+func ClearScreenForTesting(img *Image) {
+	ui.Get().ClearScreenForTesting(img.image)
+}
+
+const MaxPooledImages = maxPooledImages
+
+func PooledImageCountForTesting() int {
+	theImagePool.m.Lock()
+	defer theImagePool.m.Unlock()
+	return len(theImagePool.images)
+}
+
+func GetPooledImageForTesting(width, height int) *Image {
+	return theImagePool.get(width, height)
+}
+
+func PutPooledImageForTesting(img *Image) {
+	theImagePool.put(img)
+}
+
+type InputHistory struct {
+	h inputHistory
+}
+
+func (i *InputHistory) SetSize(ticks int) {
+	i.h.setSize(ticks)
+}
+
+func (i *InputHistory) Record(keys []Key, cursorX, cursorY float64) {
+	var state ui.InputState
+	for _, k := range keys {
+		state.KeyPressed[k] = true
+	}
+	state.CursorX = cursorX
+	state.CursorY = cursorY
+	i.h.record(&state)
+}
+
+func (i *InputHistory) Dump(w io.Writer) error {
+	return i.h.dump(w)
 }
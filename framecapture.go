@@ -0,0 +1,97 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+)
+
+// FrameCaptureFormat represents the format of frames written by FrameCapturer.
+type FrameCaptureFormat int
+
+const (
+	// FrameCaptureFormatRaw writes the pixels as raw RGBA pre-multiplied alpha values, 4 bytes per pixel.
+	// The rows are written from the top to the bottom without padding.
+	FrameCaptureFormatRaw FrameCaptureFormat = iota
+
+	// FrameCaptureFormatPNG writes the pixels as a PNG image.
+	FrameCaptureFormatPNG
+)
+
+// FrameCapturer writes images' pixels to io.Writers, e.g. for a screen recorder.
+//
+// FrameCapturer reuses its internal buffers among Capture calls, so capturing frames repeatedly doesn't cause GC pressure.
+// A FrameCapturer must not be used from multiple goroutines at the same time.
+//
+// The zero value is a valid FrameCapturer writing raw frames.
+type FrameCapturer struct {
+	// Format is the format to write frames.
+	Format FrameCaptureFormat
+
+	pixels     []byte
+	pngEncoder png.Encoder
+	pngBuffers frameCapturerPNGBufferPool
+}
+
+// Capture reads the pixels of img and writes them to w in the format c.Format.
+//
+// For example, to capture the game screen, call Capture with the screen image at the end of Draw.
+//
+// Capture can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (c *FrameCapturer) Capture(w io.Writer, img *Image) error {
+	c.pixels = img.PixelsInto(c.pixels)
+	b := img.Bounds()
+	stride := 4 * b.Dx()
+
+	switch c.Format {
+	case FrameCaptureFormatRaw:
+		// Write the pixels row by row so that a writer with a small buffer doesn't have to hold the entire frame.
+		for j := 0; j < b.Dy(); j++ {
+			if _, err := w.Write(c.pixels[j*stride : (j+1)*stride]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FrameCaptureFormatPNG:
+		// Wrap the pixels as an image.RGBA without copying them.
+		rgba := &image.RGBA{
+			Pix:    c.pixels,
+			Stride: stride,
+			Rect:   image.Rect(0, 0, b.Dx(), b.Dy()),
+		}
+		c.pngEncoder.BufferPool = &c.pngBuffers
+		return c.pngEncoder.Encode(w, rgba)
+	default:
+		return fmt.Errorf("ebiten: invalid frame capture format: %d", c.Format)
+	}
+}
+
+// frameCapturerPNGBufferPool is a png.EncoderBufferPool holding one buffer.
+type frameCapturerPNGBufferPool struct {
+	buffer *png.EncoderBuffer
+}
+
+func (p *frameCapturerPNGBufferPool) Get() *png.EncoderBuffer {
+	b := p.buffer
+	p.buffer = nil
+	return b
+}
+
+func (p *frameCapturerPNGBufferPool) Put(b *png.EncoderBuffer) {
+	p.buffer = b
+}
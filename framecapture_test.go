@@ -0,0 +1,90 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestFrameCapturer(t *testing.T) {
+	const w, h = 7, 5
+	img := ebiten.NewImage(w, h)
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (i + j*w)
+			pix[idx] = byte(i * 0x20)
+			pix[idx+1] = byte(j * 0x30)
+			pix[idx+2] = byte((i + j) * 0x10)
+			pix[idx+3] = 0xff
+		}
+	}
+	img.WritePixels(pix)
+
+	var c ebiten.FrameCapturer
+	for k := 0; k < 2; k++ {
+		var buf bytes.Buffer
+		c.Format = ebiten.FrameCaptureFormatRaw
+		if err := c.Capture(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), pix) {
+			t.Errorf("raw frame: got: %v, want: %v", buf.Bytes(), pix)
+		}
+
+		buf.Reset()
+		c.Format = ebiten.FrameCaptureFormatPNG
+		if err := c.Capture(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := decoded.Bounds().Size(), img.Bounds().Size(); got != want {
+			t.Fatalf("PNG frame size: got: %v, want: %v", got, want)
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := color.RGBAModel.Convert(decoded.At(i, j))
+				want := img.At(i, j)
+				if got != want {
+					t.Errorf("PNG frame (%d, %d): got: %v, want: %v", i, j, got, want)
+				}
+			}
+		}
+	}
+
+	// A sub-image is captured without the outside pixels.
+	sub := img.SubImage(image.Rect(2, 1, 5, 4)).(*ebiten.Image)
+	var buf bytes.Buffer
+	c.Format = ebiten.FrameCaptureFormatRaw
+	if err := c.Capture(&buf, sub); err != nil {
+		t.Fatal(err)
+	}
+	var want []byte
+	for j := 1; j < 4; j++ {
+		want = append(want, pix[4*(2+j*w):4*(5+j*w)]...)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("raw frame of the sub-image: got: %v, want: %v", buf.Bytes(), want)
+	}
+}
@@ -61,6 +61,7 @@ func (g *gameForUI) NewOffscreenImage(width, height int) *ui.Image {
 		imageType = atlas.ImageTypeVolatile
 	}
 	g.offscreen = newImage(image.Rect(0, 0, width, height), imageType)
+	gameScreenImage.Store(g.offscreen.image)
 	return g.offscreen.image
 }
 
@@ -94,10 +95,15 @@ func (g *gameForUI) Layout(outsideWidth, outsideHeight float64) (float64, float6
 }
 
 func (g *gameForUI) UpdateInputState(fn func(*ui.InputState)) {
-	theInputState.update(fn)
+	theInputState.update(func(state *ui.InputState) {
+		fn(state)
+		theInputHistory.record(state)
+	})
 }
 
 func (g *gameForUI) Update() error {
+	notifyDeviceScaleChange()
+	notifyWindowResize()
 	if err := g.game.Update(); err != nil {
 		return err
 	}
@@ -119,6 +125,7 @@ func (g *gameForUI) DrawFinalScreen(scale, offsetX, offsetY float64) {
 	var geoM GeoM
 	geoM.Scale(scale, scale)
 	geoM.Translate(offsetX, offsetY)
+	geoM = snapTranslation(geoM, PixelSnappingScreen)
 
 	if d, ok := g.game.(FinalScreenDrawer); ok {
 		d.DrawFinalScreen(g.screen, g.offscreen, geoM)
@@ -88,7 +88,8 @@ const (
 	StandardGamepadButtonLeftLeft         StandardGamepadButton = gamepaddb.StandardButtonLeftLeft
 	StandardGamepadButtonLeftRight        StandardGamepadButton = gamepaddb.StandardButtonLeftRight
 	StandardGamepadButtonCenterCenter     StandardGamepadButton = gamepaddb.StandardButtonCenterCenter
-	StandardGamepadButtonMax              StandardGamepadButton = StandardGamepadButtonCenterCenter
+	StandardGamepadButtonTouchpad         StandardGamepadButton = gamepaddb.StandardButtonTouchpad
+	StandardGamepadButtonMax              StandardGamepadButton = StandardGamepadButtonTouchpad
 )
 
 // StandardGamepadAxis represents a gamepad axis in the standard layout.
@@ -105,3 +106,38 @@ const (
 	StandardGamepadAxisRightStickVertical   StandardGamepadAxis = gamepaddb.StandardAxisRightStickVertical
 	StandardGamepadAxisMax                  StandardGamepadAxis = StandardGamepadAxisRightStickVertical
 )
+
+// GamepadConnectionType represents how a gamepad is connected to the device.
+type GamepadConnectionType = gamepad.ConnectionType
+
+// GamepadConnectionTypes
+const (
+	GamepadConnectionTypeUnknown  GamepadConnectionType = gamepad.ConnectionTypeUnknown
+	GamepadConnectionTypeWired    GamepadConnectionType = gamepad.ConnectionTypeWired
+	GamepadConnectionTypeWireless GamepadConnectionType = gamepad.ConnectionTypeWireless
+)
+
+// GamepadTrigger represents an adaptive trigger of a gamepad.
+type GamepadTrigger = gamepad.Trigger
+
+// GamepadTriggers
+const (
+	GamepadTriggerLeft  GamepadTrigger = gamepad.TriggerLeft
+	GamepadTriggerRight GamepadTrigger = gamepad.TriggerRight
+)
+
+// GamepadTriggerEffectType represents a type of force feedback of a gamepad's adaptive trigger.
+type GamepadTriggerEffectType = gamepad.TriggerEffectType
+
+// GamepadTriggerEffectTypes
+const (
+	// GamepadTriggerEffectTypeOff turns off the force feedback.
+	GamepadTriggerEffectTypeOff GamepadTriggerEffectType = gamepad.TriggerEffectTypeOff
+
+	// GamepadTriggerEffectTypeResistance makes the trigger resist from StartPosition to the end.
+	GamepadTriggerEffectTypeResistance GamepadTriggerEffectType = gamepad.TriggerEffectTypeResistance
+
+	// GamepadTriggerEffectTypeWeapon makes the trigger resist from StartPosition and snap at EndPosition,
+	// like pulling a trigger of a gun.
+	GamepadTriggerEffectTypeWeapon GamepadTriggerEffectType = gamepad.TriggerEffectTypeWeapon
+)
@@ -15,6 +15,7 @@
 package ebiten
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -27,6 +28,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/mipmap"
 	"github.com/hajimehoshi/ebiten/v2/internal/restorable"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -52,6 +54,12 @@ type Image struct {
 	// tmpUniforms must not be reused until ui.Image.Draw* is called.
 	tmpUniforms []uint32
 
+	// opaqueBounds is the cached result of OpaqueBounds.
+	// opaqueBounds is valid while opaqueBoundsCached is true and the image's modification count is opaqueBoundsModificationCount.
+	opaqueBounds                  image.Rectangle
+	opaqueBoundsCached            bool
+	opaqueBoundsModificationCount uint64
+
 	// Do not add a 'buffering' member that are resolved lazily.
 	// This tends to forget resolving the buffer easily (#2362).
 }
@@ -80,6 +88,10 @@ func (i *Image) isSubImage() bool {
 
 // Clear resets the pixels of the image into 0.
 //
+// If the image is a sub-image, Clear resets only the pixels in the sub-image's bounds,
+// and the other pixels of the original image are kept.
+// As with other drawing functions, the result is kept even after the graphics context is lost and restored.
+//
 // When the image is disposed, Clear does nothing.
 func (i *Image) Clear() {
 	i.Fill(color.Transparent)
@@ -142,6 +154,14 @@ type DrawImageOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// ColorMask specifies whether each of the red, green, blue and alpha channels of the destination is written,
+	// like glColorMask.
+	//
+	// Unlike glColorMask, if all the elements are false, which is the default, all the channels are written.
+	// This is for the zero value to keep the regular behavior.
+	// There is no way to mask all the channels. To write nothing, just skip drawing.
+	ColorMask [4]bool
+
 	// Filter is a type of texture filter.
 	// The default (zero) value is FilterNearest.
 	Filter Filter
@@ -155,6 +175,19 @@ type DrawImageOptions struct {
 	//
 	// The default (zero) value is false.
 	DisableMipmaps bool
+
+	// MipmapLevel specifies the mipmap level to use regardless of the scale by GeoM.
+	// The level n mipmap is the source image scaled by 1/2^n.
+	// This is useful for a custom level-of-detail system that needs a deterministic level.
+	//
+	// If MipmapLevel is 0 or negative, the level is selected automatically.
+	// MipmapLevel is ignored when Filter is not FilterLinear, when DisableMipmaps is true,
+	// or when mipmaps are disabled by SetMipmapsEnabled.
+	// MipmapLevel is capped by the source image's NewImageOptions.MaxMipmapLevel.
+	// If the source image is too small to have the level, the original image is used.
+	//
+	// The default (zero) value is 0.
+	MipmapLevel int
 }
 
 // adjustPosition converts the position in the *ebiten.Image coordinate to the *ui.Image coordinate.
@@ -246,6 +279,7 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.DisabledColorChannels = disabledColorChannels(options.ColorMask)
 	filter := builtinshader.Filter(options.Filter)
 
 	geoM := options.GeoM
@@ -265,12 +299,13 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	cr, cg, cb, ca = options.ColorScale.apply(cr, cg, cb, ca)
 	vs := i.ensureTmpVertices(4 * graphics.VertexFloatCount)
 	graphics.QuadVerticesFromSrcAndMatrix(vs, float32(sx0), float32(sy0), float32(sx1), float32(sy1), a, b, c, d, tx, ty, cr, cg, cb, ca)
+	snapVertices(vs, PixelSnappingSprites)
 	is := graphics.QuadIndices()
 
 	srcs := [graphics.ShaderSrcImageCount]*ui.Image{img.image}
 
 	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, builtinshader.AddressUnsafe, useColorM)
+	shader := builtinShader(filter, builtinshader.AddressUnsafe, useColorM, true)
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
 		var body [16]float32
@@ -289,10 +324,15 @@ func (i *Image) DrawImage(img *Image, options *DrawImageOptions) {
 	}
 
 	skipMipmap := options.DisableMipmaps
+	var mipmapLevel int
 	if !skipMipmap {
-		skipMipmap = canSkipMipmap(det, filter)
+		if options.MipmapLevel > 0 && filter == builtinshader.FilterLinear {
+			mipmapLevel = options.MipmapLevel
+		} else {
+			skipMipmap = canSkipMipmap(det, filter)
+		}
 	}
-	i.image.DrawTriangles(srcs, vs, is, blend, dr, [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, skipMipmap, false, hint)
+	i.image.DrawTriangles(srcs, vs, is, blend, dr, [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, skipMipmap, mipmapLevel, i.antialias(false), hint)
 }
 
 // overwritesDstRegion reports whether the given parameters overwrite the destination region completely.
@@ -366,6 +406,10 @@ const (
 
 	// AddressRepeat means that texture coordinates wrap to the other side of the texture.
 	AddressRepeat Address = Address(builtinshader.AddressRepeat)
+
+	// AddressMirroredRepeat means that texture coordinates wrap to the other side of the texture,
+	// and the texture is mirrored at every other repetition.
+	AddressMirroredRepeat Address = Address(builtinshader.AddressMirroredRepeat)
 )
 
 // FillRule is the rule whether an overlapped region is rendered with DrawTriangles(Shader).
@@ -441,6 +485,14 @@ type DrawTrianglesOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// ColorMask specifies whether each of the red, green, blue and alpha channels of the destination is written,
+	// like glColorMask.
+	//
+	// Unlike glColorMask, if all the elements are false, which is the default, all the channels are written.
+	// This is for the zero value to keep the regular behavior.
+	// There is no way to mask all the channels. To write nothing, just skip drawing.
+	ColorMask [4]bool
+
 	// Filter is a type of texture filter.
 	// The default (zero) value is FilterNearest.
 	Filter Filter
@@ -476,6 +528,16 @@ type DrawTrianglesOptions struct {
 	//
 	// The default (zero) value is false.
 	DisableMipmaps bool
+
+	// IgnoreVertexColors indicates whether the color values of the vertices are ignored.
+	// If IgnoreVertexColors is true, all the vertex colors are treated as (1, 1, 1, 1).
+	// ColorM is still applied.
+	//
+	// When the vertex colors are not used, IgnoreVertexColors can make rendering a little faster,
+	// as a simpler shader without color multiplications might be used.
+	//
+	// The default (zero) value is false.
+	IgnoreVertexColors bool
 }
 
 // MaxIndicesCount is the maximum number of indices for DrawTriangles and DrawTrianglesShader.
@@ -580,6 +642,7 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.DisabledColorChannels = disabledColorChannels(options.ColorMask)
 
 	address := builtinshader.Address(options.Address)
 	filter := builtinshader.Filter(options.Filter)
@@ -588,7 +651,21 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 
 	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
 	dst := i
-	if options.ColorScaleMode == ColorScaleModeStraightAlpha {
+	if options.IgnoreVertexColors {
+		// See comment below (#3103).
+		for i := range vertices {
+			dx, dy := dst.adjustPositionF32(vertices[i].DstX, vertices[i].DstY)
+			vs[i*graphics.VertexFloatCount] = dx
+			vs[i*graphics.VertexFloatCount+1] = dy
+			sx, sy := img.adjustPositionF32(vertices[i].SrcX, vertices[i].SrcY)
+			vs[i*graphics.VertexFloatCount+2] = sx
+			vs[i*graphics.VertexFloatCount+3] = sy
+			vs[i*graphics.VertexFloatCount+4] = cr
+			vs[i*graphics.VertexFloatCount+5] = cg
+			vs[i*graphics.VertexFloatCount+6] = cb
+			vs[i*graphics.VertexFloatCount+7] = ca
+		}
+	} else if options.ColorScaleMode == ColorScaleModeStraightAlpha {
 		// Avoid using `for i, v := range vertices` as adding `v` creates a copy from `vertices` unnecessarily on each loop (#3103).
 		for i := range vertices {
 			dx, dy := dst.adjustPositionF32(vertices[i].DstX, vertices[i].DstY)
@@ -618,10 +695,15 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 		}
 	}
 
+	snapVertices(vs, PixelSnappingTriangles)
+
 	srcs := [graphics.ShaderSrcImageCount]*ui.Image{img.image}
 
 	useColorM := !colorm.IsIdentity()
-	shader := builtinShader(filter, address, useColorM)
+	// When the vertex colors are ignored and the color matrix doesn't have a scale part,
+	// all the vertex colors are (1, 1, 1, 1) and the multiplication in the shader can be skipped.
+	useVertexColors := !options.IgnoreVertexColors || cr != 1 || cg != 1 || cb != 1 || ca != 1
+	shader := builtinShader(filter, address, useColorM, useVertexColors)
 	i.tmpUniforms = i.tmpUniforms[:0]
 	if useColorM {
 		var body [16]float32
@@ -637,7 +719,7 @@ func (i *Image) DrawTriangles32(vertices []Vertex, indices []uint32, img *Image,
 	if !skipMipmap {
 		skipMipmap = filter != builtinshader.FilterLinear
 	}
-	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), skipMipmap, options.AntiAlias, restorable.HintNone)
+	i.image.DrawTriangles(srcs, vs, indices, blend, i.adjustedBounds(), [graphics.ShaderSrcImageCount]image.Rectangle{img.adjustedBounds()}, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), skipMipmap, 0, i.antialias(options.AntiAlias), restorable.HintNone)
 }
 
 // DrawTrianglesShaderOptions represents options for DrawTrianglesShader.
@@ -653,6 +735,14 @@ type DrawTrianglesShaderOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// ColorMask specifies whether each of the red, green, blue and alpha channels of the destination is written,
+	// like glColorMask.
+	//
+	// Unlike glColorMask, if all the elements are false, which is the default, all the channels are written.
+	// This is for the zero value to keep the regular behavior.
+	// There is no way to mask all the channels. To write nothing, just skip drawing.
+	ColorMask [4]bool
+
 	// Uniforms is a set of uniform variables for the shader.
 	// The keys are the names of the uniform variables.
 	// The values must be a numeric type, or a slice or an array of a numeric type.
@@ -781,6 +871,7 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.DisabledColorChannels = disabledColorChannels(options.ColorMask)
 
 	vs := i.ensureTmpVertices(len(vertices) * graphics.VertexFloatCount)
 	dst := i
@@ -805,6 +896,7 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 		vs[i*graphics.VertexFloatCount+10] = vertices[i].Custom2
 		vs[i*graphics.VertexFloatCount+11] = vertices[i].Custom3
 	}
+	snapVertices(vs, PixelSnappingTriangles)
 
 	var imgs [graphics.ShaderSrcImageCount]*ui.Image
 	var imgSize image.Point
@@ -839,7 +931,7 @@ func (i *Image) DrawTrianglesShader32(vertices []Vertex, indices []uint32, shade
 	i.tmpUniforms = i.tmpUniforms[:0]
 	i.tmpUniforms = shader.appendUniforms(i.tmpUniforms, options.Uniforms)
 
-	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, options.AntiAlias, restorable.HintNone)
+	i.image.DrawTriangles(imgs, vs, indices, blend, i.adjustedBounds(), srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRule(options.FillRule), true, 0, i.antialias(options.AntiAlias), restorable.HintNone)
 }
 
 // DrawRectShaderOptions represents options for DrawRectShader.
@@ -864,6 +956,14 @@ type DrawRectShaderOptions struct {
 	// The default (zero) value is the regular alpha blending.
 	Blend Blend
 
+	// ColorMask specifies whether each of the red, green, blue and alpha channels of the destination is written,
+	// like glColorMask.
+	//
+	// Unlike glColorMask, if all the elements are false, which is the default, all the channels are written.
+	// This is for the zero value to keep the regular behavior.
+	// There is no way to mask all the channels. To write nothing, just skip drawing.
+	ColorMask [4]bool
+
 	// Uniforms is a set of uniform variables for the shader.
 	// The keys are the names of the uniform variables.
 	// The values must be a numeric type, or a slice or an array of a numeric type.
@@ -926,6 +1026,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 	} else {
 		blend = options.CompositeMode.blend().internalBlend()
 	}
+	blend.DisabledColorChannels = disabledColorChannels(options.ColorMask)
 
 	var imgs [graphics.ShaderSrcImageCount]*ui.Image
 	for i, img := range options.Images {
@@ -970,6 +1071,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		float32(srcRegions[0].Min.X), float32(srcRegions[0].Min.Y),
 		float32(srcRegions[0].Min.X+width), float32(srcRegions[0].Min.Y+height),
 		a, b, c, d, tx, ty, cr, cg, cb, ca)
+	snapVertices(vs, PixelSnappingSprites)
 	is := graphics.QuadIndices()
 
 	i.tmpUniforms = i.tmpUniforms[:0]
@@ -982,7 +1084,7 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 		hint = restorable.HintOverwriteDstRegion
 	}
 
-	i.image.DrawTriangles(imgs, vs, is, blend, dr, srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, true, false, hint)
+	i.image.DrawTriangles(imgs, vs, is, blend, dr, srcRegions, shader.shader, i.tmpUniforms, graphicsdriver.FillRuleFillAll, true, 0, i.antialias(false), hint)
 }
 
 // SubImage returns an image representing the portion of the image p visible through r.
@@ -996,6 +1098,11 @@ func (i *Image) DrawRectShader(width, height int, shader *Shader, options *DrawR
 // If a sub-image is used as a rendering source, the image is used as if it is a small image.
 // If a sub-image is used as a rendering destination, the region being rendered is clipped.
 //
+// With FilterLinear or FilterPixelated, DrawImage can sample texels just outside a sub-image,
+// i.e. texels of an adjacent region of the same image.
+// To avoid bleeding between adjacent regions, e.g. packed sprites or glyphs, leave a transparent gap between them,
+// or draw the sub-image by DrawTriangles with AddressClampToZero.
+//
 // Successive uses of multiple various regions as rendering destination is still efficient
 // when all the underlying images are the same, but some platforms like browsers might not work efficiently.
 func (i *Image) SubImage(r image.Rectangle) image.Image {
@@ -1075,6 +1182,77 @@ func (i *Image) ReadPixels(pixels []byte) {
 	i.image.ReadPixels(pixels, i.adjustedBounds())
 }
 
+// PixelsInto reads the image's pixels into dst, and returns the slice holding the pixels.
+// The format is the same as ReadPixels.
+//
+// If the capacity of dst is enough, PixelsInto reuses dst's underlying array and doesn't allocate.
+// Otherwise, PixelsInto allocates a new slice.
+// The length of the returned slice is always 4 * (bounds width) * (bounds height).
+//
+// PixelsInto is useful to read pixels repeatedly, e.g. capturing frames, without GC pressure.
+//
+// PixelsInto can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) PixelsInto(dst []byte) []byte {
+	i.copyCheck()
+
+	b := i.Bounds()
+	n := 4 * b.Dx() * b.Dy()
+	if cap(dst) < n {
+		dst = make([]byte, n)
+	}
+	dst = dst[:n]
+	i.ReadPixels(dst)
+	return dst
+}
+
+// OpaqueBounds returns the smallest rectangle containing all the non-transparent pixels of the image.
+// The returned rectangle is in the same coordinate as Bounds.
+//
+// OpaqueBounds returns an empty rectangle if all the pixels are transparent or the image is disposed.
+//
+// OpaqueBounds reads pixels from GPU to system memory, which means that OpaqueBounds is slow.
+// The result is cached until the image is modified, so calling OpaqueBounds repeatedly for an unchanged image is cheap.
+//
+// OpaqueBounds can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) OpaqueBounds() image.Rectangle {
+	if i.isDisposed() {
+		return image.Rectangle{}
+	}
+
+	count := i.image.ModificationCount()
+	if i.opaqueBoundsCached && i.opaqueBoundsModificationCount == count {
+		return i.opaqueBounds
+	}
+
+	i.opaqueBounds = i.opaqueBoundsFromPixels()
+	i.opaqueBoundsCached = true
+	i.opaqueBoundsModificationCount = count
+	return i.opaqueBounds
+}
+
+func (i *Image) opaqueBoundsFromPixels() image.Rectangle {
+	b := i.Bounds()
+	pix := i.PixelsInto(nil)
+
+	minX, minY := b.Dx(), b.Dy()
+	maxX, maxY := -1, -1
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			if pix[4*(y*b.Dx()+x)+3] == 0 {
+				continue
+			}
+			minX = min(minX, x)
+			minY = min(minY, y)
+			maxX = max(maxX, x)
+			maxY = max(maxY, y)
+		}
+	}
+	if maxX < 0 {
+		return image.Rectangle{}
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1).Add(b.Min)
+}
+
 // At returns the color of the image at (x, y).
 //
 // At implements the standard image.Image's At.
@@ -1222,6 +1400,40 @@ func (i *Image) WritePixels(pixels []byte) {
 	i.image.WritePixels(pixels, i.adjustedBounds())
 }
 
+// WritePixelsOptions represents options for WritePixelsWithOptions.
+type WritePixelsOptions struct {
+	// PremultiplyAlpha represents whether the given pixels are straight (non-premultiplied) alpha values.
+	// If PremultiplyAlpha is true, the pixels are converted to premultiplied alpha values when written.
+	// Fully transparent pixels are written as zero colors.
+	//
+	// The default (zero) value is false.
+	PremultiplyAlpha bool
+}
+
+// WritePixelsWithOptions replaces the pixels of the image with the given options.
+//
+// If options is nil or options.PremultiplyAlpha is false, WritePixelsWithOptions works exactly like WritePixels.
+//
+// The given pixels slice is never modified.
+func (i *Image) WritePixelsWithOptions(pixels []byte, options *WritePixelsOptions) {
+	if options == nil || !options.PremultiplyAlpha {
+		i.WritePixels(pixels)
+		return
+	}
+
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return
+	}
+
+	// Convert the pixels before passing them to the internal image so that the pixels recorded for restoring are
+	// already premultiplied.
+	pix := make([]byte, len(pixels))
+	graphics.PremultiplyAlpha(pix, pixels)
+	i.image.WritePixels(pix, i.adjustedBounds())
+}
+
 // ReplacePixels replaces the pixels of the image.
 //
 // Deprecated: as of v2.4. Use WritePixels instead.
@@ -1251,6 +1463,18 @@ type NewImageOptions struct {
 	// A regular image is a part of an internal texture atlas, and locating them is done automatically in Ebitengine.
 	// Unmanaged is useful when you want finer controls over the image for performance and memory reasons.
 	Unmanaged bool
+
+	// MaxMipmapLevel is the maximum mipmap level used when the image is rendered with FilterLinear and shrunk.
+	// The level n mipmap is an image scaled by 1/2^n.
+	// Capping the level reduces the memory for mipmaps, especially for very big images.
+	//
+	// If MaxMipmapLevel is negative, no mipmaps are generated for the image.
+	// MaxMipmapLevel is capped at 6.
+	//
+	// The default (zero) value means the default maximum level, 6.
+	//
+	// See also SetMipmapsEnabled to disable mipmaps for all the images.
+	MaxMipmapLevel int
 }
 
 // NewImageWithOptions returns an empty image with the given bounds and the options.
@@ -1273,7 +1497,61 @@ func NewImageWithOptions(bounds image.Rectangle, options *NewImageOptions) *Imag
 	if options != nil && options.Unmanaged {
 		imageType = atlas.ImageTypeUnmanaged
 	}
-	return newImage(bounds, imageType)
+	img := newImage(bounds, imageType)
+	if options != nil && options.MaxMipmapLevel != 0 {
+		img.image.SetMaxMipmapLevel(options.MaxMipmapLevel)
+	}
+	return img
+}
+
+// SetMipmapsEnabled enables or disables mipmaps for all the images.
+//
+// Mipmaps are generated automatically when an image is rendered with FilterLinear and shrunk.
+// Mipmaps make shrunk images smooth, but consume extra GPU memory and time to generate.
+// If your game never shrinks images, e.g. a pixel-art game, disabling mipmaps can save them.
+// While mipmaps are disabled, shrunk images with FilterLinear can look jaggy or flickery, especially when they are shrunk to less than half.
+//
+// To disable mipmaps for a specific image, use NewImageOptions.MaxMipmapLevel instead.
+//
+// The default state is true.
+//
+// SetMipmapsEnabled is concurrent-safe, but already generated mipmaps are not released immediately.
+func SetMipmapsEnabled(enabled bool) {
+	mipmap.SetEnabled(enabled)
+}
+
+// IsMipmapsEnabled reports whether mipmaps are enabled for all the images.
+//
+// IsMipmapsEnabled is concurrent-safe.
+func IsMipmapsEnabled() bool {
+	return mipmap.IsEnabled()
+}
+
+// RepackImages moves the given images into new texture atlases where only the given images are packed tightly.
+//
+// Ebitengine packs small images into big texture atlases automatically.
+// After many images are disposed, the remaining images might be packed poorly, which can increase draw calls.
+// RepackImages is useful to repack a known set of images, e.g., a level's tileset after streaming the level is done.
+// The contents of the images are kept.
+//
+// For a sub-image, the whole original image is repacked.
+// Images that are not on a texture atlas, like images created with NewImageOptions.Unmanaged, are ignored.
+//
+// RepackImages returns an error if any of the images is nil or disposed. In this case, nothing is repacked.
+func RepackImages(imgs ...*Image) error {
+	uiImages := make([]*ui.Image, 0, len(imgs))
+	for _, img := range imgs {
+		if img == nil {
+			return errors.New("ebiten: a nil image cannot be repacked")
+		}
+		img.copyCheck()
+		if img.isDisposed() {
+			return errors.New("ebiten: a disposed image cannot be repacked")
+		}
+		uiImages = append(uiImages, img.image)
+	}
+	ui.RepackImages(uiImages)
+	return nil
 }
 
 func newImage(bounds image.Rectangle, imageType atlas.ImageType) *Image {
@@ -316,6 +316,53 @@ func TestImageWritePixelsNil(t *testing.T) {
 	img.WritePixels(nil)
 }
 
+func TestImageWritePixelsPremultiplyAlpha(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			idx := 4 * (j*w + i)
+			pix[idx] = 0xff
+			pix[idx+1] = byte(i * 16)
+			pix[idx+2] = 0x40
+			pix[idx+3] = byte(j * 16)
+		}
+	}
+	orig := make([]byte, len(pix))
+	copy(orig, pix)
+
+	img.WritePixelsWithOptions(pix, &ebiten.WritePixelsOptions{
+		PremultiplyAlpha: true,
+	})
+
+	if !bytes.Equal(pix, orig) {
+		t.Errorf("WritePixelsWithOptions must not modify the given pixels")
+	}
+
+	got := make([]byte, 4*w*h)
+	img.ReadPixels(got)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			a := uint32(j * 16)
+			var want [4]byte
+			if a > 0 {
+				want = [4]byte{
+					byte((0xff*a + 0x7f) / 0xff),
+					byte((uint32(i*16)*a + 0x7f) / 0xff),
+					byte((0x40*a + 0x7f) / 0xff),
+					byte(a),
+				}
+			}
+			idx := 4 * (j*w + i)
+			if g := [4]byte(got[idx : idx+4]); g != want {
+				t.Errorf("pixel at (%d, %d): got: %v, want: %v", i, j, g, want)
+			}
+		}
+	}
+}
+
 func TestImageDispose(t *testing.T) {
 	img := ebiten.NewImage(16, 16)
 	img.Fill(color.White)
@@ -371,6 +418,36 @@ func TestImageBlendLighter(t *testing.T) {
 	}
 }
 
+func TestImageBlendLighterAlpha(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	// The source color is alpha-premultiplied.
+	src.Fill(color.RGBA{R: 0x40, G: 0x20, B: 0x10, A: 0x80})
+
+	draw := func(blend ebiten.Blend) color.RGBA {
+		dst := ebiten.NewImage(w, h)
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = blend
+		// Draw two semi-transparent quads.
+		dst.DrawImage(src, op)
+		dst.DrawImage(src, op)
+		return dst.At(0, 0).(color.RGBA)
+	}
+
+	got := draw(ebiten.BlendLighterAlpha)
+	// c_out = c_src × α_src + c_dst, twice.
+	want := color.RGBA{R: 0x40, G: 0x20, B: 0x10, A: 0xff}
+	if !sameColors(got, want, 1) {
+		t.Errorf("BlendLighterAlpha: got: %v, want: %v", got, want)
+	}
+	if lighter := draw(ebiten.BlendLighter); sameColors(got, lighter, 1) {
+		t.Errorf("BlendLighterAlpha must differ from BlendLighter: got: %v, BlendLighter: %v", got, lighter)
+	}
+	if sourceOver := draw(ebiten.BlendSourceOver); sameColors(got, sourceOver, 1) {
+		t.Errorf("BlendLighterAlpha must differ from BlendSourceOver: got: %v, BlendSourceOver: %v", got, sourceOver)
+	}
+}
+
 func TestNewImageFromEbitenImage(t *testing.T) {
 	img, _, err := openEbitenImage()
 	if err != nil {
@@ -637,6 +714,91 @@ func BenchmarkDrawImage(b *testing.B) {
 	}
 }
 
+func TestImagePixelsInto(t *testing.T) {
+	const w, h = 16, 8
+	img := ebiten.NewImage(32, 32).SubImage(image.Rect(4, 4, 4+w, 4+h)).(*ebiten.Image)
+	img.Fill(color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+
+	for _, c := range []int{0, 4 * w * h, 8 * w * h} {
+		dst := make([]byte, 0, c)
+		got := img.PixelsInto(dst)
+		if len(got) != 4*w*h {
+			t.Fatalf("cap: %d, len(got): %d, want: %d", c, len(got), 4*w*h)
+		}
+		if c >= 4*w*h && &got[0] != &dst[:1][0] {
+			t.Errorf("cap: %d, PixelsInto must reuse the given slice", c)
+		}
+		for i := 0; i < len(got); i += 4 {
+			if got[i] != 0x80 || got[i+1] != 0x40 || got[i+2] != 0x20 || got[i+3] != 0xff {
+				t.Fatalf("cap: %d, got[%d:%d]: %v", c, i, i+4, got[i:i+4])
+			}
+		}
+	}
+}
+
+func TestImageOpaqueBounds(t *testing.T) {
+	img := ebiten.NewImage(32, 32)
+	if got, want := img.OpaqueBounds(), (image.Rectangle{}); got != want {
+		t.Errorf("OpaqueBounds() for a transparent image: got: %v, want: %v", got, want)
+	}
+
+	img.Set(5, 7, color.RGBA{A: 0x01})
+	img.Set(20, 3, color.White)
+	img.Set(9, 24, color.White)
+	if got, want := img.OpaqueBounds(), image.Rect(5, 3, 21, 25); got != want {
+		t.Errorf("OpaqueBounds(): got: %v, want: %v", got, want)
+	}
+
+	// The result is in the sub-image's coordinate.
+	sub := img.SubImage(image.Rect(8, 2, 32, 32)).(*ebiten.Image)
+	if got, want := sub.OpaqueBounds(), image.Rect(9, 3, 21, 25); got != want {
+		t.Errorf("OpaqueBounds() for a sub-image: got: %v, want: %v", got, want)
+	}
+
+	// The cached result must be invalidated by modifications, including ones via a sub-image.
+	sub.Set(30, 30, color.White)
+	if got, want := img.OpaqueBounds(), image.Rect(5, 3, 31, 31); got != want {
+		t.Errorf("OpaqueBounds() after modifying the sub-image: got: %v, want: %v", got, want)
+	}
+	img.Clear()
+	if got, want := sub.OpaqueBounds(), (image.Rectangle{}); got != want {
+		t.Errorf("OpaqueBounds() for a sub-image after Clear: got: %v, want: %v", got, want)
+	}
+
+	img.Dispose()
+	if got, want := img.OpaqueBounds(), (image.Rectangle{}); got != want {
+		t.Errorf("OpaqueBounds() for a disposed image: got: %v, want: %v", got, want)
+	}
+}
+
+func TestImagePixelsIntoNoAllocs(t *testing.T) {
+	const w, h = 16, 16
+	img := ebiten.NewImage(w, h)
+	img.Fill(color.White)
+
+	dst := img.PixelsInto(nil)
+	if got, want := len(dst), 4*w*h; got != want {
+		t.Fatalf("len(PixelsInto(nil)): got: %d, want: %d", got, want)
+	}
+
+	// Reusing the buffer must not allocate.
+	if allocs := testing.AllocsPerRun(10, func() {
+		dst = img.PixelsInto(dst)
+	}); allocs != 0 {
+		t.Errorf("PixelsInto with a reused buffer: got: %v allocs, want: 0", allocs)
+	}
+}
+
+func BenchmarkImagePixelsInto(b *testing.B) {
+	img := ebiten.NewImage(256, 256)
+	dst := make([]byte, 4*256*256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst = img.PixelsInto(dst)
+	}
+}
+
 func BenchmarkDrawTriangles(b *testing.B) {
 	const w, h = 16, 16
 	img0 := ebiten.NewImage(w, h)
@@ -690,6 +852,84 @@ func BenchmarkDrawTriangles(b *testing.B) {
 	}
 }
 
+func benchmarkDrawTrianglesManyTriangles(b *testing.B, ignoreVertexColors bool) {
+	const (
+		w, h          = 256, 256
+		triangleCount = 100000
+		quadsPerCall  = 10000
+	)
+	dst := ebiten.NewImage(w, h)
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	vs := make([]ebiten.Vertex, 0, 4*quadsPerCall)
+	is := make([]uint32, 0, 6*quadsPerCall)
+	for i := 0; i < quadsPerCall; i++ {
+		x := float32(i % w)
+		y := float32(i / w % h)
+		n := uint32(len(vs))
+		vs = append(vs,
+			ebiten.Vertex{DstX: x, DstY: y, SrcX: x, SrcY: y, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			ebiten.Vertex{DstX: x + 1, DstY: y, SrcX: x + 1, SrcY: y, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			ebiten.Vertex{DstX: x, DstY: y + 1, SrcX: x, SrcY: y + 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			ebiten.Vertex{DstX: x + 1, DstY: y + 1, SrcX: x + 1, SrcY: y + 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1})
+		is = append(is, n, n+1, n+2, n+1, n+2, n+3)
+	}
+
+	op := &ebiten.DrawTrianglesOptions{
+		IgnoreVertexColors: ignoreVertexColors,
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < triangleCount/(2*quadsPerCall); j++ {
+			dst.DrawTriangles32(vs, is, src, op)
+		}
+		// Flush the commands to measure the GPU cost too.
+		_ = dst.At(0, 0)
+	}
+}
+
+func BenchmarkDrawTrianglesWithVertexColors(b *testing.B) {
+	benchmarkDrawTrianglesManyTriangles(b, false)
+}
+
+func BenchmarkDrawTrianglesIgnoreVertexColors(b *testing.B) {
+	benchmarkDrawTrianglesManyTriangles(b, true)
+}
+
+func TestImageDrawTrianglesIgnoreVertexColors(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 0.5},
+		{DstX: w, DstY: 0, SrcX: w, SrcY: 0, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 0.5},
+		{DstX: 0, DstY: h, SrcX: 0, SrcY: h, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 0.5},
+		{DstX: w, DstY: h, SrcX: w, SrcY: h, ColorR: 1, ColorG: 0, ColorB: 0, ColorA: 0.5},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	for _, scale := range []float32{1, 0.5} {
+		dst := ebiten.NewImage(w, h)
+		op := &ebiten.DrawTrianglesOptions{
+			IgnoreVertexColors: true,
+		}
+		op.ColorM.Scale(float64(scale), float64(scale), float64(scale), 1)
+		dst.DrawTriangles(vs, is, src, op)
+
+		want := color.RGBA{R: byte(0x80 * scale), G: byte(0x40 * scale), B: byte(0x20 * scale), A: 0xff}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				if !sameColors(got, want, 1) {
+					t.Errorf("scale: %v, dst.At(%d, %d): got: %v, want: %v", scale, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
 func TestImageLinearGraduation(t *testing.T) {
 	img0 := ebiten.NewImage(2, 2)
 	img0.WritePixels([]byte{
@@ -1074,6 +1314,68 @@ func TestImageMipmapColor(t *testing.T) {
 	}
 }
 
+func TestImageMipmapsDisabled(t *testing.T) {
+	if !ebiten.IsMipmapsEnabled() {
+		t.Fatal("mipmaps must be enabled by default")
+	}
+	ebiten.SetMipmapsEnabled(false)
+	defer ebiten.SetMipmapsEnabled(true)
+
+	// Only every 8th column is opaque.
+	src := ebiten.NewImage(16, 16)
+	for j := 0; j < 16; j++ {
+		for i := 0; i < 16; i += 8 {
+			src.Set(i, j, color.White)
+		}
+	}
+
+	dst := ebiten.NewImage(2, 2)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(1.0/8, 1.0/8)
+	op.Filter = ebiten.FilterLinear
+	dst.DrawImage(src, op)
+
+	// Without mipmaps, the level 0 image is sampled around (4, 4), where the pixels are transparent.
+	if got, want := dst.At(0, 0), (color.RGBA{}); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestImageMipmapPremultipliedAlpha(t *testing.T) {
+	// The left half is opaque red and the right half is transparent.
+	// The transparent pixels have non-zero RGB values, which is common in straight-alpha image files.
+	// Such hidden colors must not bleed into the edge.
+	pix := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			if i < 32 {
+				pix.SetNRGBA(i, j, color.NRGBA{R: 0xff, A: 0xff})
+			} else {
+				pix.SetNRGBA(i, j, color.NRGBA{G: 0xff, B: 0xff})
+			}
+		}
+	}
+	src := ebiten.NewImageFromImage(pix)
+
+	for _, s := range []float64{0.5, 0.25, 0.125} {
+		dst := ebiten.NewImage(64, 64)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(s, s)
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(src, op)
+
+		// Averaging in the premultiplied-alpha space never makes the edge darker nor tinted.
+		for j := 0; j < 64; j++ {
+			for i := 0; i < 64; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				if got.R != got.A || got.G != 0 || got.B != 0 {
+					t.Errorf("scale: %f, dst.At(%d, %d): got: %v, want: premultiplied pure red", s, i, j, got)
+				}
+			}
+		}
+	}
+}
+
 // Issue #725
 func TestImageMiamapAndDrawTriangle(t *testing.T) {
 	img0 := ebiten.NewImage(32, 32)
@@ -1259,6 +1561,53 @@ func TestImageLinearFilterGlitch(t *testing.T) {
 	}
 }
 
+func TestImageFilterNonIntegerScale(t *testing.T) {
+	src := ebiten.NewImage(2, 2)
+	src.WritePixels([]byte{
+		0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0xff,
+		0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff,
+	})
+
+	const (
+		scale = 3.5
+		w     = 7
+		h     = 7
+	)
+
+	draw := func(filter ebiten.Filter) map[color.RGBA]int {
+		dst := ebiten.NewImage(w, h)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.Filter = filter
+		dst.DrawImage(src, op)
+
+		colors := map[color.RGBA]int{}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				colors[dst.At(i, j).(color.RGBA)]++
+			}
+		}
+		return colors
+	}
+
+	nearest := draw(ebiten.FilterNearest)
+	// With the nearest filter, every pixel must be either white or black even at a non-integer scale.
+	for clr := range nearest {
+		if clr != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) && clr != (color.RGBA{A: 0xff}) {
+			t.Errorf("FilterNearest: unexpected color: %v", clr)
+		}
+	}
+	if len(nearest) != 2 {
+		t.Errorf("FilterNearest: the number of colors must be 2 but %d", len(nearest))
+	}
+
+	linear := draw(ebiten.FilterLinear)
+	// With the linear filter, there must be intermediate colors.
+	if len(linear) <= 2 {
+		t.Errorf("FilterLinear: the number of colors must be more than 2 but %d", len(linear))
+	}
+}
+
 // Issue #1212
 func TestImageLinearFilterGlitch2(t *testing.T) {
 	const w, h = 100, 100
@@ -1467,6 +1816,58 @@ func TestImageAddressRepeatNegativePosition(t *testing.T) {
 	}
 }
 
+func TestImageAddressRepeatTiles(t *testing.T) {
+	const w, h = 8, 8
+	colors := [2][2]color.RGBA{
+		{{R: 0xff, A: 0xff}, {G: 0xff, A: 0xff}},
+		{{B: 0xff, A: 0xff}, {R: 0xff, G: 0xff, A: 0xff}},
+	}
+	src := ebiten.NewImage(2, 2)
+	for j := 0; j < 2; j++ {
+		for i := 0; i < 2; i++ {
+			src.Set(i, j, colors[j][i])
+		}
+	}
+
+	vs := []ebiten.Vertex{
+		{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w, DstY: 0, SrcX: w, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: 0, DstY: h, SrcX: 0, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		{DstX: w, DstY: h, SrcX: w, SrcY: h, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+	}
+	is := []uint16{0, 1, 2, 1, 2, 3}
+
+	for _, address := range []ebiten.Address{ebiten.AddressRepeat, ebiten.AddressMirroredRepeat} {
+		for _, filter := range []ebiten.Filter{ebiten.FilterNearest, ebiten.FilterLinear} {
+			dst := ebiten.NewImage(w, h)
+			op := &ebiten.DrawTrianglesOptions{}
+			op.Address = address
+			op.Filter = filter
+			dst.DrawTriangles(vs, is, src, op)
+
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					si, sj := i%2, j%2
+					if address == ebiten.AddressMirroredRepeat {
+						// The texture is mirrored at every other repetition.
+						if (i/2)%2 == 1 {
+							si = 1 - si
+						}
+						if (j/2)%2 == 1 {
+							sj = 1 - sj
+						}
+					}
+					got := dst.At(i, j).(color.RGBA)
+					want := colors[sj][si]
+					if !sameColors(got, want, 1) {
+						t.Errorf("address: %d, filter: %d, dst.At(%d, %d): got %v, want: %v", address, filter, i, j, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
 func TestImageWritePixelsAfterClear(t *testing.T) {
 	const w, h = 256, 256
 	img := ebiten.NewImage(w, h)
@@ -2656,6 +3057,40 @@ func TestImageSubImageFill(t *testing.T) {
 	}
 }
 
+func TestImageSubImageClear(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.White)
+
+	dst := ebiten.NewImage(w, h)
+	dst.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	// Draw an image onto a sub-image, and then clear other sub-images.
+	dst.SubImage(image.Rect(0, 0, 8, 8)).(*ebiten.Image).DrawImage(src, nil)
+	dst.SubImage(image.Rect(8, 0, 16, 8)).(*ebiten.Image).Clear()
+	dst.SubImage(image.Rect(4, 4, 12, 12)).(*ebiten.Image).Clear()
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			var want color.RGBA
+			switch {
+			case 4 <= i && i < 12 && 4 <= j && j < 12:
+				want = color.RGBA{}
+			case i < 8 && j < 8:
+				want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			case j < 8:
+				want = color.RGBA{}
+			default:
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
 func TestImageEvenOdd(t *testing.T) {
 	whiteImage := ebiten.NewImage(3, 3)
 	whiteImage.Fill(color.White)
@@ -4808,3 +5243,501 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 		}
 	}
 }
+
+func TestImageAverageLuminance(t *testing.T) {
+	const w, h = 16, 16
+
+	img := ebiten.NewImage(w, h)
+	if got := img.AverageLuminance(); got != 0 {
+		t.Errorf("got: %v, want: 0", got)
+	}
+
+	img.Fill(color.White)
+	if got := img.AverageLuminance(); math.Abs(got-1) > 1.0/255 {
+		t.Errorf("got: %v, want: 1", got)
+	}
+
+	img.SubImage(image.Rect(0, 0, w/2, h)).(*ebiten.Image).Fill(color.Black)
+	if got := img.AverageLuminance(); math.Abs(got-0.5) > 2.0/255 {
+		t.Errorf("got: %v, want: 0.5", got)
+	}
+}
+
+func TestImageAverageLuminanceOddSize(t *testing.T) {
+	// With odd sizes, the last row and column must be counted as well as the others.
+	for _, size := range []image.Point{{5, 5}, {7, 3}, {1, 9}, {17, 16}, {513, 300}} {
+		img := ebiten.NewImage(size.X, size.Y)
+		img.Fill(color.White)
+		if got := img.AverageLuminance(); math.Abs(got-1) > 1.0/255 {
+			t.Errorf("size: %v, got: %v, want: 1", size, got)
+		}
+
+		// Only the last column is white.
+		img.Clear()
+		img.SubImage(image.Rect(size.X-1, 0, size.X, size.Y)).(*ebiten.Image).Fill(color.White)
+		if got, want := img.AverageLuminance(), 1/float64(size.X); math.Abs(got-want) > 1.0/255 {
+			t.Errorf("size: %v, got: %v, want: %v", size, got, want)
+		}
+
+		// The padding must not amplify the rounding errors of the intermediate images.
+		img.Fill(color.Gray{Y: 0x80})
+		if got, want := img.AverageLuminance(), 0x80/255.0; math.Abs(got-want) > 1.0/255 {
+			t.Errorf("size: %v, got: %v, want: %v", size, got, want)
+		}
+	}
+}
+
+func TestGenerateNoise(t *testing.T) {
+	const w, h = 32, 32
+
+	pixels := func(img *ebiten.Image) []byte {
+		pix := make([]byte, 4*w*h)
+		img.ReadPixels(pix)
+		return pix
+	}
+
+	for _, typ := range []ebiten.NoiseType{ebiten.NoiseTypeValue, ebiten.NoiseTypePerlin, ebiten.NoiseTypeSimplex} {
+		op := &ebiten.NoiseOptions{
+			Type:      typ,
+			Seed:      1,
+			Frequency: 1.0 / 8,
+			Octaves:   3,
+		}
+		pix0 := pixels(ebiten.GenerateNoise(w, h, op))
+		pix1 := pixels(ebiten.GenerateNoise(w, h, op))
+		if !bytes.Equal(pix0, pix1) {
+			t.Errorf("type: %d: the same seed must generate the same noise", typ)
+		}
+
+		op.Seed = 2
+		pix2 := pixels(ebiten.GenerateNoise(w, h, op))
+		if bytes.Equal(pix0, pix2) {
+			t.Errorf("type: %d: different seeds must generate different noises", typ)
+		}
+
+		var minV, maxV byte = 0xff, 0
+		for i := 0; i < len(pix0)/4; i++ {
+			r, g, b, a := pix0[4*i], pix0[4*i+1], pix0[4*i+2], pix0[4*i+3]
+			if r != g || g != b || a != 0xff {
+				t.Fatalf("type: %d: the pixel (%d, %d, %d, %d) must be opaque gray", typ, r, g, b, a)
+			}
+			minV = min(minV, r)
+			maxV = max(maxV, r)
+		}
+		if minV == maxV {
+			t.Errorf("type: %d: the noise must not be flat", typ)
+		}
+	}
+}
+
+func TestImageMaxMipmapLevel(t *testing.T) {
+	const (
+		srcSize = 256
+		dstSize = 8
+	)
+
+	// A fine checkerboard pattern shimmers when it is shrunk without mipmaps.
+	pix := make([]byte, 4*srcSize*srcSize)
+	for j := 0; j < srcSize; j++ {
+		for i := 0; i < srcSize; i++ {
+			if (i+j)%2 == 0 {
+				continue
+			}
+			idx := 4 * (j*srcSize + i)
+			pix[idx] = 0xff
+			pix[idx+1] = 0xff
+			pix[idx+2] = 0xff
+			pix[idx+3] = 0xff
+		}
+	}
+
+	draw := func(maxLevel int) *ebiten.Image {
+		src := ebiten.NewImageWithOptions(image.Rect(0, 0, srcSize, srcSize), &ebiten.NewImageOptions{
+			MaxMipmapLevel: maxLevel,
+		})
+		src.WritePixels(pix)
+		dst := ebiten.NewImage(dstSize, dstSize)
+		op := &ebiten.DrawImageOptions{}
+		// Sample the centers of the source pixels so that the result without mipmaps is not blended.
+		op.GeoM.Translate(-0.5, -0.5)
+		op.GeoM.Scale(float64(dstSize)/srcSize, float64(dstSize)/srcSize)
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(src, op)
+		return dst
+	}
+
+	withMipmap := draw(0)
+	withoutMipmap := draw(-1)
+
+	var diff bool
+	for j := 0; j < dstSize; j++ {
+		for i := 0; i < dstSize; i++ {
+			// With mipmaps, the checkerboard is averaged into a gray.
+			got := withMipmap.At(i, j).(color.RGBA)
+			want := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80}
+			if !sameColors(got, want, 2) {
+				t.Errorf("with mipmaps: dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+			if withMipmap.At(i, j) != withoutMipmap.At(i, j) {
+				diff = true
+			}
+		}
+	}
+	if !diff {
+		t.Errorf("the results with and without mipmaps must be different")
+	}
+}
+
+func TestImageDrawImageMipmapLevel(t *testing.T) {
+	const size = 16
+
+	// A fine checkerboard pattern is averaged into a gray at the level 1 mipmap.
+	src := ebiten.NewImage(size, size)
+	for j := 0; j < size; j++ {
+		for i := 0; i < size; i++ {
+			if (i+j)%2 == 0 {
+				continue
+			}
+			src.Set(i, j, color.White)
+		}
+	}
+
+	for _, level := range []int{0, 1} {
+		dst := ebiten.NewImage(size, size)
+		op := &ebiten.DrawImageOptions{}
+		op.Filter = ebiten.FilterLinear
+		op.MipmapLevel = level
+		dst.DrawImage(src, op)
+
+		// Without scaling, mipmaps are not used unless the level is specified.
+		const i, j = size / 2, size / 2
+		got := dst.At(i, j).(color.RGBA)
+		want := color.RGBA{}
+		if level == 1 {
+			want = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80}
+		}
+		if !sameColors(got, want, 2) {
+			t.Errorf("level: %d, dst.At(%d, %d): got: %v, want: %v", level, i, j, got, want)
+		}
+	}
+}
+
+func TestImageRotated(t *testing.T) {
+	const w, h = 4, 8
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	cases := []struct {
+		Theta      float64
+		WantWidth  int
+		WantHeight int
+	}{
+		{Theta: 0, WantWidth: w + 2, WantHeight: h + 2},
+		{Theta: math.Pi / 2, WantWidth: h + 2, WantHeight: w + 2},
+		{Theta: math.Pi, WantWidth: w + 2, WantHeight: h + 2},
+		{Theta: math.Pi / 4, WantWidth: int(math.Ceil(float64(w+h+4) / math.Sqrt2)), WantHeight: int(math.Ceil(float64(w+h+4) / math.Sqrt2))},
+	}
+	for _, c := range cases {
+		dst := src.Rotated(c.Theta)
+		if got, want := dst.Bounds().Size(), image.Pt(c.WantWidth, c.WantHeight); got != want {
+			t.Errorf("theta: %v, size: got: %v, want: %v", c.Theta, got, want)
+			continue
+		}
+
+		// The center must be filled and the corners must be transparent.
+		if got, want := dst.At(c.WantWidth/2, c.WantHeight/2).(color.RGBA), (color.RGBA{R: 0xff, A: 0xff}); !sameColors(got, want, 1) {
+			t.Errorf("theta: %v, center: got: %v, want: %v", c.Theta, got, want)
+		}
+		if got, want := dst.At(0, 0).(color.RGBA), (color.RGBA{}); got != want {
+			t.Errorf("theta: %v, corner: got: %v, want: %v", c.Theta, got, want)
+		}
+	}
+
+	// The rotated image by 0 radians must keep the content at the padded position.
+	dst := src.Rotated(0)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if got, want := dst.At(i+1, j+1).(color.RGBA), (color.RGBA{R: 0xff, A: 0xff}); !sameColors(got, want, 1) {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i+1, j+1, got, want)
+			}
+		}
+	}
+}
+
+func TestImageColorMask(t *testing.T) {
+	const w, h = 16, 16
+	src := ebiten.NewImage(w, h)
+	src.Fill(color.RGBA{R: 0x40, G: 0x80, B: 0xc0, A: 0xff})
+
+	for _, mask := range [][4]bool{
+		// All false means all the channels are written, unlike glColorMask.
+		{},
+		{true, false, false, false},
+		{false, true, true, false},
+		{true, true, true, false},
+		{false, false, false, true},
+	} {
+		dst := ebiten.NewImage(w, h)
+		dst.Fill(color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0x40})
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendCopy
+		op.ColorMask = mask
+		dst.DrawImage(src, op)
+
+		want := color.RGBA{R: 0x40, G: 0x80, B: 0xc0, A: 0xff}
+		if mask != [4]bool{} {
+			if !mask[0] {
+				want.R = 0x10
+			}
+			if !mask[1] {
+				want.G = 0x20
+			}
+			if !mask[2] {
+				want.B = 0x30
+			}
+			if !mask[3] {
+				want.A = 0x40
+			}
+		}
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				if !sameColors(got, want, 1) {
+					t.Errorf("mask: %v, dst.At(%d, %d): got: %v, want: %v", mask, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawImagePixelSnapping(t *testing.T) {
+	defer ebiten.SetPixelSnapping(ebiten.PixelSnappingNone)
+
+	src := ebiten.NewImage(2, 2)
+	src.Fill(color.White)
+
+	for _, snap := range []bool{false, true} {
+		if snap {
+			ebiten.SetPixelSnapping(ebiten.PixelSnappingSprites)
+		} else {
+			ebiten.SetPixelSnapping(ebiten.PixelSnappingNone)
+		}
+
+		dst := ebiten.NewImage(4, 4)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(0.75, 0.75)
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(src, op)
+
+		// With snapping, the image is drawn at (1, 1) exactly.
+		for j := 0; j < 4; j++ {
+			for i := 0; i < 4; i++ {
+				got := dst.At(i, j).(color.RGBA)
+				var want color.RGBA
+				if 1 <= i && i < 3 && 1 <= j && j < 3 {
+					want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+				}
+				if snap && got != want {
+					t.Errorf("snap: dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+				}
+				// Without snapping, the edge is blurred by the linear filter.
+				if !snap && i == 1 && j == 1 && got.A == 0xff {
+					t.Errorf("no snap: dst.At(%d, %d): got: %v, want: translucent", i, j, got)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawImagePixelSnappingScaled(t *testing.T) {
+	defer ebiten.SetPixelSnapping(ebiten.PixelSnappingNone)
+	ebiten.SetPixelSnapping(ebiten.PixelSnappingSprites)
+
+	src := ebiten.NewImage(2, 2)
+	src.Fill(color.White)
+
+	dst := ebiten.NewImage(4, 4)
+	op := &ebiten.DrawImageOptions{}
+	// The vertices are at (0.25, 0.25) and (3.25, 3.25), and are snapped to (0, 0) and (3, 3).
+	op.GeoM.Scale(1.5, 1.5)
+	op.GeoM.Translate(0.25, 0.25)
+	dst.DrawImage(src, op)
+
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			got := dst.At(i, j).(color.RGBA)
+			var want color.RGBA
+			if i < 3 && j < 3 {
+				want = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestImageDrawTrianglesPixelSnapping(t *testing.T) {
+	defer ebiten.SetPixelSnapping(ebiten.PixelSnappingNone)
+
+	// The left column is white and the right column is transparent.
+	src := ebiten.NewImage(2, 2)
+	src.SubImage(image.Rect(0, 0, 1, 2)).(*ebiten.Image).Fill(color.White)
+
+	for _, mode := range []ebiten.PixelSnappingMode{ebiten.PixelSnappingSprites, ebiten.PixelSnappingTriangles} {
+		ebiten.SetPixelSnapping(mode)
+
+		dst := ebiten.NewImage(4, 4)
+		vs := []ebiten.Vertex{
+			{DstX: 0.75, DstY: 0.75, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: 2.75, DstY: 0.75, SrcX: 2, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: 0.75, DstY: 2.75, SrcX: 0, SrcY: 2, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: 2.75, DstY: 2.75, SrcX: 2, SrcY: 2, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		}
+		op := &ebiten.DrawTrianglesOptions{}
+		op.Filter = ebiten.FilterLinear
+		dst.DrawTriangles(vs, []uint16{0, 1, 2, 1, 2, 3}, src, op)
+
+		got := dst.At(1, 1).(color.RGBA)
+		switch mode {
+		case ebiten.PixelSnappingTriangles:
+			// With snapping, the pixel center (1.5, 1.5) is mapped to the center of the white texel exactly.
+			if want := (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}); got != want {
+				t.Errorf("PixelSnappingTriangles: dst.At(1, 1): got: %v, want: %v", got, want)
+			}
+		case ebiten.PixelSnappingSprites:
+			// PixelSnappingSprites doesn't affect DrawTriangles, so the white texel is blended with the transparent one.
+			if got.A == 0 || got.A == 0xff {
+				t.Errorf("PixelSnappingSprites: dst.At(1, 1): got: %v, want: translucent", got)
+			}
+		}
+	}
+}
+
+func TestImageScreenSupersampling(t *testing.T) {
+	defer ebiten.SetScreenSupersampling(false)
+
+	for _, enabled := range []bool{true, false} {
+		ebiten.SetScreenSupersampling(enabled)
+		if got := ebiten.IsScreenSupersamplingEnabled(); got != enabled {
+			t.Errorf("IsScreenSupersamplingEnabled() after SetScreenSupersampling(%t): got: %t", enabled, got)
+		}
+	}
+
+	src := ebiten.NewImage(1, 1)
+	src.Fill(color.White)
+
+	// hasTranslucentPixel reports whether a triangle with a diagonal edge has an anti-aliased edge.
+	hasTranslucentPixel := func(dst *ebiten.Image) bool {
+		vs := []ebiten.Vertex{
+			{DstX: 0, DstY: 0, SrcX: 0, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: 16, DstY: 0, SrcX: 1, SrcY: 0, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			{DstX: 0, DstY: 16, SrcX: 0, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+		}
+		dst.DrawTriangles(vs, []uint16{0, 1, 2}, src, nil)
+		for j := 0; j < 16; j++ {
+			for i := 0; i < 16; i++ {
+				if a := dst.At(i, j).(color.RGBA).A; a != 0 && a != 0xff {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, enabled := range []bool{false, true} {
+		ebiten.SetScreenSupersampling(enabled)
+
+		screen := ebiten.NewImage(16, 16)
+		restore := ebiten.SetGameScreenForTesting(screen)
+		if got, want := hasTranslucentPixel(screen), enabled; got != want {
+			t.Errorf("screen with supersampling %t: anti-aliased: got: %t, want: %t", enabled, got, want)
+		}
+		restore()
+
+		// Offscreen images are not affected.
+		offscreen := ebiten.NewImage(16, 16)
+		if hasTranslucentPixel(offscreen) {
+			t.Errorf("offscreen with supersampling %t: anti-aliased: got: true, want: false", enabled)
+		}
+	}
+}
+
+func TestImageDrawImageAdjacentImagesDontBleed(t *testing.T) {
+	// Small images are packed into the same texture atlas next to each other.
+	// With the linear filter, the neighbor's color must not be sampled.
+	const size = 4
+	red := ebiten.NewImage(size, size)
+	red.Fill(color.RGBA{R: 0xff, A: 0xff})
+	green := ebiten.NewImage(size, size)
+	green.Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	for _, tc := range []struct {
+		src   *ebiten.Image
+		other func(c color.RGBA) uint8
+	}{
+		{src: red, other: func(c color.RGBA) uint8 { return c.G }},
+		{src: green, other: func(c color.RGBA) uint8 { return c.R }},
+	} {
+		const scale = 8
+		dst := ebiten.NewImage(size*scale+2, size*scale+2)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(1, 1)
+		op.Filter = ebiten.FilterLinear
+		dst.DrawImage(tc.src, op)
+
+		for j := 0; j < dst.Bounds().Dy(); j++ {
+			for i := 0; i < dst.Bounds().Dx(); i++ {
+				c := dst.At(i, j).(color.RGBA)
+				if tc.other(c) != 0 {
+					t.Errorf("dst.At(%d, %d): got: %v, the other image's color must not bleed", i, j, c)
+				}
+			}
+		}
+	}
+}
+
+func TestImageDrawTrianglesAdjacentSubImagesDontBleed(t *testing.T) {
+	// Sub-images of the same image, e.g. glyphs in a text atlas, are adjacent without any padding.
+	// With AddressClampToZero, the neighbor's color must not be sampled even with the linear filter.
+	const size = 4
+	img := ebiten.NewImage(2*size, size)
+	img.SubImage(image.Rect(0, 0, size, size)).(*ebiten.Image).Fill(color.RGBA{R: 0xff, A: 0xff})
+	img.SubImage(image.Rect(size, 0, 2*size, size)).(*ebiten.Image).Fill(color.RGBA{G: 0xff, A: 0xff})
+
+	for _, tc := range []struct {
+		src   *ebiten.Image
+		other func(c color.RGBA) uint8
+	}{
+		{src: img.SubImage(image.Rect(0, 0, size, size)).(*ebiten.Image), other: func(c color.RGBA) uint8 { return c.G }},
+		{src: img.SubImage(image.Rect(size, 0, 2*size, size)).(*ebiten.Image), other: func(c color.RGBA) uint8 { return c.R }},
+	} {
+		for _, filter := range []ebiten.Filter{ebiten.FilterLinear, ebiten.FilterPixelated} {
+			const scale = 8
+			dst := ebiten.NewImage(size*scale+2, size*scale+2)
+			b := tc.src.Bounds()
+			x0, y0 := float32(1), float32(1)
+			x1, y1 := float32(1+size*scale), float32(1+size*scale)
+			vs := []ebiten.Vertex{
+				{DstX: x0, DstY: y0, SrcX: float32(b.Min.X), SrcY: float32(b.Min.Y), ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				{DstX: x1, DstY: y0, SrcX: float32(b.Max.X), SrcY: float32(b.Min.Y), ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				{DstX: x0, DstY: y1, SrcX: float32(b.Min.X), SrcY: float32(b.Max.Y), ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+				{DstX: x1, DstY: y1, SrcX: float32(b.Max.X), SrcY: float32(b.Max.Y), ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1},
+			}
+			op := &ebiten.DrawTrianglesOptions{}
+			op.Filter = filter
+			op.Address = ebiten.AddressClampToZero
+			dst.DrawTriangles(vs, []uint16{0, 1, 2, 1, 2, 3}, tc.src, op)
+
+			for j := 0; j < dst.Bounds().Dy(); j++ {
+				for i := 0; i < dst.Bounds().Dx(); i++ {
+					c := dst.At(i, j).(color.RGBA)
+					if tc.other(c) != 0 {
+						t.Errorf("filter: %d: dst.At(%d, %d): got: %v, the other sub-image's color must not bleed", filter, i, j, c)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
+)
+
+// maxPooledImages is the maximum number of images kept in the pool.
+// When the pool is full, the least recently put image is deallocated,
+// so images of sizes that are no longer used, e.g. after the window is resized, don't stay forever.
+const maxPooledImages = 16
+
+// imagePool is a pool of offscreen images used as scratch render targets by built-in effects.
+type imagePool struct {
+	// images is ordered from the least recently put image.
+	images []*Image
+	m      sync.Mutex
+}
+
+var theImagePool = &imagePool{}
+
+// get returns a cleared image with the given size.
+// The returned image must be returned by put after being used.
+func (p *imagePool) get(width, height int) *Image {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	size := image.Pt(width, height)
+	for i := len(p.images) - 1; i >= 0; i-- {
+		img := p.images[i]
+		if img.Bounds().Size() != size {
+			continue
+		}
+		copy(p.images[i:], p.images[i+1:])
+		p.images[len(p.images)-1] = nil
+		p.images = p.images[:len(p.images)-1]
+		img.Clear()
+		return img
+	}
+	return newImage(image.Rect(0, 0, width, height), atlas.ImageTypeUnmanaged)
+}
+
+// put puts back the image to the pool.
+func (p *imagePool) put(img *Image) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if len(p.images) >= maxPooledImages {
+		p.images[0].Deallocate()
+		copy(p.images, p.images[1:])
+		p.images[len(p.images)-1] = nil
+		p.images = p.images[:len(p.images)-1]
+	}
+	p.images = append(p.images, img)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestImagePoolIsBounded(t *testing.T) {
+	// Emulate effects applied while the window is being resized.
+	var imgs []*ebiten.Image
+	for i := 0; i < ebiten.MaxPooledImages+8; i++ {
+		img := ebiten.GetPooledImageForTesting(100+i, 100)
+		imgs = append(imgs, img)
+		ebiten.PutPooledImageForTesting(img)
+	}
+
+	if got, want := ebiten.PooledImageCountForTesting(), ebiten.MaxPooledImages; got != want {
+		t.Errorf("PooledImageCountForTesting(): got: %d, want: %d", got, want)
+	}
+
+	// The least recently put images are evicted.
+	if got := ebiten.GetPooledImageForTesting(100, 100); got == imgs[0] {
+		t.Errorf("the least recently put image must be evicted")
+	} else {
+		ebiten.PutPooledImageForTesting(got)
+	}
+
+	// The most recently put image is reused.
+	last := imgs[len(imgs)-1]
+	if got := ebiten.GetPooledImageForTesting(last.Bounds().Dx(), last.Bounds().Dy()); got != last {
+		t.Errorf("the most recently put image must be reused")
+	} else {
+		ebiten.PutPooledImageForTesting(got)
+	}
+}
@@ -15,8 +15,11 @@
 package ebiten
 
 import (
+	"image"
+	"image/color"
 	"io/fs"
 	"sync"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
@@ -46,6 +49,52 @@ func InputChars() []rune {
 	return AppendInputChars(nil)
 }
 
+// InputEventType represents the type of an InputEvent.
+type InputEventType int
+
+const (
+	InputEventTypeKey         InputEventType = InputEventType(ui.InputEventTypeKey)
+	InputEventTypeMouseButton InputEventType = InputEventType(ui.InputEventTypeMouseButton)
+)
+
+// InputEvent represents a transition of a key or a mouse button.
+type InputEvent struct {
+	// Type is the type of the event.
+	Type InputEventType
+
+	// Key is the key of the event. Key is valid only when Type is InputEventTypeKey.
+	Key Key
+
+	// MouseButton is the mouse button of the event. MouseButton is valid only when Type is InputEventTypeMouseButton.
+	MouseButton MouseButton
+
+	// Pressed reports whether the key or the mouse button is pressed or released.
+	Pressed bool
+
+	// Time is the time when the event arrived from the platform.
+	// Time has a monotonic clock reading, then Sub between two Times is reliable.
+	Time time.Time
+}
+
+// AppendInputEvents appends key and mouse button events, which arrived between the previous tick and the
+// current tick, to events in the arrival order, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// Unlike IsKeyPressed and IsMouseButtonPressed, AppendInputEvents reports all the transitions within a tick with
+// their timestamps. Repeated key events by holding a key are not reported.
+//
+// If too many events arrive before they are read, e.g. when Update is not called for a while, the oldest events
+// are dropped.
+//
+// AppendInputEvents doesn't report gamepad or touch events so far, as their states are polled once per frame.
+//
+// AppendInputEvents works only on desktops and browsers so far.
+//
+// AppendInputEvents is concurrent-safe.
+func AppendInputEvents(events []InputEvent) []InputEvent {
+	return theInputState.appendInputEvents(events)
+}
+
 // IsKeyPressed returns a boolean indicating whether key is pressed.
 //
 // If you want to know whether the key started being pressed in the current tick,
@@ -87,6 +136,23 @@ func CursorPosition() (x, y int) {
 	return int(cx), int(cy)
 }
 
+// AppendCursorPositionHistory appends the cursor positions, sampled by the platform between the previous tick and
+// the current tick, to positions in the order, and returns the extended buffer.
+// Giving a slice that already has enough capacity works efficiently.
+//
+// The positions are in the same coordinate as CursorPosition.
+// The history is useful to track fast mouse movements that happen within a tick, e.g. for drawing applications.
+// If the cursor doesn't move within a tick, no positions are appended.
+//
+// If too many positions are sampled before they are read, the oldest positions are dropped.
+//
+// AppendCursorPositionHistory works only on desktops and browsers so far.
+//
+// AppendCursorPositionHistory is concurrent-safe.
+func AppendCursorPositionHistory(positions []image.Point) []image.Point {
+	return theInputState.appendCursorPositionHistory(positions)
+}
+
 // Wheel returns x and y offsets of the mouse wheel or touchpad scroll.
 // It returns 0 if the wheel isn't being rolled.
 //
@@ -139,6 +205,78 @@ func GamepadName(id GamepadID) string {
 	return g.Name()
 }
 
+// GamepadSessionID returns a string that identifies the connected gamepad device in the current process.
+//
+// Unlike GamepadID, which can be reused by another gamepad after a disconnection,
+// GamepadSessionID is useful to detect whether a reconnected gamepad is the same device.
+// A session ID consists of the SDL ID and a connection counter, so two identical gamepads have distinct session IDs.
+// When a gamepad is reconnected within a few seconds after a gamepad with the same SDL ID is disconnected,
+// the reconnected gamepad reuses the disconnected gamepad's session ID.
+//
+// GamepadSessionID returns an empty string when the gamepad is not connected.
+//
+// GamepadSessionID is concurrent-safe.
+func GamepadSessionID(id GamepadID) string {
+	g := gamepad.Get(id)
+	if g == nil {
+		return ""
+	}
+	return g.SessionID()
+}
+
+// GamepadConnectedTime returns the time when the gamepad was connected.
+//
+// GamepadConnectedTime returns the zero time when the gamepad is not connected.
+//
+// GamepadConnectedTime is concurrent-safe.
+func GamepadConnectedTime(id GamepadID) time.Time {
+	g := gamepad.Get(id)
+	if g == nil {
+		return time.Time{}
+	}
+	return g.ConnectedTime()
+}
+
+// GamepadConnection returns how the gamepad is connected, by wire or wirelessly.
+//
+// GamepadConnection is available only on Linux and macOS so far.
+// GamepadConnection returns GamepadConnectionTypeUnknown when the gamepad is not connected
+// or the platform doesn't expose the connection type.
+// A gamepad connected via a USB wireless receiver is reported as wired.
+//
+// GamepadConnection is concurrent-safe.
+func GamepadConnection(id GamepadID) GamepadConnectionType {
+	g := gamepad.Get(id)
+	if g == nil {
+		return GamepadConnectionTypeUnknown
+	}
+	return g.ConnectionType()
+}
+
+// ErrGamepadLEDNotSupported is returned by SetGamepadLEDColor when the LED light bar of the gamepad cannot be controlled.
+var ErrGamepadLEDNotSupported = gamepad.ErrLEDNotSupported
+
+// SetGamepadLEDColor sets the color of the gamepad's LED light bar, like the one of DualShock 4 and DualSense.
+// This is useful to tell players which gamepad is theirs.
+//
+// The alpha value of clr is ignored.
+//
+// SetGamepadLEDColor works only on Linux so far.
+// On Linux, the light bar is controlled via sysfs, and writing it usually requires a udev rule to grant the permission.
+//
+// SetGamepadLEDColor returns ErrGamepadLEDNotSupported when the gamepad is not connected,
+// the gamepad doesn't have a light bar, or the platform doesn't support it.
+//
+// SetGamepadLEDColor is concurrent-safe.
+func SetGamepadLEDColor(id GamepadID, clr color.Color) error {
+	g := gamepad.Get(id)
+	if g == nil {
+		return ErrGamepadLEDNotSupported
+	}
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return g.SetLEDColor(c.R, c.G, c.B)
+}
+
 // AppendGamepadIDs appends available gamepad IDs to gamepadIDs, and returns the extended buffer.
 // Giving a slice that already has enough capacity works efficiently.
 //
@@ -156,6 +294,9 @@ func GamepadIDs() []GamepadID {
 
 // GamepadAxisCount returns the number of axes of the gamepad (id).
 //
+// GamepadAxisCount reports the raw axes of the device, regardless of whether a standard layout mapping is available.
+// The number might change when the gamepad is reconnected.
+//
 // GamepadAxisCount is concurrent-safe.
 func GamepadAxisCount(id GamepadID) int {
 	g := gamepad.Get(id)
@@ -174,6 +315,12 @@ func GamepadAxisNum(id GamepadID) int {
 
 // GamepadAxisValue returns a float value [-1.0 - 1.0] of the given gamepad (id)'s axis (axis).
 //
+// GamepadAxisValue returns the raw value of the device's axis, regardless of whether a standard layout mapping is available.
+// This is useful for axes that don't fit the standard layout, like a throttle or a rudder.
+// To get a value in the standard layout, use StandardGamepadAxisValue.
+//
+// GamepadAxisValue returns 0 if axis is out of range.
+//
 // GamepadAxisValue is concurrent-safe.
 func GamepadAxisValue(id GamepadID, axis GamepadAxisType) float64 {
 	g := gamepad.Get(id)
@@ -311,6 +458,35 @@ func IsStandardGamepadButtonAvailable(id GamepadID, button StandardGamepadButton
 	return g.IsStandardButtonAvailable(button)
 }
 
+// StandardGamepadButtonFromRaw returns the standard gamepad button that the button of the gamepad (id) is mapped to.
+// button is the same as the one for IsGamepadButtonPressed, so a direction of a hat is also resolved.
+// This is useful for a tool to calibrate gamepads, e.g., to show which standard button the pressed button works as.
+//
+// StandardGamepadButtonFromRaw returns false when no standard button is mapped to the button.
+// StandardGamepadButtonFromRaw works only for a gamepad whose standard layout mapping comes from SDL_GameControllerDB,
+// so StandardGamepadButtonFromRaw always returns false on browsers and mobiles.
+//
+// StandardGamepadButtonFromRaw is concurrent-safe.
+func StandardGamepadButtonFromRaw(id GamepadID, button GamepadButton) (StandardGamepadButton, bool) {
+	g := gamepad.Get(id)
+	if g == nil {
+		return 0, false
+	}
+
+	nbuttons := g.ButtonCount()
+	if int(button) < nbuttons {
+		return g.StandardButtonFromRaw(int(button))
+	}
+
+	// For backward compatibility, hats are treated as buttons in GLFW.
+	if hat := (int(button) - nbuttons) / 4; hat < g.HatCount() {
+		dir := (int(button) - nbuttons) % 4
+		return g.StandardButtonFromRawHat(hat, 1<<dir)
+	}
+
+	return 0, false
+}
+
 // UpdateStandardGamepadLayoutMappings parses the specified string mappings in SDL_GameControllerDB format and
 // updates the gamepad layout definitions.
 //
@@ -349,6 +525,129 @@ func UpdateStandardGamepadLayoutMappings(mappings string) (bool, error) {
 	return true, nil
 }
 
+// OverrideStandardGamepadLayoutMapping parses the specified string as a single gamepad layout definition
+// in the SDL_GameControllerDB format, and adds it as an override.
+//
+// An override replaces the existing definition for the same GUID,
+// and takes precedence over the definitions added by UpdateStandardGamepadLayoutMappings, even ones added later.
+// An override is kept while the process is running.
+// OverrideStandardGamepadLayoutMapping is useful to let players specify mappings for their gamepads at runtime.
+//
+// OverrideStandardGamepadLayoutMapping returns an error if the definition is malformed or is not for the current platform.
+// See UpdateStandardGamepadLayoutMappings for the platform field.
+//
+// OverrideStandardGamepadLayoutMapping is concurrent-safe.
+func OverrideStandardGamepadLayoutMapping(mapping string) error {
+	return gamepaddb.AddOverride(mapping)
+}
+
+// WatchStandardGamepadLayoutMappingsFile loads gamepad layout definitions in the SDL_GameControllerDB format from the file at path,
+// and reloads them whenever the file is modified.
+// The definitions are applied in the same way as UpdateStandardGamepadLayoutMappings.
+//
+// WatchStandardGamepadLayoutMappingsFile is intended for development, e.g., to edit a mapping for a new gamepad
+// without restarting the game.
+// The file is polled, and is reloaded after the file stops changing.
+// Only one file can be watched at a time, and calling WatchStandardGamepadLayoutMappingsFile again stops watching the previous file.
+//
+// WatchStandardGamepadLayoutMappingsFile returns an error if the initial load fails, and then the file is not watched.
+// An error on reloading doesn't stop watching, and is reported by StandardGamepadLayoutMappingsFileError.
+//
+// WatchStandardGamepadLayoutMappingsFile is concurrent-safe.
+func WatchStandardGamepadLayoutMappingsFile(path string) error {
+	return gamepaddb.WatchFile(path)
+}
+
+// StopWatchingStandardGamepadLayoutMappingsFile stops watching the file specified by WatchStandardGamepadLayoutMappingsFile.
+// The definitions already loaded from the file are kept.
+//
+// StopWatchingStandardGamepadLayoutMappingsFile is concurrent-safe.
+func StopWatchingStandardGamepadLayoutMappingsFile() {
+	gamepaddb.StopWatchingFile()
+}
+
+// StandardGamepadLayoutMappingsFileError returns the error of the last reload of the file
+// specified by WatchStandardGamepadLayoutMappingsFile, e.g., a parse error while the file is being edited.
+// StandardGamepadLayoutMappingsFileError returns nil if the last reload succeeded.
+//
+// StandardGamepadLayoutMappingsFileError is concurrent-safe.
+func StandardGamepadLayoutMappingsFileError() error {
+	return gamepaddb.WatchError()
+}
+
+// SetStandardGamepadLayoutNameFallbackEnabled enables or disables finding a standard gamepad layout mapping by a gamepad name.
+//
+// When the fallback is enabled and there is no mapping for a gamepad's SDL ID,
+// a mapping is looked up by the gamepad name, like "Xbox 360 Controller", from a small table Ebitengine has.
+// This is useful on platforms reporting unreliable SDL IDs with consistent gamepad names, e.g., old Linux drivers.
+// As the matching is heuristic, the fallback is disabled by default.
+//
+// SetStandardGamepadLayoutNameFallbackEnabled affects already connected gamepads too.
+//
+// SetStandardGamepadLayoutNameFallbackEnabled is concurrent-safe.
+func SetStandardGamepadLayoutNameFallbackEnabled(enabled bool) {
+	gamepaddb.EnableNameFallback(enabled)
+}
+
+// IsStandardGamepadLayoutNameFallbackEnabled reports whether finding a standard gamepad layout mapping by a gamepad name is enabled.
+//
+// IsStandardGamepadLayoutNameFallbackEnabled is concurrent-safe.
+func IsStandardGamepadLayoutNameFallbackEnabled() bool {
+	return gamepaddb.IsNameFallbackEnabled()
+}
+
+// SetStandardGamepadAxisDeadzone sets the deadzone threshold for the given standard axis of all the gamepads.
+//
+// A value of StandardGamepadAxisValue whose absolute value is less than or equal to threshold is reported as 0.
+// A value outside of the deadzone is rescaled so that the value changes continuously from 0 at the threshold to 1.
+// SetStandardGamepadAxisDeadzone is useful to suppress a stick drift.
+//
+// threshold must be in [0, 1), or SetStandardGamepadAxisDeadzone panics.
+// The default threshold is 0, which means no deadzone.
+//
+// SetStandardGamepadAxisDeadzone is concurrent-safe.
+func SetStandardGamepadAxisDeadzone(axis StandardGamepadAxis, threshold float64) {
+	gamepaddb.SetAxisDeadzone(axis, threshold)
+}
+
+// KnownGamepad represents a gamepad model that has a standard gamepad layout mapping.
+type KnownGamepad struct {
+	// SDLID is the SDL ID (GUID) of the gamepad model. See GamepadSDLID.
+	SDLID string
+
+	// Name is the name of the gamepad model in the mapping.
+	Name string
+
+	// MappingCompleteness is the fraction of the standard layout buttons and axes that are mapped, in [0, 1].
+	// 1 means all the buttons and axes of the standard layout are available.
+	MappingCompleteness float64
+}
+
+// KnownGamepads returns the gamepad models that have standard gamepad layout mappings for the current platform,
+// sorted by the SDL IDs.
+// The gamepads don't have to be connected.
+//
+// KnownGamepads is useful to show which gamepad models are supported fully or partially.
+//
+// On platforms where gamepad mappings are not managed by Ebitengine, KnownGamepads returns nil.
+//
+// KnownGamepads is concurrent-safe.
+func KnownGamepads() []KnownGamepad {
+	infos := gamepaddb.ListMappings(gamepaddb.CurrentPlatformName())
+	if len(infos) == 0 {
+		return nil
+	}
+	gamepads := make([]KnownGamepad, 0, len(infos))
+	for _, info := range infos {
+		gamepads = append(gamepads, KnownGamepad{
+			SDLID:               info.ID,
+			Name:                info.Name,
+			MappingCompleteness: info.Completeness,
+		})
+	}
+	return gamepads
+}
+
 // TouchID represents a touch's identifier.
 type TouchID int
 
@@ -401,6 +700,21 @@ func (i *inputState) appendInputChars(runes []rune) []rune {
 	return append(runes, i.state.Runes...)
 }
 
+func (i *inputState) appendInputEvents(events []InputEvent) []InputEvent {
+	i.m.Lock()
+	defer i.m.Unlock()
+	for _, e := range i.state.Events {
+		events = append(events, InputEvent{
+			Type:        InputEventType(e.Type),
+			Key:         Key(e.Key),
+			MouseButton: MouseButton(e.MouseButton),
+			Pressed:     e.Pressed,
+			Time:        e.Time,
+		})
+	}
+	return events
+}
+
 func (i *inputState) isKeyPressed(key Key) bool {
 	if !key.isValid() {
 		return false
@@ -429,6 +743,15 @@ func (i *inputState) cursorPosition() (float64, float64) {
 	return i.state.CursorX, i.state.CursorY
 }
 
+func (i *inputState) appendCursorPositionHistory(positions []image.Point) []image.Point {
+	i.m.Lock()
+	defer i.m.Unlock()
+	for _, p := range i.state.CursorHistory {
+		positions = append(positions, image.Pt(int(p.X), int(p.Y)))
+	}
+	return positions
+}
+
 func (i *inputState) wheel() (float64, float64) {
 	i.m.Lock()
 	defer i.m.Unlock()
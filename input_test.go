@@ -0,0 +1,181 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"image"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestAppendInputEvents(t *testing.T) {
+	defer ebiten.ResetInputEventsForTesting()
+
+	now := time.Now()
+	want := []ebiten.InputEvent{
+		{Type: ebiten.InputEventTypeKey, Key: ebiten.KeyA, Pressed: true, Time: now},
+		{Type: ebiten.InputEventTypeMouseButton, MouseButton: ebiten.MouseButtonLeft, Pressed: true, Time: now.Add(time.Millisecond)},
+		{Type: ebiten.InputEventTypeKey, Key: ebiten.KeyA, Pressed: false, Time: now.Add(2 * time.Millisecond)},
+		{Type: ebiten.InputEventTypeKey, Key: ebiten.KeyB, Pressed: true, Time: now.Add(3 * time.Millisecond)},
+		{Type: ebiten.InputEventTypeMouseButton, MouseButton: ebiten.MouseButtonLeft, Pressed: false, Time: now.Add(4 * time.Millisecond)},
+	}
+	for _, e := range want {
+		ebiten.AppendInputEventForTesting(e)
+	}
+
+	got := ebiten.AppendInputEvents(nil)
+	if len(got) != len(want) {
+		t.Fatalf("len(got): got: %d, want: %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d]: got: %v, want: %v", i, got[i], want[i])
+		}
+		if d := got[i].Time.Sub(now); d != time.Duration(i)*time.Millisecond {
+			t.Errorf("got[%d].Time.Sub(now): got: %v, want: %v", i, d, time.Duration(i)*time.Millisecond)
+		}
+	}
+}
+
+func TestAppendInputEventsOverflow(t *testing.T) {
+	defer ebiten.ResetInputEventsForTesting()
+
+	const n = 10000
+	now := time.Now()
+	for i := 0; i < n; i++ {
+		ebiten.AppendInputEventForTesting(ebiten.InputEvent{
+			Type:    ebiten.InputEventTypeKey,
+			Key:     ebiten.KeyA,
+			Pressed: i%2 == 0,
+			Time:    now.Add(time.Duration(i)),
+		})
+	}
+
+	got := ebiten.AppendInputEvents(nil)
+	if len(got) == 0 || len(got) >= n {
+		t.Fatalf("len(got): got: %d, want: in (0, %d)", len(got), n)
+	}
+	// The oldest events must be dropped.
+	for i, e := range got {
+		if want := now.Add(time.Duration(n - len(got) + i)); !e.Time.Equal(want) {
+			t.Errorf("got[%d].Time: got: %v, want: %v", i, e.Time, want)
+		}
+	}
+}
+
+func TestAppendCursorPositionHistory(t *testing.T) {
+	defer ebiten.ResetCursorPositionHistoryForTesting()
+
+	want := []image.Point{
+		image.Pt(1, 2),
+		image.Pt(3, 4),
+		image.Pt(10, 5),
+		image.Pt(0, 7),
+	}
+	for _, p := range want {
+		ebiten.AppendCursorPositionForTesting(float64(p.X)+0.5, float64(p.Y)+0.25)
+	}
+
+	// The given slice must be extended.
+	buf := []image.Point{image.Pt(100, 100)}
+	got := ebiten.AppendCursorPositionHistory(buf)
+	if len(got) != len(want)+1 {
+		t.Fatalf("len(got): got: %d, want: %d", len(got), len(want)+1)
+	}
+	if got[0] != image.Pt(100, 100) {
+		t.Errorf("got[0]: got: %v, want: %v", got[0], image.Pt(100, 100))
+	}
+	for i := range want {
+		if got[i+1] != want[i] {
+			t.Errorf("got[%d]: got: %v, want: %v", i+1, got[i+1], want[i])
+		}
+	}
+}
+
+func TestInputHistory(t *testing.T) {
+	type record struct {
+		Keys    []string `json:"keys"`
+		CursorX float64  `json:"cursorX"`
+		CursorY float64  `json:"cursorY"`
+	}
+	dump := func(h *ebiten.InputHistory) []record {
+		var buf bytes.Buffer
+		if err := h.Dump(&buf); err != nil {
+			t.Fatal(err)
+		}
+		var rs []record
+		s := bufio.NewScanner(&buf)
+		for s.Scan() {
+			var r record
+			if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+				t.Fatal(err)
+			}
+			rs = append(rs, r)
+		}
+		return rs
+	}
+
+	var h ebiten.InputHistory
+
+	// Nothing is recorded before the size is set.
+	h.Record([]ebiten.Key{ebiten.KeyA}, 1, 2)
+	if got := dump(&h); len(got) != 0 {
+		t.Errorf("got: %v, want: no records", got)
+	}
+
+	// Only the most recent records are kept from the oldest to the newest.
+	h.SetSize(2)
+	h.Record([]ebiten.Key{ebiten.KeyA}, 1, 2)
+	h.Record(nil, 3, 4)
+	h.Record([]ebiten.Key{ebiten.KeyB}, 5, 6)
+	want := []record{
+		{CursorX: 3, CursorY: 4},
+		{Keys: []string{"B"}, CursorX: 5, CursorY: 6},
+	}
+	if got := dump(&h); !slices.EqualFunc(got, want, func(a, b record) bool {
+		return slices.Equal(a.Keys, b.Keys) && a.CursorX == b.CursorX && a.CursorY == b.CursorY
+	}) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	// Shrinking the history keeps the newest records.
+	h.SetSize(1)
+	if got := dump(&h); len(got) != 1 || got[0].CursorX != 5 {
+		t.Errorf("got: %v, want: only the newest record", got)
+	}
+
+	// A non-positive size discards the history.
+	h.SetSize(0)
+	if got := dump(&h); len(got) != 0 {
+		t.Errorf("got: %v, want: no records", got)
+	}
+}
+
+func TestDumpInputHistoryDisabled(t *testing.T) {
+	// The input history is disabled by default.
+	var buf bytes.Buffer
+	if err := ebiten.DumpInputHistory(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("DumpInputHistory must write nothing when the history is disabled but: %q", buf.String())
+	}
+}
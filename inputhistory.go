@@ -0,0 +1,197 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// SetInputHistorySize sets the number of the most recent ticks whose input states are recorded.
+//
+// The input history is useful to attach recent inputs to a crash report.
+// The recorded history can be written by DumpInputHistory.
+//
+// If ticks is 0 or less, the input history is not recorded and the existing history is discarded.
+// The default value is 0.
+//
+// SetInputHistorySize is concurrent-safe.
+func SetInputHistorySize(ticks int) {
+	theInputHistory.setSize(ticks)
+}
+
+// DumpInputHistory writes the recorded input history to w.
+//
+// The history is written in the JSON Lines format, one JSON object per tick, from the oldest to the newest.
+// Each object has the tick, the pressed keys, the pressed mouse buttons, the cursor position, the wheel offsets,
+// the touches, the input characters, and the states of the connected gamepads.
+//
+// If the input history is not enabled by SetInputHistorySize, DumpInputHistory writes nothing.
+//
+// DumpInputHistory is concurrent-safe.
+func DumpInputHistory(w io.Writer) error {
+	return theInputHistory.dump(w)
+}
+
+type inputHistoryTouch struct {
+	ID int `json:"id"`
+	X  int `json:"x"`
+	Y  int `json:"y"`
+}
+
+type inputHistoryGamepad struct {
+	ID      int       `json:"id"`
+	SDLID   string    `json:"sdlId,omitempty"`
+	Axes    []float64 `json:"axes,omitempty"`
+	Buttons []bool    `json:"buttons,omitempty"`
+}
+
+type inputHistoryRecord struct {
+	Tick         uint64                `json:"tick"`
+	Keys         []string              `json:"keys,omitempty"`
+	MouseButtons []int                 `json:"mouseButtons,omitempty"`
+	CursorX      float64               `json:"cursorX"`
+	CursorY      float64               `json:"cursorY"`
+	WheelX       float64               `json:"wheelX,omitempty"`
+	WheelY       float64               `json:"wheelY,omitempty"`
+	Touches      []inputHistoryTouch   `json:"touches,omitempty"`
+	Runes        string                `json:"runes,omitempty"`
+	Gamepads     []inputHistoryGamepad `json:"gamepads,omitempty"`
+}
+
+var theInputHistory inputHistory
+
+// inputHistory is a ring buffer of input states.
+type inputHistory struct {
+	records []inputHistoryRecord
+
+	// head is the index of the oldest record.
+	head int
+
+	// count is the number of the valid records.
+	count int
+
+	gamepadIDs []gamepad.ID
+
+	m sync.Mutex
+}
+
+func (h *inputHistory) setSize(ticks int) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if ticks <= 0 {
+		h.records = nil
+		h.head = 0
+		h.count = 0
+		return
+	}
+
+	// Keep the newest records as much as possible.
+	records := make([]inputHistoryRecord, ticks)
+	n := min(h.count, ticks)
+	for i := 0; i < n; i++ {
+		records[i] = h.records[(h.head+h.count-n+i)%len(h.records)]
+	}
+	h.records = records
+	h.head = 0
+	h.count = n
+}
+
+func (h *inputHistory) record(state *ui.InputState) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if len(h.records) == 0 {
+		return
+	}
+
+	var idx int
+	if h.count < len(h.records) {
+		idx = (h.head + h.count) % len(h.records)
+		h.count++
+	} else {
+		idx = h.head
+		h.head = (h.head + 1) % len(h.records)
+	}
+
+	// Reuse the slices of the overwritten record.
+	r := &h.records[idx]
+	r.Tick = ui.Get().Tick()
+	r.Keys = r.Keys[:0]
+	for k, pressed := range state.KeyPressed {
+		if !pressed {
+			continue
+		}
+		r.Keys = append(r.Keys, Key(k).String())
+	}
+	r.MouseButtons = r.MouseButtons[:0]
+	for b, pressed := range state.MouseButtonPressed {
+		if !pressed {
+			continue
+		}
+		r.MouseButtons = append(r.MouseButtons, b)
+	}
+	r.CursorX = state.CursorX
+	r.CursorY = state.CursorY
+	r.WheelX = state.WheelX
+	r.WheelY = state.WheelY
+	r.Touches = r.Touches[:0]
+	for _, t := range state.Touches {
+		r.Touches = append(r.Touches, inputHistoryTouch{
+			ID: int(t.ID),
+			X:  t.X,
+			Y:  t.Y,
+		})
+	}
+	r.Runes = string(state.Runes)
+
+	r.Gamepads = r.Gamepads[:0]
+	h.gamepadIDs = gamepad.AppendGamepadIDs(h.gamepadIDs[:0])
+	for _, id := range h.gamepadIDs {
+		g := gamepad.Get(id)
+		if g == nil {
+			continue
+		}
+		gp := inputHistoryGamepad{
+			ID:    int(id),
+			SDLID: g.SDLID(),
+		}
+		for i := 0; i < g.AxisCount(); i++ {
+			gp.Axes = append(gp.Axes, g.Axis(i))
+		}
+		for i := 0; i < g.ButtonCount(); i++ {
+			gp.Buttons = append(gp.Buttons, g.Button(i))
+		}
+		r.Gamepads = append(r.Gamepads, gp)
+	}
+}
+
+func (h *inputHistory) dump(w io.Writer) error {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	e := json.NewEncoder(w)
+	for i := 0; i < h.count; i++ {
+		if err := e.Encode(&h.records[(h.head+i)%len(h.records)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
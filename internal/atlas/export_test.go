@@ -75,3 +75,15 @@ func BackendCountForTesting() int {
 	defer backendsM.Unlock()
 	return len(theBackends)
 }
+
+func (i *Image) AllocateForTesting() {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	i.allocate(nil, true)
+}
+
+func (i *Image) SharesBackendForTesting(other *Image) bool {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+	return i.backend == other.backend
+}
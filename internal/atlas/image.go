@@ -20,6 +20,7 @@ import (
 	"math"
 	"math/bits"
 	"runtime"
+	"slices"
 	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
@@ -320,6 +321,89 @@ func (i *Image) putOnSourceBackend() {
 	}
 }
 
+// Repack moves the given images into new source backends, which are packed tightly only with the given images.
+//
+// Repack is useful when the images are poorly packed, for example after many images on the same atlas are deallocated.
+// Images that are not on an atlas, including images not allocated yet, are ignored.
+func Repack(images []*Image) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		imgs := make([]*Image, len(images))
+		copy(imgs, images)
+
+		appendDeferred(func() {
+			repack(imgs)
+		})
+		return
+	}
+
+	repack(images)
+}
+
+func repack(images []*Image) {
+	var imgs []*Image
+	for _, img := range images {
+		if img == nil || !img.isOnAtlas() {
+			continue
+		}
+		if slices.Contains(imgs, img) {
+			continue
+		}
+		imgs = append(imgs, img)
+	}
+	if len(imgs) == 0 {
+		return
+	}
+
+	// Put taller images first so that the images are packed tightly.
+	slices.SortStableFunc(imgs, func(a, b *Image) int {
+		if a.height != b.height {
+			return b.height - a.height
+		}
+		return b.width - a.width
+	})
+
+	var backends []*backend
+	for _, i := range imgs {
+		newI := NewImage(i.width, i.height, i.imageType)
+		wp := i.width + i.paddingSize()
+		hp := i.height + i.paddingSize()
+		for _, b := range backends {
+			if n, ok := b.tryAlloc(wp, hp); ok {
+				newI.backend = b
+				newI.node = n
+				break
+			}
+		}
+		if newI.backend == nil {
+			b := i.newBackendOnAtlas(true)
+			n := b.page.Alloc(wp, hp)
+			if n == nil {
+				panic("atlas: Alloc result must not be nil at repack")
+			}
+			backends = append(backends, b)
+			newI.backend = b
+			newI.node = n
+		}
+		runtime.SetFinalizer(newI, (*Image).finalize)
+
+		w, h := float32(i.width), float32(i.height)
+		vs := make([]float32, 4*graphics.VertexFloatCount)
+		graphics.QuadVerticesFromDstAndSrc(vs, 0, 0, w, h, 0, 0, w, h, 1, 1, 1, 1)
+		is := graphics.QuadIndices()
+		dr := image.Rect(0, 0, i.width, i.height)
+		sr := image.Rect(0, 0, i.width, i.height)
+		newI.drawTriangles([graphics.ShaderSrcImageCount]*Image{i}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, restorable.HintOverwriteDstRegion)
+
+		// Keep the counter since the image's usage doesn't change.
+		usedAsDestinationCount := i.usedAsDestinationCount
+		newI.moveTo(i)
+		i.usedAsDestinationCount = usedAsDestinationCount
+	}
+}
+
 func (i *Image) regionWithPadding() image.Rectangle {
 	if i.backend == nil {
 		panic("atlas: backend must not be nil: not allocated yet?")
@@ -473,6 +557,11 @@ func (i *Image) writePixels(pix []byte, region image.Rectangle) {
 		if pix == nil {
 			return
 		}
+		// An image not on an atlas can be created with the pixels directly, which skips clearing the image.
+		if !i.canBePutOnAtlas() && i.imageType != ImageTypeScreen && region.Eq(image.Rect(0, 0, i.width, i.height)) {
+			i.allocateWithPixels(pix)
+			return
+		}
 		// Allocate as a source as this image will likely be used as a source.
 		i.allocate(nil, true)
 	}
@@ -692,6 +781,46 @@ loop:
 		}
 	}
 
+	b := i.newBackendOnAtlas(asSource)
+	n := b.page.Alloc(wp, hp)
+	if n == nil {
+		panic("atlas: Alloc result must not be nil at allocate")
+	}
+	i.backend = b
+	i.node = n
+}
+
+// allocateWithPixels allocates a backend for the image i with the initial pixels.
+// allocateWithPixels is available only for an image that cannot be put on an atlas.
+func (i *Image) allocateWithPixels(pix []byte) {
+	if i.backend != nil {
+		panic("atlas: the image is already allocated")
+	}
+	if i.width+i.paddingSize() > maxSize || i.height+i.paddingSize() > maxSize {
+		panic(fmt.Sprintf("atlas: the image being put on an atlas is too big: width: %d, height: %d", i.width, i.height))
+	}
+	if i.paddingSize() != 0 {
+		panic(fmt.Sprintf("atlas: allocateWithPixels assumes the padding is always 0 but the actual padding was %d", i.paddingSize()))
+	}
+
+	runtime.SetFinalizer(i, (*Image).finalize)
+
+	typ := restorable.ImageTypeRegular
+	if i.imageType == ImageTypeVolatile {
+		typ = restorable.ImageTypeVolatile
+	}
+	i.backend = &backend{
+		restorable: restorable.NewImageWithPixels(i.width, i.height, pix, typ),
+		source:     typ == restorable.ImageTypeRegular,
+	}
+	theBackends = append(theBackends, i.backend)
+}
+
+// newBackendOnAtlas creates a new backend with an atlas page that is big enough for the image i.
+func (i *Image) newBackendOnAtlas(asSource bool) *backend {
+	wp := i.width + i.paddingSize()
+	hp := i.height + i.paddingSize()
+
 	var width, height int
 	if asSource {
 		width, height = minSourceSize, minSourceSize
@@ -721,13 +850,7 @@ loop:
 		source:     asSource,
 	}
 	theBackends = append(theBackends, b)
-
-	n := b.page.Alloc(wp, hp)
-	if n == nil {
-		panic("atlas: Alloc result must not be nil at allocate")
-	}
-	i.backend = b
-	i.node = n
+	return b
 }
 
 func (i *Image) DumpScreenshot(graphicsDriver graphicsdriver.Graphics, path string, blackbg bool) (string, error) {
@@ -775,6 +898,22 @@ func SwapBuffers(graphicsDriver graphicsdriver.Graphics) error {
 	return nil
 }
 
+// FlushCommands flushes the queued draw commands without ending the current frame.
+// FlushCommands returns false when this is called out of a frame. Try this later in this case.
+func FlushCommands(graphicsDriver graphicsdriver.Graphics) (ok bool, err error) {
+	backendsM.Lock()
+	defer backendsM.Unlock()
+
+	if !inFrame {
+		return false, nil
+	}
+
+	if err := restorable.FlushCommands(graphicsDriver); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func floorPowerOf2(x int) int {
 	if x <= 0 {
 		return 0
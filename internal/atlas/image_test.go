@@ -915,3 +915,114 @@ func TestDallocateUnmanagedImageBackends(t *testing.T) {
 }
 
 // TODO: Add tests to extend image on an atlas out of the main loop
+
+func TestRepack(t *testing.T) {
+	const size = 16
+
+	var imgs []*atlas.Image
+	var fillers []*atlas.Image
+	for i := 0; i < 4; i++ {
+		img := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+		defer img.Deallocate()
+		pix := make([]byte, 4*size*size)
+		for j := range pix {
+			pix[j] = byte(i + 1)
+		}
+		img.WritePixels(pix, image.Rect(0, 0, size, size))
+		imgs = append(imgs, img)
+
+		// Allocate another image between the images, and deallocate it later so that the atlas has holes.
+		filler := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+		filler.WritePixels(make([]byte, 4*size*size), image.Rect(0, 0, size, size))
+		fillers = append(fillers, filler)
+	}
+	for _, f := range fillers {
+		f.Deallocate()
+	}
+
+	// other is not repacked.
+	other := atlas.NewImage(size, size, atlas.ImageTypeRegular)
+	defer other.Deallocate()
+	other.WritePixels(make([]byte, 4*size*size), image.Rect(0, 0, size, size))
+
+	atlas.Repack(imgs)
+
+	for i, img := range imgs {
+		if !img.SharesBackendForTesting(imgs[0]) {
+			t.Errorf("imgs[%d] must share the backend with imgs[0]", i)
+		}
+		if img.SharesBackendForTesting(other) {
+			t.Errorf("imgs[%d] must not share the backend with an image that is not repacked", i)
+		}
+		if got, want := img.IsOnSourceBackendForTesting(), true; got != want {
+			t.Errorf("imgs[%d]: IsOnSourceBackendForTesting(): got: %v, want: %v", i, got, want)
+		}
+
+		pix := make([]byte, 4*size*size)
+		ok, err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), pix, image.Rect(0, 0, size, size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("ReadPixels failed")
+		}
+		for j, got := range pix {
+			if want := byte(i + 1); got != want {
+				t.Errorf("imgs[%d]: pix[%d]: got: %d, want: %d", i, j, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestWritePixelsToTooBigUnmanagedImage(t *testing.T) {
+	const w, h = maxImageSizeForTesting + 1, 1
+	img := atlas.NewImage(w, h, atlas.ImageTypeUnmanaged)
+	defer img.Deallocate()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("WritePixels to a too big image must panic but not")
+		}
+	}()
+	img.WritePixels(make([]byte, 4*w*h), image.Rect(0, 0, w, h))
+}
+
+func BenchmarkNewUnmanagedImagesWithPixels(b *testing.B) {
+	// Emulate loading many images at startup.
+	const (
+		n    = 100
+		size = 128
+	)
+	pix := make([]byte, 4*size*size)
+	for i := range pix {
+		pix[i] = 0xff
+	}
+
+	for _, withClear := range []bool{false, true} {
+		name := "WithoutClear"
+		if withClear {
+			name = "WithClear"
+		}
+		b.Run(name, func(b *testing.B) {
+			imgs := make([]*atlas.Image, n)
+			for i := 0; i < b.N; i++ {
+				for j := range imgs {
+					img := atlas.NewImage(size, size, atlas.ImageTypeUnmanaged)
+					if withClear {
+						// Allocating the image without pixels clears the image before WritePixels.
+						img.AllocateForTesting()
+					}
+					img.WritePixels(pix, image.Rect(0, 0, size, size))
+					imgs[j] = img
+				}
+				if _, err := atlas.FlushCommands(ui.Get().GraphicsDriverForTesting()); err != nil {
+					b.Fatal(err)
+				}
+				for _, img := range imgs {
+					img.Deallocate()
+				}
+			}
+		})
+	}
+}
@@ -70,6 +70,17 @@ func (i *Image) Deallocate() {
 	i.pixelsUnsynced = false
 }
 
+// Repack moves the given images into new tightly-packed atlases.
+// See atlas.Repack.
+func Repack(images []*Image) {
+	imgs := make([]*atlas.Image, 0, len(images))
+	for _, i := range images {
+		i.syncPixelsIfNeeded()
+		imgs = append(imgs, i.img)
+	}
+	atlas.Repack(imgs)
+}
+
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) (bool, error) {
 	if region.Dx() == 1 && region.Dy() == 1 {
 		if c, ok := i.dotsBuffer[region.Min]; ok {
@@ -0,0 +1,381 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+const (
+	UniformIntensity = "Intensity"
+)
+
+// ColorGradeShaderSource is a shader to remap colors with a 3D color lookup table (LUT).
+//
+// The source image 0 is the image to be graded, and the source image 1 is the LUT.
+// The LUT is a N*N*N cube unrolled horizontally into N slices of N*N pixels,
+// then the LUT image size is (N*N, N).
+// A slice corresponds to a blue value, and red and green values are mapped to x and y in the slice.
+// The size N is determined by the LUT image's height, so LUTs of any size like 16 and 32 work.
+// Colors between the cells are interpolated trilinearly.
+//
+//ebitengine:shadersource
+const ColorGradeShaderSource = `//kage:unit pixels
+
+package main
+
+var Intensity float
+
+// lutAt returns the color of the given cell in the LUT whose size is n.
+func lutAt(cell vec3, n float) vec3 {
+	pos := vec2(cell.b*n+cell.r, cell.g) + 0.5
+	return imageSrc1At(imageSrc1Origin() + pos).rgb
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0At(srcPos)
+
+	// Un-premultiply alpha.
+	// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.
+	rgb := clr.rgb / (clr.a + (1-sign(clr.a)))
+
+	// Interpolate the 8 neighboring cells trilinearly to avoid banding.
+	n := imageSrc1Size().y
+	p := clamp(rgb, 0, 1) * (n - 1)
+	c0 := floor(p)
+	c1 := min(c0+1, n-1)
+	f := p - c0
+	g00 := mix(lutAt(c0, n), lutAt(vec3(c1.r, c0.g, c0.b), n), f.r)
+	g10 := mix(lutAt(vec3(c0.r, c1.g, c0.b), n), lutAt(vec3(c1.r, c1.g, c0.b), n), f.r)
+	g01 := mix(lutAt(vec3(c0.r, c0.g, c1.b), n), lutAt(vec3(c1.r, c0.g, c1.b), n), f.r)
+	g11 := mix(lutAt(vec3(c0.r, c1.g, c1.b), n), lutAt(c1, n), f.r)
+	graded := mix(mix(g00, g10, f.g), mix(g01, g11, f.g), f.b)
+
+	rgb = mix(rgb, graded, Intensity)
+
+	// Premultiply alpha and apply the color scale.
+	return vec4(rgb*clr.a, clr.a) * color
+}
+`
+
+const (
+	UniformThreshold = "Threshold"
+	UniformDirection = "Direction"
+	UniformRadius    = "Radius"
+)
+
+// BrightPassShaderSource is a shader to extract bright colors whose luminance exceeds a threshold.
+//
+//ebitengine:shadersource
+const BrightPassShaderSource = `//kage:unit pixels
+
+package main
+
+var Threshold float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	clr := imageSrc0At(srcPos)
+	l := dot(clr.rgb, vec3(0.2126, 0.7152, 0.0722))
+	// Scale the color so that the luminance becomes l - Threshold.
+	rate := max(l-Threshold, 0) / max(l, 1.0/255.0)
+	return clr * rate * color
+}
+`
+
+// MaxBlurRadius is the maximum radius in pixels of BlurShaderSource.
+const MaxBlurRadius = 16
+
+// BlurShaderSource is a shader to apply a one-dimensional Gaussian blur.
+// A two-dimensional blur is achieved by applying this shader horizontally and then vertically.
+//
+//ebitengine:shadersource
+const BlurShaderSource = `//kage:unit pixels
+
+package main
+
+var Direction vec2
+var Radius float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	if Radius < 1 {
+		return imageSrc0At(srcPos) * color
+	}
+
+	sigma := Radius / 2
+	var sum vec4
+	var weight float
+	for i := -16; i <= 16; i++ {
+		x := float(i)
+		if abs(x) > Radius {
+			continue
+		}
+		w := exp(-x*x / (2*sigma*sigma))
+		sum += imageSrc0At(srcPos+Direction*x) * w
+		weight += w
+	}
+	return sum / weight * color
+}
+`
+
+const (
+	UniformNoiseType = "NoiseType"
+	UniformFrequency = "Frequency"
+	UniformOctaves   = "Octaves"
+	UniformOffset    = "Offset"
+)
+
+const (
+	NoiseTypeValue = iota
+	NoiseTypePerlin
+	NoiseTypeSimplex
+)
+
+// MaxNoiseOctaves is the maximum number of octaves of NoiseShaderSource.
+const MaxNoiseOctaves = 8
+
+// NoiseShaderSource is a shader to generate a grayscale fractal noise.
+//
+// NoiseType specifies the basis noise. Frequency is the number of noise cells per pixel.
+// Octaves is the number of layered noises, each of which has the double frequency and the half amplitude of the previous one.
+// Offset is added to the noise coordinate, and works as a seed.
+//
+// The random values at lattice points are calculated with integers, so the noise is the same on any GPUs
+// except for tiny differences by floating point errors. The noise repeats every 289 cells.
+//
+//ebitengine:shadersource
+const NoiseShaderSource = `//kage:unit pixels
+
+package main
+
+var NoiseType int
+var Frequency float
+var Octaves int
+var Offset vec2
+
+// permute is a permutation polynomial over [0, 289).
+// The calculation is done with integers so that the result doesn't depend on the GPU's floating point precision.
+func permute(x int) int {
+	return ((34*x + 10) * x) % 289
+}
+
+// hash returns a pseudo-random value in [0, 1] for a lattice point p.
+func hash(p vec2) float {
+	// p has integer values. The results of mod are exact, or 289 instead of 0, which is the same for permute.
+	x := int(mod(p.x, 289))
+	y := int(mod(p.y, 289))
+	return float(permute(permute(x)+y)) / 288
+}
+
+func gradient(p vec2) vec2 {
+	a := hash(p) * 6.2831853
+	return vec2(cos(a), sin(a))
+}
+
+func valueNoise(p vec2) float {
+	i := floor(p)
+	f := fract(p)
+	u := f * f * (3 - 2*f)
+	a := hash(i)
+	b := hash(i + vec2(1, 0))
+	c := hash(i + vec2(0, 1))
+	d := hash(i + vec2(1, 1))
+	return mix(mix(a, b, u.x), mix(c, d, u.x), u.y)
+}
+
+func perlinNoise(p vec2) float {
+	i := floor(p)
+	f := fract(p)
+	u := f * f * (3 - 2*f)
+	a := dot(gradient(i), f)
+	b := dot(gradient(i+vec2(1, 0)), f-vec2(1, 0))
+	c := dot(gradient(i+vec2(0, 1)), f-vec2(0, 1))
+	d := dot(gradient(i+vec2(1, 1)), f-vec2(1, 1))
+	// The range of a 2D Perlin noise is [-sqrt(1/2), sqrt(1/2)].
+	return mix(mix(a, b, u.x), mix(c, d, u.x), u.y)*0.7071 + 0.5
+}
+
+func simplexNoise(p vec2) float {
+	// Skew the coordinate to find the simplex cell.
+	// 0.3660254 is (sqrt(3)-1)/2 and 0.2113249 is (3-sqrt(3))/6.
+	i := floor(p + (p.x+p.y)*0.3660254)
+	a := p - i + (i.x+i.y)*0.2113249
+	m := step(a.y, a.x)
+	o := vec2(m, 1-m)
+	b := a - o + 0.2113249
+	c := a - 1 + 2*0.2113249
+	h := max(0.5-vec3(dot(a, a), dot(b, b), dot(c, c)), 0)
+	n := h * h * h * h * vec3(dot(a, gradient(i)), dot(b, gradient(i+o)), dot(c, gradient(i+1)))
+	return dot(n, vec3(70))*0.5 + 0.5
+}
+
+func noise(p vec2) float {
+	if NoiseType == 1 {
+		return perlinNoise(p)
+	}
+	if NoiseType == 2 {
+		return simplexNoise(p)
+	}
+	return valueNoise(p)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	p := (dstPos.xy-imageDstOrigin())*Frequency + Offset
+	sum := 0.0
+	total := 0.0
+	amp := 1.0
+	for i := 0; i < 8; i++ {
+		if i >= Octaves {
+			break
+		}
+		sum += noise(p) * amp
+		total += amp
+		amp *= 0.5
+		p *= 2
+	}
+	v := clamp(sum/total, 0, 1)
+	return vec4(v, v, v, 1) * color
+}
+`
+
+const (
+	UniformFilter = "Filter"
+)
+
+// SDFShaderSource is a shader to render a signed distance field (SDF) image.
+//
+// The source image 0 is a distance field whose alpha value is 0.5 at the edges,
+// larger inside the shape, and smaller outside the shape.
+// The edges are anti-aliased based on the screen-space derivatives.
+//
+// Filter is a Filter value. The distance field is sampled bilinearly with FilterLinear, and at the nearest texel otherwise.
+// ColorMBody and ColorMTranslation are a color matrix applied to the white color with the coverage as its alpha.
+//
+//ebitengine:shadersource
+const SDFShaderSource = `//kage:unit pixels
+
+package main
+
+var Filter int
+var ColorMBody mat4
+var ColorMTranslation vec4
+
+func distance(p vec2) float {
+	if Filter != 1 {
+		return imageSrc0At(floor(p) + 0.5).a
+	}
+
+	p -= 0.5
+	i := floor(p) + 0.5
+	f := fract(p)
+	a := imageSrc0At(i).a
+	b := imageSrc0At(i + vec2(1, 0)).a
+	c := imageSrc0At(i + vec2(0, 1)).a
+	d := imageSrc0At(i + vec2(1, 1)).a
+	return mix(mix(a, b, f.x), mix(c, d, f.x), f.y)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	d := distance(srcPos)
+	w := max(fwidth(d)*0.5, 1.0/255.0)
+	clr := vec4(1, 1, 1, smoothstep(0.5-w, 0.5+w, d))
+	clr = (ColorMBody * clr) + ColorMTranslation
+	clr.rgb *= clr.a
+	return clr * color
+}
+`
+
+const (
+	UniformOutlineColor = "OutlineColor"
+	UniformThickness    = "Thickness"
+)
+
+// MaxOutlineThickness is the maximum thickness of OutlineShaderSource.
+const MaxOutlineThickness = 8
+
+// OutlineShaderSource is a shader to draw an image with an outline around its opaque region.
+//
+// OutlineColor is a premultiplied-alpha color of the outline.
+// Thickness is the outline thickness in pixels.
+// The outline is the region within Thickness from the opaque pixels, and is drawn behind the image.
+// The pixels outside the source region are treated as transparent.
+//
+//ebitengine:shadersource
+const OutlineShaderSource = `//kage:unit pixels
+
+package main
+
+var OutlineColor vec4
+var Thickness float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+
+	var a float
+	for j := -8; j <= 8; j++ {
+		for i := -8; i <= 8; i++ {
+			d := vec2(float(i), float(j))
+			if length(d) > Thickness {
+				continue
+			}
+			a = max(a, imageSrc0At(srcPos+d).a)
+		}
+	}
+	return (c + OutlineColor*a*(1-c.a)) * color
+}
+`
+
+const (
+	UniformTransitionType = "TransitionType"
+	UniformProgress       = "Progress"
+)
+
+const (
+	TransitionTypeFade = iota
+	TransitionTypeWipeLeft
+	TransitionTypeCircleReveal
+	TransitionTypeDissolve
+)
+
+// TransitionShaderSource is a shader to blend two images for a scene transition.
+//
+// The source image 0 is the image to transition from, and the source image 1 is the image to transition to.
+// For TransitionTypeDissolve, the source image 2 is a grayscale noise image to determine the order of pixels to dissolve.
+// Progress is the progress of the transition in [0, 1].
+//
+//ebitengine:shadersource
+const TransitionShaderSource = `//kage:unit pixels
+
+package main
+
+var TransitionType int
+var Progress float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	from := imageSrc0At(srcPos)
+	to := imageSrc1At(srcPos)
+	pos := srcPos - imageSrc0Origin()
+	size := imageSrc0Size()
+
+	var t float
+	if TransitionType == 1 {
+		// The destination image appears from the right edge to the left edge.
+		t = step(size.x*(1-Progress), pos.x)
+	} else if TransitionType == 2 {
+		// The destination image appears in a circle growing from the center.
+		t = step(length(pos-size/2), Progress*length(size)/2)
+	} else if TransitionType == 3 {
+		t = step(imageSrc2At(srcPos).r, Progress)
+	} else {
+		t = Progress
+	}
+	return mix(from, to, t) * color
+}
+`
@@ -18,10 +18,18 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/msl"
 )
 
 func main() {
@@ -52,6 +60,16 @@ const note = `// This file is intended for precompiled shaders that will be intr
 `
 
 func xmain() error {
+	if err := writeDefs(); err != nil {
+		return err
+	}
+	if err := writeMetalLibraries(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeDefs() error {
 	f, err := os.Create("defs.go")
 	if err != nil {
 		return err
@@ -76,25 +94,175 @@ func xmain() error {
 		return err
 	}
 
+	var names []string
 	for filter := builtinshader.Filter(0); filter < builtinshader.FilterCount; filter++ {
 		for address := builtinshader.Address(0); address < builtinshader.AddressCount; address++ {
 			for _, useColorM := range []bool{false, true} {
-				s := builtinshader.ShaderSource(filter, address, useColorM)
-				if _, err := w.WriteString("\n"); err != nil {
-					return err
+				for _, useVertexColors := range []bool{true, false} {
+					names = append(names, builtinshader.ImageShaderName(filter, address, useColorM, useVertexColors))
 				}
-				if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
-					return err
-				}
-				if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+			}
+		}
+	}
+	for filter := builtinshader.Filter(0); filter < builtinshader.FilterCount; filter++ {
+		for address := builtinshader.Address(0); address < builtinshader.AddressCount; address++ {
+			names = append(names, builtinshader.LinearColorMShaderName(filter, address))
+		}
+	}
+
+	for _, name := range names {
+		s, ok := builtinshader.ShaderSourceByName(name)
+		if !ok {
+			return fmt.Errorf("gen: shader source for %s not found", name)
+		}
+		if _, err := w.WriteString("\n"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "// %s\n//\n", name); err != nil {
+			return err
+		}
+		if _, err := w.WriteString("//ebitengine:shadersource\n"); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "const _ = %q\n", s); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeMetalLibraries writes the precompiled Metal libraries to metallibs.go.
+// If the Metal compiler is not available, writeMetalLibraries leaves metallibs.go as it is,
+// so that the generated files don't depend on the machine running 'go generate'.
+func writeMetalLibraries() error {
+	libs, err := compileMetalLibraries()
+	if err != nil {
+		return err
+	}
+	if libs == nil {
+		return nil
+	}
+
+	f, err := os.Create("metallibs.go")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+
+	if _, err := w.WriteString("// Code generated by gen.go using 'go generate'. DO NOT EDIT.\n\n"); err != nil {
+		return err
+	}
+	if _, err := w.WriteString(license); err != nil {
+		return err
+	}
+	if _, err := w.WriteString("\npackage builtinshader\n\n"); err != nil {
+		return err
+	}
+	if _, err := w.WriteString("import (\n\t\"github.com/hajimehoshi/ebiten/v2/internal/shaderir\"\n)\n\n"); err != nil {
+		return err
+	}
+	if _, err := w.WriteString("// metalLibraries is a map from source hashes to precompiled Metal libraries for macOS.\n"); err != nil {
+		return err
+	}
+	if _, err := w.WriteString("var metalLibraries = map[shaderir.SourceHash]string{\n"); err != nil {
+		return err
+	}
+	hashes := make([]shaderir.SourceHash, 0, len(libs))
+	for hash := range libs {
+		hashes = append(hashes, hash)
+	}
+	slices.SortFunc(hashes, func(a, b shaderir.SourceHash) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for _, hash := range hashes {
+		if _, err := w.WriteString("\t{"); err != nil {
+			return err
+		}
+		for i, b := range hash {
+			if i > 0 {
+				if _, err := w.WriteString(", "); err != nil {
 					return err
 				}
 			}
+			if _, err := fmt.Fprintf(w, "0x%02x", b); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "}: %q,\n", libs[hash]); err != nil {
+			return err
 		}
 	}
+	if _, err := w.WriteString("}\n"); err != nil {
+		return err
+	}
 
 	if err := w.Flush(); err != nil {
 		return err
 	}
 	return nil
 }
+
+// compileMetalLibraries compiles all the built-in shaders into Metal libraries for macOS.
+// If the Metal compiler is not available, compileMetalLibraries returns nil.
+func compileMetalLibraries() (map[shaderir.SourceHash][]byte, error) {
+	if runtime.GOOS != "darwin" {
+		fmt.Fprintln(os.Stderr, "gen: skipping precompiling Metal libraries as the Metal compiler is available only on macOS. metallibs.go is not updated.")
+		return nil, nil
+	}
+	if _, err := exec.LookPath("xcrun"); err != nil {
+		fmt.Fprintln(os.Stderr, "gen: skipping precompiling Metal libraries as xcrun is not found. metallibs.go is not updated.")
+		return nil, nil
+	}
+	if err := exec.Command("xcrun", "-sdk", "macosx", "--find", "metal").Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gen: skipping precompiling Metal libraries as the Metal compiler is not found. metallibs.go is not updated.")
+		return nil, nil
+	}
+
+	tmpdir, err := os.MkdirTemp("", "ebitengine-builtinshader-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpdir)
+
+	libs := map[shaderir.SourceHash][]byte{}
+	for _, src := range builtinshader.Sources() {
+		hash, err := graphics.CalcSourceHash(src)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := libs[hash]; ok {
+			continue
+		}
+
+		ir, err := graphics.CompileShader(src)
+		if err != nil {
+			return nil, err
+		}
+
+		name := hash.String()
+		metalPath := filepath.Join(tmpdir, name+".metal")
+		irPath := filepath.Join(tmpdir, name+".ir")
+		libPath := filepath.Join(tmpdir, name+".metallib")
+		if err := os.WriteFile(metalPath, []byte(msl.Compile(ir)), 0644); err != nil {
+			return nil, err
+		}
+		if out, err := exec.Command("xcrun", "-sdk", "macosx", "metal", "-o", irPath, "-c", metalPath).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("gen: metal failed: %w: %s", err, out)
+		}
+		if out, err := exec.Command("xcrun", "-sdk", "macosx", "metallib", "-o", libPath, irPath).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("gen: metallib failed: %w: %s", err, out)
+		}
+		lib, err := os.ReadFile(libPath)
+		if err != nil {
+			return nil, err
+		}
+		libs[hash] = lib
+	}
+	return libs, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+// effectShaderSources is a list of the built-in shaders other than the image shaders, in the order of Sources.
+var effectShaderSources = []struct {
+	name   string
+	source string
+}{
+	{name: "clear", source: ClearShaderSource},
+	{name: "colorgrade", source: ColorGradeShaderSource},
+	{name: "brightpass", source: BrightPassShaderSource},
+	{name: "blur", source: BlurShaderSource},
+	{name: "noise", source: NoiseShaderSource},
+	{name: "sdf", source: SDFShaderSource},
+	{name: "outline", source: OutlineShaderSource},
+	{name: "transition", source: TransitionShaderSource},
+}
+
+var (
+	replacedSources  = map[string]string{}
+	replacedSourcesM sync.Mutex
+)
+
+type imageShaderParams struct {
+	filter          Filter
+	address         Address
+	useColorM       bool
+	useVertexColors bool
+	linearColorM    bool
+}
+
+var imageShaderParamsByName = sync.OnceValue(func() map[string]imageShaderParams {
+	m := map[string]imageShaderParams{}
+	for filter := Filter(0); filter < FilterCount; filter++ {
+		for address := Address(0); address < AddressCount; address++ {
+			for _, useColorM := range []bool{false, true} {
+				for _, useVertexColors := range []bool{true, false} {
+					m[ImageShaderName(filter, address, useColorM, useVertexColors)] = imageShaderParams{
+						filter:          filter,
+						address:         address,
+						useColorM:       useColorM,
+						useVertexColors: useVertexColors,
+					}
+				}
+			}
+			m[LinearColorMShaderName(filter, address)] = imageShaderParams{
+				filter:          filter,
+				address:         address,
+				useColorM:       true,
+				useVertexColors: true,
+				linearColorM:    true,
+			}
+		}
+	}
+	return m
+})
+
+// ImageShaderName returns the canonical name of the built-in shader to render an image with the given parameters,
+// like "image-linear-repeat-colorm".
+func ImageShaderName(filter Filter, address Address, useColorM bool, useVertexColors bool) string {
+	return imageShaderName(filter, address, useColorM, useVertexColors, false)
+}
+
+// LinearColorMShaderName returns the canonical name of the built-in shader returned by ShaderSourceWithLinearColorM,
+// like "image-linear-repeat-colorm-linearcolorspace".
+func LinearColorMShaderName(filter Filter, address Address) string {
+	return imageShaderName(filter, address, true, true, true)
+}
+
+func imageShaderName(filter Filter, address Address, useColorM bool, useVertexColors bool, linearColorM bool) string {
+	var f string
+	switch filter {
+	case FilterNearest:
+		f = "nearest"
+	case FilterLinear:
+		f = "linear"
+	case FilterPixelated:
+		f = "pixelated"
+	default:
+		panic(fmt.Sprintf("builtinshader: unexpected filter: %d", filter))
+	}
+
+	var a string
+	switch address {
+	case AddressUnsafe:
+		a = "unsafe"
+	case AddressClampToZero:
+		a = "clamptozero"
+	case AddressRepeat:
+		a = "repeat"
+	case AddressMirroredRepeat:
+		a = "mirroredrepeat"
+	default:
+		panic(fmt.Sprintf("builtinshader: unexpected address: %d", address))
+	}
+
+	name := "image-" + f + "-" + a
+	if useColorM {
+		name += "-colorm"
+	}
+	if !useVertexColors {
+		name += "-novertexcolors"
+	}
+	if linearColorM {
+		name += "-linearcolorspace"
+	}
+	return name
+}
+
+// ShaderNames returns the canonical names of all the built-in shaders in the same order as Sources.
+func ShaderNames() []string {
+	var names []string
+	for filter := Filter(0); filter < FilterCount; filter++ {
+		for address := Address(0); address < AddressCount; address++ {
+			for _, useColorM := range []bool{false, true} {
+				names = append(names, ImageShaderName(filter, address, useColorM, true))
+				names = append(names, ImageShaderName(filter, address, useColorM, false))
+			}
+		}
+	}
+	for filter := Filter(0); filter < FilterCount; filter++ {
+		for address := Address(0); address < AddressCount; address++ {
+			names = append(names, LinearColorMShaderName(filter, address))
+		}
+	}
+	for _, s := range effectShaderSources {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// ShaderSourceByName returns the source of the built-in shader with the given canonical name.
+// If the source is replaced by ReplaceShaderSource, ShaderSourceByName returns the replaced source.
+//
+// ShaderSourceByName returns false if name is unknown.
+func ShaderSourceByName(name string) (string, bool) {
+	if src, ok := replacedSource(name); ok {
+		return src, true
+	}
+	for _, s := range effectShaderSources {
+		if s.name == name {
+			return s.source, true
+		}
+	}
+	if p, ok := imageShaderParamsByName()[name]; ok {
+		return string(shaderSource(p.filter, p.address, p.useColorM, p.useVertexColors, p.linearColorM)), true
+	}
+	return "", false
+}
+
+// ReplaceShaderSource replaces the source of the built-in shader with the given canonical name.
+//
+// ReplaceShaderSource is for experimentation, and should be called before the shader is used, e.g., in an init function.
+// A shader already compiled is not affected.
+// The "clear" shader and the image shaders without a color matrix for the nearest and linear filters with
+// the unsafe address, which are compiled at initialization, are never affected.
+//
+// ReplaceShaderSource returns an error if name is unknown or src cannot be compiled.
+func ReplaceShaderSource(name string, src string) error {
+	if _, ok := ShaderSourceByName(name); !ok {
+		return fmt.Errorf("builtinshader: unknown shader name: %q", name)
+	}
+	if _, err := graphics.CompileShader([]byte(src)); err != nil {
+		return fmt.Errorf("builtinshader: the replacement for %q cannot be compiled: %w", name, err)
+	}
+
+	replacedSourcesM.Lock()
+	defer replacedSourcesM.Unlock()
+	replacedSources[name] = src
+	return nil
+}
+
+func replacedSource(name string) (string, bool) {
+	replacedSourcesM.Lock()
+	defer replacedSourcesM.Unlock()
+	src, ok := replacedSources[name]
+	return src, ok
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// Sources returns all the built-in shader sources.
+// Sources is used to precompile the built-in shaders.
+func Sources() [][]byte {
+	var srcs [][]byte
+	for filter := Filter(0); filter < FilterCount; filter++ {
+		for address := Address(0); address < AddressCount; address++ {
+			for _, useColorM := range []bool{false, true} {
+				srcs = append(srcs, ShaderSource(filter, address, useColorM))
+				srcs = append(srcs, ShaderSourceWithoutVertexColors(filter, address, useColorM))
+			}
+		}
+	}
+	for filter := Filter(0); filter < FilterCount; filter++ {
+		for address := Address(0); address < AddressCount; address++ {
+			srcs = append(srcs, ShaderSourceWithLinearColorM(filter, address))
+		}
+	}
+	for _, s := range effectShaderSources {
+		srcs = append(srcs, []byte(s.source))
+	}
+	return srcs
+}
+
+// RegisterPrecompiledMetalLibraries calls register for each precompiled Metal library for macOS of the built-in shaders.
+// The built-in shaders without precompiled libraries are compiled from their sources at runtime.
+func RegisterPrecompiledMetalLibraries(register func(hash shaderir.SourceHash, lib []byte)) {
+	for hash, lib := range metalLibraries {
+		register(hash, []byte(lib))
+	}
+}
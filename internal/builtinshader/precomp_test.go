@@ -0,0 +1,50 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtinshader_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+func TestPrecompiledMetalLibrariesAreUpToDate(t *testing.T) {
+	libs := map[shaderir.SourceHash][]byte{}
+	builtinshader.RegisterPrecompiledMetalLibraries(func(hash shaderir.SourceHash, lib []byte) {
+		libs[hash] = lib
+	})
+	if len(libs) == 0 {
+		t.Skip("no precompiled Metal libraries")
+	}
+
+	hashes := map[shaderir.SourceHash]struct{}{}
+	for i, src := range builtinshader.Sources() {
+		hash, err := graphics.CalcSourceHash(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes[hash] = struct{}{}
+		if len(libs[hash]) == 0 {
+			t.Errorf("source #%d (%s) doesn't have a precompiled library. Run 'go generate' on macOS", i, hash)
+		}
+	}
+	for hash := range libs {
+		if _, ok := hashes[hash]; !ok {
+			t.Errorf("the precompiled library %s doesn't match any source. Run 'go generate' on macOS", hash)
+		}
+	}
+}
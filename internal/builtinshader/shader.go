@@ -40,9 +40,10 @@ const (
 	AddressUnsafe Address = iota
 	AddressClampToZero
 	AddressRepeat
+	AddressMirroredRepeat
 )
 
-const AddressCount = 3
+const AddressCount = 4
 
 const (
 	UniformColorMBody        = "ColorMBody"
@@ -50,7 +51,7 @@ const (
 )
 
 var (
-	shaders  [FilterCount][AddressCount][2][]byte
+	shaders  [FilterCount][AddressCount][2][2][2][]byte
 	shadersM sync.Mutex
 )
 
@@ -62,6 +63,17 @@ package main
 var ColorMBody mat4
 var ColorMTranslation vec4
 {{end}}
+{{- if .LinearColorM}}
+
+func srgbToLinear(c vec3) vec3 {
+	return mix(c/12.92, pow((c+0.055)/1.055, vec3(2.4)), step(vec3(0.04045), c))
+}
+
+func linearToSRGB(c vec3) vec3 {
+	c = clamp(c, 0, 1)
+	return mix(c*12.92, 1.055*pow(c, vec3(1/2.4))-0.055, step(vec3(0.0031308), c))
+}
+{{- end}}
 
 {{if eq .Address .AddressRepeat}}
 func adjustSrcPosForAddressRepeat(p vec2) vec2 {
@@ -69,6 +81,16 @@ func adjustSrcPosForAddressRepeat(p vec2) vec2 {
 	size := imageSrc0Size()
 	return mod(p - origin, size) + origin
 }
+{{else if eq .Address .AddressMirroredRepeat}}
+func adjustSrcPosForAddressMirroredRepeat(p vec2) vec2 {
+	origin := imageSrc0Origin()
+	size := imageSrc0Size()
+	p = mod(p - origin, 2*size)
+	// Mirror the texel index in the odd tiles.
+	// Keep the fractional part so that the filters' interpolation rates are not changed.
+	p = mix(p, 2*size - 1 - floor(p) + fract(p), step(size, p))
+	return p + origin
+}
 {{end}}
 
 func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
@@ -79,6 +101,8 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	clr := imageSrc0At(srcPos)
 {{else if eq .Address .AddressRepeat}}
 	clr := imageSrc0At(adjustSrcPosForAddressRepeat(srcPos))
+{{else if eq .Address .AddressMirroredRepeat}}
+	clr := imageSrc0At(adjustSrcPosForAddressMirroredRepeat(srcPos))
 {{end}}
 {{else}}
 {{if eq .Filter .FilterLinear}}
@@ -97,6 +121,9 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 {{if eq .Address .AddressRepeat}}
 	p0 = adjustSrcPosForAddressRepeat(p0)
 	p1 = adjustSrcPosForAddressRepeat(p1)
+{{else if eq .Address .AddressMirroredRepeat}}
+	p0 = adjustSrcPosForAddressMirroredRepeat(p0)
+	p1 = adjustSrcPosForAddressMirroredRepeat(p1)
 {{end}}
 
 {{if eq .Address .AddressUnsafe}}
@@ -123,15 +150,25 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 	// Un-premultiply alpha.
 	// When the alpha is 0, 1-sign(alpha) is 1.0, which means division does nothing.
 	clr.rgb /= clr.a + (1-sign(clr.a))
+{{- if .LinearColorM}}
+	// Decode the color to the linear color space.
+	clr.rgb = srgbToLinear(clr.rgb)
+{{- end}}
 	// Apply the clr matrix.
 	clr = (ColorMBody * clr) + ColorMTranslation
+{{- if .LinearColorM}}
+	// Encode the color to the sRGB color space.
+	clr.rgb = linearToSRGB(clr.rgb)
+{{- end}}
 	// Premultiply alpha
 	clr.rgb *= clr.a
+{{- if .UseVertexColors}}
 	// Apply the color scale.
 	clr *= color
+{{- end}}
 	// Clamp the output.
 	clr.rgb = min(clr.rgb, clr.a)
-{{else}}
+{{else if .UseVertexColors}}
 	// Apply the color scale.
 	clr *= color
 {{end}}
@@ -145,6 +182,30 @@ func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
 //
 // The returned shader always uses a color matrix so far.
 func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
+	return shaderSource(filter, address, useColorM, true, false)
+}
+
+// ShaderSourceWithoutVertexColors returns the built-in shader source that doesn't multiply the vertex colors.
+// This is a variant of ShaderSource for the case when all the vertex colors are known to be (1, 1, 1, 1).
+func ShaderSourceWithoutVertexColors(filter Filter, address Address, useColorM bool) []byte {
+	return shaderSource(filter, address, useColorM, false, false)
+}
+
+// ShaderSourceWithLinearColorM returns the built-in shader source that applies a color matrix in the linear color space.
+// The source colors are decoded from sRGB to linear before the color matrix is applied, and encoded to sRGB after that.
+func ShaderSourceWithLinearColorM(filter Filter, address Address) []byte {
+	return shaderSource(filter, address, true, true, true)
+}
+
+func shaderSource(filter Filter, address Address, useColorM bool, useVertexColors bool, linearColorM bool) []byte {
+	if linearColorM && !useColorM {
+		panic("builtinshader: linearColorM requires useColorM")
+	}
+
+	if src, ok := replacedSource(imageShaderName(filter, address, useColorM, useVertexColors, linearColorM)); ok {
+		return []byte(src)
+	}
+
 	shadersM.Lock()
 	defer shadersM.Unlock()
 
@@ -152,37 +213,51 @@ func ShaderSource(filter Filter, address Address, useColorM bool) []byte {
 	if useColorM {
 		c = 1
 	}
-	if s := shaders[filter][address][c]; s != nil {
+	var v int
+	if useVertexColors {
+		v = 1
+	}
+	var l int
+	if linearColorM {
+		l = 1
+	}
+	if s := shaders[filter][address][c][v][l]; s != nil {
 		return s
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, struct {
-		Filter             Filter
-		FilterNearest      Filter
-		FilterLinear       Filter
-		FilterPixelated    Filter
-		Address            Address
-		AddressUnsafe      Address
-		AddressClampToZero Address
-		AddressRepeat      Address
-		UseColorM          bool
+		Filter                Filter
+		FilterNearest         Filter
+		FilterLinear          Filter
+		FilterPixelated       Filter
+		Address               Address
+		AddressUnsafe         Address
+		AddressClampToZero    Address
+		AddressRepeat         Address
+		AddressMirroredRepeat Address
+		UseColorM             bool
+		UseVertexColors       bool
+		LinearColorM          bool
 	}{
-		Filter:             filter,
-		FilterNearest:      FilterNearest,
-		FilterLinear:       FilterLinear,
-		FilterPixelated:    FilterPixelated,
-		Address:            address,
-		AddressUnsafe:      AddressUnsafe,
-		AddressClampToZero: AddressClampToZero,
-		AddressRepeat:      AddressRepeat,
-		UseColorM:          useColorM,
+		Filter:                filter,
+		FilterNearest:         FilterNearest,
+		FilterLinear:          FilterLinear,
+		FilterPixelated:       FilterPixelated,
+		Address:               address,
+		AddressUnsafe:         AddressUnsafe,
+		AddressClampToZero:    AddressClampToZero,
+		AddressRepeat:         AddressRepeat,
+		AddressMirroredRepeat: AddressMirroredRepeat,
+		UseColorM:             useColorM,
+		UseVertexColors:       useVertexColors,
+		LinearColorM:          linearColorM,
 	}); err != nil {
 		panic(fmt.Sprintf("builtinshader: tmpl.Execute failed: %v", err))
 	}
 
 	b := buf.Bytes()
-	shaders[filter][address][c] = b
+	shaders[filter][address][c][v][l] = b
 	return b
 }
 
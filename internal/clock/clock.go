@@ -16,6 +16,7 @@
 package clock
 
 import (
+	"slices"
 	"sync"
 	"time"
 )
@@ -29,6 +30,12 @@ var (
 	// tps represents TPS (ticks per second).
 	tps = DefaultTPS
 
+	// unfocusedTPS represents TPS when the game is not focused.
+	// A negative value means that tps is used regardless of the focus state.
+	unfocusedTPS = -1
+
+	focused = true
+
 	lastNow int64
 
 	// lastSystemTime is the last system time in the previous UpdateFrame.
@@ -42,9 +49,31 @@ var (
 	fpsCount    = 0
 	tpsCount    = 0
 
+	// frameTimes is a ring buffer of the durations of the recent frames.
+	frameTimes       [frameTimeWindowSize]time.Duration
+	frameTimesIndex  int
+	frameTimesCount  int
+	frameTimesSorted [frameTimeWindowSize]time.Duration
+
+	// frameUpdated reports whether UpdateFrame has been called.
+	// The first frame time is not recorded since it includes the startup time.
+	frameUpdated bool
+
 	m sync.Mutex
 )
 
+// frameTimeWindowSize is the number of the recent frames used for FrameTimeStats.
+const frameTimeWindowSize = 300
+
+// FrameTimeStats represents statistics of frame times.
+type FrameTimeStats struct {
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+	P95  time.Duration
+	P99  time.Duration
+}
+
 func init() {
 	n := now()
 	lastNow = n
@@ -64,6 +93,48 @@ func ActualTPS() float64 {
 	return actualTPS
 }
 
+// CurrentFrameTimeStats returns the statistics of the durations of the recent frames.
+// If no frame has been recorded yet, CurrentFrameTimeStats returns the zero value.
+func CurrentFrameTimeStats() FrameTimeStats {
+	m.Lock()
+	defer m.Unlock()
+
+	if frameTimesCount == 0 {
+		return FrameTimeStats{}
+	}
+
+	ts := frameTimesSorted[:frameTimesCount]
+	copy(ts, frameTimes[:frameTimesCount])
+	slices.Sort(ts)
+
+	var sum time.Duration
+	for _, t := range ts {
+		sum += t
+	}
+
+	// Use the nearest-rank method for percentiles.
+	percentile := func(p int) time.Duration {
+		idx := (p*len(ts)+99)/100 - 1
+		return ts[max(idx, 0)]
+	}
+
+	return FrameTimeStats{
+		Min:  ts[0],
+		Max:  ts[len(ts)-1],
+		Mean: sum / time.Duration(len(ts)),
+		P95:  percentile(95),
+		P99:  percentile(99),
+	}
+}
+
+func recordFrameTime(d time.Duration) {
+	frameTimes[frameTimesIndex] = d
+	frameTimesIndex = (frameTimesIndex + 1) % frameTimeWindowSize
+	if frameTimesCount < frameTimeWindowSize {
+		frameTimesCount++
+	}
+}
+
 func calcCountFromTPS(tps int64, now int64) int {
 	if tps == 0 {
 		return 0
@@ -142,13 +213,17 @@ func UpdateFrame() int {
 		// This ensures that now() must be monotonic (#875).
 		panic("clock: lastNow must be older than n")
 	}
+	if frameUpdated {
+		recordFrameTime(time.Duration(n - lastNow))
+	}
+	frameUpdated = true
 	lastNow = n
 
 	c := 0
-	if tps == SyncWithFPS {
+	if t := currentTPS(); t == SyncWithFPS {
 		c = 1
-	} else if tps > 0 {
-		c = calcCountFromTPS(int64(tps), n)
+	} else if t > 0 {
+		c = calcCountFromTPS(int64(t), n)
 	}
 	updateFPSAndTPS(n, c)
 
@@ -166,3 +241,36 @@ func TPS() int {
 	defer m.Unlock()
 	return tps
 }
+
+// currentTPS returns TPS considering the focus state.
+func currentTPS() int {
+	if focused || unfocusedTPS < 0 {
+		return tps
+	}
+	if tps == SyncWithFPS || unfocusedTPS < tps {
+		return unfocusedTPS
+	}
+	return tps
+}
+
+// SetUnfocusedTPS sets TPS used when the game is not focused.
+// If newTPS is negative, TPS is not changed by the focus state.
+func SetUnfocusedTPS(newTPS int) {
+	m.Lock()
+	defer m.Unlock()
+	unfocusedTPS = newTPS
+}
+
+func UnfocusedTPS() int {
+	m.Lock()
+	defer m.Unlock()
+	return unfocusedTPS
+}
+
+// SetFocused sets the focus state of the game.
+// SetFocused is expected to be called by a UI driver before UpdateFrame.
+func SetFocused(f bool) {
+	m.Lock()
+	defer m.Unlock()
+	focused = f
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func resetFrameTimes() {
+	m.Lock()
+	defer m.Unlock()
+
+	frameTimesIndex = 0
+	frameTimesCount = 0
+	frameUpdated = false
+}
+
+func TestFrameTimeStats(t *testing.T) {
+	resetFrameTimes()
+	defer resetFrameTimes()
+
+	if got, want := CurrentFrameTimeStats(), (FrameTimeStats{}); got != want {
+		t.Errorf("CurrentFrameTimeStats() without frames: got: %v, want: %v", got, want)
+	}
+
+	// Record 1ms, 2ms, ..., 100ms in a shuffled order.
+	for i := 0; i < 100; i++ {
+		recordFrameTime(time.Duration((i*37)%100+1) * time.Millisecond)
+	}
+	want := FrameTimeStats{
+		Min:  time.Millisecond,
+		Max:  100 * time.Millisecond,
+		Mean: 50500 * time.Microsecond,
+		P95:  95 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+	}
+	if got := CurrentFrameTimeStats(); got != want {
+		t.Errorf("CurrentFrameTimeStats(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestFrameTimeStatsWindow(t *testing.T) {
+	resetFrameTimes()
+	defer resetFrameTimes()
+
+	// Old frames out of the window must be discarded.
+	for i := 0; i < frameTimeWindowSize; i++ {
+		recordFrameTime(time.Second)
+	}
+	for i := 0; i < frameTimeWindowSize; i++ {
+		recordFrameTime(time.Millisecond)
+	}
+	want := FrameTimeStats{
+		Min:  time.Millisecond,
+		Max:  time.Millisecond,
+		Mean: time.Millisecond,
+		P95:  time.Millisecond,
+		P99:  time.Millisecond,
+	}
+	if got := CurrentFrameTimeStats(); got != want {
+		t.Errorf("CurrentFrameTimeStats(): got: %v, want: %v", got, want)
+	}
+}
+
+func TestFrameTimeStatsSkipFirstFrame(t *testing.T) {
+	resetFrameTimes()
+	defer resetFrameTimes()
+
+	// The first frame includes the startup time and must not be recorded.
+	UpdateFrame()
+	if got, want := CurrentFrameTimeStats(), (FrameTimeStats{}); got != want {
+		t.Errorf("CurrentFrameTimeStats() after the first frame: got: %v, want: %v", got, want)
+	}
+
+	UpdateFrame()
+	m.Lock()
+	count := frameTimesCount
+	m.Unlock()
+	if got, want := count, 1; got != want {
+		t.Errorf("the number of recorded frames: got: %d, want: %d", got, want)
+	}
+}
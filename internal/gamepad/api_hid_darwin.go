@@ -70,6 +70,7 @@ var (
 	kIOHIDProductIDKey       = []byte("ProductID\x00")
 	kIOHIDVersionNumberKey   = []byte("VersionNumber\x00")
 	kIOHIDProductKey         = []byte("Product\x00")
+	kIOHIDTransportKey       = []byte("Transport\x00")
 	kIOHIDDeviceUsagePageKey = []byte("DeviceUsagePage\x00")
 	kIOHIDDeviceUsageKey     = []byte("DeviceUsage\x00")
 )
@@ -22,6 +22,11 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+const (
+	_BUS_USB       = 0x03
+	_BUS_BLUETOOTH = 0x05
+)
+
 const (
 	_ABS_X     = 0x00
 	_ABS_Y     = 0x01
@@ -0,0 +1,102 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// The layouts of DualSense's output reports are the same as the ones in Linux's hid-playstation driver.
+const (
+	dualSenseOutputReportUSB     = 0x02
+	dualSenseOutputReportUSBSize = 63
+	dualSenseOutputReportBT      = 0x31
+	dualSenseOutputReportBTSize  = 78
+
+	dualSenseOutputTagBT     = 0x10
+	dualSenseOutputCRCSeedBT = 0xa2
+
+	dualSenseValidFlag0RightTriggerEffect = 1 << 2
+	dualSenseValidFlag0LeftTriggerEffect  = 1 << 3
+
+	// The offsets of the trigger effects in the common part of an output report.
+	dualSenseRightTriggerEffectOffset = 10
+	dualSenseLeftTriggerEffectOffset  = 21
+	dualSenseTriggerEffectSize        = 11
+
+	dualSenseTriggerEffectModeOff        = 0x05
+	dualSenseTriggerEffectModeResistance = 0x01
+	dualSenseTriggerEffectModeWeapon     = 0x02
+)
+
+// dualSenseTriggerEffectReport returns an output report of DualSense to set the effect of the trigger.
+// seq is a sequence number used only for Bluetooth, and must be in between 0 and 15.
+func dualSenseTriggerEffectReport(trigger Trigger, effect TriggerEffect, bluetooth bool, seq uint8) []byte {
+	var report []byte
+	var common []byte
+	if bluetooth {
+		report = make([]byte, dualSenseOutputReportBTSize)
+		report[0] = dualSenseOutputReportBT
+		report[1] = seq << 4
+		report[2] = dualSenseOutputTagBT
+		common = report[3:]
+	} else {
+		report = make([]byte, dualSenseOutputReportUSBSize)
+		report[0] = dualSenseOutputReportUSB
+		common = report[1:]
+	}
+
+	var params []byte
+	switch trigger {
+	case TriggerLeft:
+		common[0] = dualSenseValidFlag0LeftTriggerEffect
+		params = common[dualSenseLeftTriggerEffectOffset : dualSenseLeftTriggerEffectOffset+dualSenseTriggerEffectSize]
+	case TriggerRight:
+		common[0] = dualSenseValidFlag0RightTriggerEffect
+		params = common[dualSenseRightTriggerEffectOffset : dualSenseRightTriggerEffectOffset+dualSenseTriggerEffectSize]
+	default:
+		panic(fmt.Sprintf("gamepad: invalid trigger: %d", trigger))
+	}
+
+	switch effect.Type {
+	case TriggerEffectTypeOff:
+		params[0] = dualSenseTriggerEffectModeOff
+	case TriggerEffectTypeResistance:
+		params[0] = dualSenseTriggerEffectModeResistance
+		params[1] = triggerEffectByte(effect.StartPosition)
+		params[2] = triggerEffectByte(effect.Strength)
+	case TriggerEffectTypeWeapon:
+		params[0] = dualSenseTriggerEffectModeWeapon
+		params[1] = triggerEffectByte(effect.StartPosition)
+		params[2] = triggerEffectByte(effect.EndPosition)
+		params[3] = triggerEffectByte(effect.Strength)
+	default:
+		panic(fmt.Sprintf("gamepad: invalid trigger effect type: %d", effect.Type))
+	}
+
+	if bluetooth {
+		// A Bluetooth output report ends with CRC32 of the seed byte and the report.
+		crc := crc32.Update(crc32.ChecksumIEEE([]byte{dualSenseOutputCRCSeedBT}), crc32.IEEETable, report[:len(report)-4])
+		binary.LittleEndian.PutUint32(report[len(report)-4:], crc)
+	}
+	return report
+}
+
+// triggerEffectByte converts a value in between 0 and 1 to a byte.
+func triggerEffectByte(v float64) byte {
+	return byte(min(max(v, 0), 1)*255 + 0.5)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+)
+
+func TestDualSenseTriggerEffectReport(t *testing.T) {
+	testCases := []struct {
+		name      string
+		trigger   Trigger
+		effect    TriggerEffect
+		bluetooth bool
+		flag      byte
+		offset    int
+		params    []byte
+	}{
+		{
+			name:    "USB, left, off",
+			trigger: TriggerLeft,
+			effect:  TriggerEffect{Type: TriggerEffectTypeOff},
+			flag:    0x08,
+			offset:  1 + 21,
+			params:  []byte{0x05, 0, 0, 0},
+		},
+		{
+			name:    "USB, right, resistance",
+			trigger: TriggerRight,
+			effect:  TriggerEffect{Type: TriggerEffectTypeResistance, StartPosition: 0.5, Strength: 1},
+			flag:    0x04,
+			offset:  1 + 10,
+			params:  []byte{0x01, 0x80, 0xff, 0},
+		},
+		{
+			name:      "Bluetooth, left, weapon",
+			trigger:   TriggerLeft,
+			effect:    TriggerEffect{Type: TriggerEffectTypeWeapon, StartPosition: 0.25, EndPosition: 0.5, Strength: 2},
+			bluetooth: true,
+			flag:      0x08,
+			offset:    3 + 21,
+			params:    []byte{0x02, 0x40, 0x80, 0xff},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := dualSenseTriggerEffectReport(tc.trigger, tc.effect, tc.bluetooth, 3)
+
+			flagOffset := 1
+			wantSize, wantID := 63, byte(0x02)
+			if tc.bluetooth {
+				flagOffset = 3
+				wantSize, wantID = 78, 0x31
+			}
+			if got := len(report); got != wantSize {
+				t.Fatalf("len(report): got: %d, want: %d", got, wantSize)
+			}
+			if got := report[0]; got != wantID {
+				t.Errorf("report ID: got: 0x%02x, want: 0x%02x", got, wantID)
+			}
+			if got := report[flagOffset]; got != tc.flag {
+				t.Errorf("valid flag 0: got: 0x%02x, want: 0x%02x", got, tc.flag)
+			}
+			for i, want := range tc.params {
+				if got := report[tc.offset+i]; got != want {
+					t.Errorf("params[%d]: got: 0x%02x, want: 0x%02x", i, got, want)
+				}
+			}
+
+			if !tc.bluetooth {
+				return
+			}
+			if got, want := report[1], byte(3<<4); got != want {
+				t.Errorf("sequence tag: got: 0x%02x, want: 0x%02x", got, want)
+			}
+			if got, want := report[2], byte(0x10); got != want {
+				t.Errorf("tag: got: 0x%02x, want: 0x%02x", got, want)
+			}
+			want := crc32.ChecksumIEEE(append([]byte{0xa2}, report[:74]...))
+			if got := binary.LittleEndian.Uint32(report[74:]); got != want {
+				t.Errorf("CRC32: got: 0x%08x, want: 0x%08x", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+)
+
+const SessionReuseDuration = sessionReuseDuration
+
+type SessionIDs struct {
+	s sessionIDs
+}
+
+func (s *SessionIDs) Acquire(sdlID string, now time.Time) string {
+	return s.s.acquire(sdlID, now)
+}
+
+func (s *SessionIDs) Release(sdlID string, sessionID string, now time.Time) {
+	s.s.release(sdlID, sessionID, now)
+}
+
+type Gamepads struct {
+	g gamepads
+}
+
+// AddForTesting adds a synthetic gamepad that reports the given raw axis values.
+func (g *Gamepads) AddForTesting(name, sdlID string, axes []float64) *Gamepad {
+	gp := g.g.add(name, sdlID)
+	gp.native = &nativeGamepadForTesting{
+		axes: axes,
+	}
+	return gp
+}
+
+func (g *Gamepads) Remove(gamepad *Gamepad) {
+	g.g.remove(func(gp *Gamepad) bool {
+		return gp == gamepad
+	})
+}
+
+type nativeGamepadForTesting struct {
+	axes []float64
+}
+
+func (*nativeGamepadForTesting) update(gamepads *gamepads) error {
+	return nil
+}
+
+func (*nativeGamepadForTesting) hasOwnStandardLayoutMapping() bool {
+	return false
+}
+
+func (*nativeGamepadForTesting) standardAxisInOwnMapping(axis gamepaddb.StandardAxis) mappingInput {
+	return nil
+}
+
+func (*nativeGamepadForTesting) standardButtonInOwnMapping(button gamepaddb.StandardButton) mappingInput {
+	return nil
+}
+
+func (g *nativeGamepadForTesting) axisCount() int {
+	return len(g.axes)
+}
+
+func (*nativeGamepadForTesting) buttonCount() int {
+	return 0
+}
+
+func (*nativeGamepadForTesting) hatCount() int {
+	return 0
+}
+
+func (g *nativeGamepadForTesting) isAxisReady(axis int) bool {
+	return axis >= 0 && axis < len(g.axes)
+}
+
+func (g *nativeGamepadForTesting) axisValue(axis int) float64 {
+	if axis < 0 || axis >= len(g.axes) {
+		return 0
+	}
+	return g.axes[axis]
+}
+
+func (*nativeGamepadForTesting) buttonValue(button int) float64 {
+	return 0
+}
+
+func (*nativeGamepadForTesting) isButtonPressed(button int) bool {
+	return false
+}
+
+func (*nativeGamepadForTesting) hatState(hat int) int {
+	return hatCentered
+}
+
+func (*nativeGamepadForTesting) vibrate(duration time.Duration, strongMagnitude float64, weakMagnitude float64) {
+}
@@ -15,7 +15,10 @@
 package gamepad
 
 import (
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
@@ -38,6 +41,7 @@ const (
 type gamepads struct {
 	inited   bool
 	gamepads []*Gamepad
+	sessions sessionIDs
 	m        sync.Mutex
 
 	native nativeGamepads
@@ -139,21 +143,22 @@ func (g *gamepads) find(cond func(*Gamepad) bool) *Gamepad {
 }
 
 func (g *gamepads) add(name, sdlID string) *Gamepad {
-	for i, gp := range g.gamepads {
-		if gp == nil {
-			gp := &Gamepad{
-				name:  name,
-				sdlID: sdlID,
-			}
+	now := time.Now()
+	gp := &Gamepad{
+		name:          name,
+		sdlID:         sdlID,
+		sessionID:     g.sessions.acquire(sdlID, now),
+		connectedTime: now,
+	}
+	// Compute the mapping ID at the connection so that the lookup is not done every frame.
+	gp.mappingID()
+
+	for i, p := range g.gamepads {
+		if p == nil {
 			g.gamepads[i] = gp
 			return gp
 		}
 	}
-
-	gp := &Gamepad{
-		name:  name,
-		sdlID: sdlID,
-	}
 	g.gamepads = append(g.gamepads, gp)
 	return gp
 }
@@ -164,6 +169,7 @@ func (g *gamepads) remove(cond func(*Gamepad) bool) {
 			continue
 		}
 		if cond(gp) {
+			g.sessions.release(gp.sdlID, gp.sessionID, time.Now())
 			g.gamepads[i] = nil
 		}
 	}
@@ -180,9 +186,14 @@ func (g *gamepads) setNativeWindow(nativeWindow uintptr) {
 }
 
 type Gamepad struct {
-	name  string
-	sdlID string
-	m     sync.Mutex
+	name          string
+	sdlID         string
+	sessionID     string
+	connectedTime time.Time
+	m             sync.Mutex
+
+	// cachedMappingID is the result of gamepaddb.MappingID with the mappings generation when it was computed.
+	cachedMappingID atomic.Pointer[mappingIDCache]
 
 	native nativeGamepad
 }
@@ -261,18 +272,52 @@ func (g *Gamepad) update(gamepads *gamepads) error {
 // Name is concurrent-safe.
 func (g *Gamepad) Name() string {
 	// This is immutable and doesn't have to be protected by a mutex.
-	if name := gamepaddb.Name(g.sdlID); name != "" {
+	if name := gamepaddb.Name(g.mappingID()); name != "" {
 		return name
 	}
 	return g.name
 }
 
+type mappingIDCache struct {
+	id         string
+	generation uint64
+}
+
+// mappingID returns the SDL ID to look up the gamepad mapping database.
+//
+// The result is cached and recomputed only when the mappings are modified.
+//
+// mappingID is concurrent-safe.
+func (g *Gamepad) mappingID() string {
+	// Load the generation before computing the ID so that a modification during the computation invalidates the cache.
+	gen := gamepaddb.MappingsGeneration()
+	if c := g.cachedMappingID.Load(); c != nil && c.generation == gen {
+		return c.id
+	}
+	id := gamepaddb.MappingID(g.sdlID, g.name)
+	g.cachedMappingID.Store(&mappingIDCache{
+		id:         id,
+		generation: gen,
+	})
+	return id
+}
+
 // SDLID is concurrent-safe.
 func (g *Gamepad) SDLID() string {
 	// This is immutable and doesn't have to be protected by a mutex.
 	return g.sdlID
 }
 
+func (g *Gamepad) SessionID() string {
+	// This is immutable and doesn't have to be protected by a mutex.
+	return g.sessionID
+}
+
+func (g *Gamepad) ConnectedTime() time.Time {
+	// This is immutable and doesn't have to be protected by a mutex.
+	return g.connectedTime
+}
+
 // AxisCount is concurrent-safe.
 func (g *Gamepad) AxisCount() int {
 	g.m.Lock()
@@ -334,7 +379,8 @@ func (g *Gamepad) IsStandardLayoutAvailable() bool {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
 		return true
 	}
 	return g.native.hasOwnStandardLayoutMapping()
@@ -345,8 +391,9 @@ func (g *Gamepad) IsStandardAxisAvailable(axis gamepaddb.StandardAxis) bool {
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
-		return gamepaddb.HasStandardAxis(g.sdlID, axis)
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
+		return gamepaddb.HasStandardAxis(id, axis)
 	}
 	return g.native.standardAxisInOwnMapping(axis) != nil
 }
@@ -356,33 +403,46 @@ func (g *Gamepad) IsStandardButtonAvailable(button gamepaddb.StandardButton) boo
 	g.m.Lock()
 	defer g.m.Unlock()
 
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
-		return gamepaddb.HasStandardButton(g.sdlID, button)
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
+		return gamepaddb.HasStandardButton(id, button)
 	}
 	return g.native.standardButtonInOwnMapping(button) != nil
 }
 
+// StandardButtonFromRaw is concurrent-safe.
+func (g *Gamepad) StandardButtonFromRaw(button int) (gamepaddb.StandardButton, bool) {
+	return gamepaddb.StandardButtonFromRaw(g.mappingID(), gamepaddb.CurrentPlatformName(), button)
+}
+
+// StandardButtonFromRawHat is concurrent-safe.
+func (g *Gamepad) StandardButtonFromRawHat(hat int, hatState int) (gamepaddb.StandardButton, bool) {
+	return gamepaddb.StandardButtonFromRawHat(g.mappingID(), gamepaddb.CurrentPlatformName(), hat, hatState)
+}
+
 // StandardAxisValue is concurrent-safe.
 func (g *Gamepad) StandardAxisValue(axis gamepaddb.StandardAxis) float64 {
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
 		// StandardAxisValue invokes g.Axis, g.Button, or g.Hat so this cannot be locked.
-		return gamepaddb.StandardAxisValue(g.sdlID, axis, g)
+		return gamepaddb.StandardAxisValue(id, axis, g)
 	}
 
 	g.m.Lock()
 	defer g.m.Unlock()
 
 	if m := g.native.standardAxisInOwnMapping(axis); m != nil {
-		return m.Value()*2 - 1
+		return gamepaddb.ApplyAxisDeadzone(axis, m.Value()*2-1)
 	}
 	return 0
 }
 
 // StandardButtonValue is concurrent-safe.
 func (g *Gamepad) StandardButtonValue(button gamepaddb.StandardButton) float64 {
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
 		// StandardButtonValue invokes g.Axis, g.Button, or g.Hat so this cannot be locked.
-		return gamepaddb.StandardButtonValue(g.sdlID, button, g)
+		return gamepaddb.StandardButtonValue(id, button, g)
 	}
 
 	g.m.Lock()
@@ -396,9 +456,10 @@ func (g *Gamepad) StandardButtonValue(button gamepaddb.StandardButton) float64 {
 
 // IsStandardButtonPressed is concurrent-safe.
 func (g *Gamepad) IsStandardButtonPressed(button gamepaddb.StandardButton) bool {
-	if gamepaddb.HasStandardLayoutMapping(g.sdlID) {
+	id := g.mappingID()
+	if gamepaddb.HasStandardLayoutMapping(id) {
 		// IsStandardButtonPressed invokes g.Axis, g.Button, or g.Hat so this cannot be locked.
-		return gamepaddb.IsStandardButtonPressed(g.sdlID, button, g)
+		return gamepaddb.IsStandardButtonPressed(id, button, g)
 	}
 
 	g.m.Lock()
@@ -417,3 +478,120 @@ func (g *Gamepad) Vibrate(duration time.Duration, strongMagnitude float64, weakM
 
 	g.native.vibrate(duration, strongMagnitude, weakMagnitude)
 }
+
+// ErrLEDNotSupported is returned when the gamepad or the platform doesn't support setting the LED color.
+var ErrLEDNotSupported = errors.New("gamepad: LED is not supported")
+
+// ledSetter is an optional interface for a nativeGamepad that can set the color of its LED light bar.
+type ledSetter interface {
+	setLEDColor(red, green, blue uint8) error
+}
+
+// SetLEDColor sets the color of the gamepad's LED light bar.
+// If the gamepad or the platform doesn't support it, SetLEDColor returns ErrLEDNotSupported.
+// Only Linux is supported so far.
+//
+// SetLEDColor is concurrent-safe.
+func (g *Gamepad) SetLEDColor(red, green, blue uint8) error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if !gamepaddb.SupportsLED(g.sdlID, gamepaddb.CurrentPlatformName()) {
+		return ErrLEDNotSupported
+	}
+	s, ok := g.native.(ledSetter)
+	if !ok {
+		return ErrLEDNotSupported
+	}
+	return s.setLEDColor(red, green, blue)
+}
+
+// touchpadReader is an optional interface for a nativeGamepad that can report a touch position on its touchpad.
+type touchpadReader interface {
+	touchpadPosition() (x, y float64, ok bool)
+}
+
+// TouchpadPosition returns the normalized position of a finger on the gamepad's touchpad.
+// x and y are in between 0 and 1, and (0, 0) is the upper-left corner.
+//
+// TouchpadPosition returns false as ok if the gamepad doesn't have a touchpad,
+// the platform doesn't support it, or no finger is on the touchpad.
+//
+// TouchpadPosition is concurrent-safe.
+func (g *Gamepad) TouchpadPosition() (x, y float64, ok bool) {
+	if !g.IsStandardButtonAvailable(gamepaddb.StandardButtonTouchpad) {
+		return 0, 0, false
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	r, ok := g.native.(touchpadReader)
+	if !ok {
+		return 0, 0, false
+	}
+	return r.touchpadPosition()
+}
+
+// ConnectionType represents how a gamepad is connected to the device.
+type ConnectionType int
+
+const (
+	ConnectionTypeUnknown ConnectionType = iota
+	ConnectionTypeWired
+	ConnectionTypeWireless
+)
+
+// connectionTypeReader is an optional interface for a nativeGamepad that can report its connection type.
+type connectionTypeReader interface {
+	connectionType() ConnectionType
+}
+
+// ConnectionType returns how the gamepad is connected.
+// If the platform doesn't expose it, ConnectionType returns ConnectionTypeUnknown.
+//
+// ConnectionType is concurrent-safe.
+func (g *Gamepad) ConnectionType() ConnectionType {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	r, ok := g.native.(connectionTypeReader)
+	if !ok {
+		return ConnectionTypeUnknown
+	}
+	return r.connectionType()
+}
+
+// ErrTriggerEffectNotSupported is returned when the gamepad or the platform doesn't support adaptive trigger effects.
+var ErrTriggerEffectNotSupported = errors.New("gamepad: trigger effect is not supported")
+
+// triggerEffectSetter is an optional interface for a nativeGamepad that can set force feedback of its adaptive triggers.
+type triggerEffectSetter interface {
+	setTriggerEffect(trigger Trigger, effect TriggerEffect) error
+}
+
+// SetTriggerEffect sets the force feedback effect of the gamepad's adaptive trigger.
+// If the gamepad or the platform doesn't support it, SetTriggerEffect returns ErrTriggerEffectNotSupported.
+// Only DualSense on Linux is supported so far.
+//
+// SetTriggerEffect is concurrent-safe.
+func (g *Gamepad) SetTriggerEffect(trigger Trigger, effect TriggerEffect) error {
+	if trigger != TriggerLeft && trigger != TriggerRight {
+		return fmt.Errorf("gamepad: invalid trigger: %d", trigger)
+	}
+	if effect.Type < TriggerEffectTypeOff || effect.Type > TriggerEffectTypeWeapon {
+		return fmt.Errorf("gamepad: invalid trigger effect type: %d", effect.Type)
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if !gamepaddb.SupportsTriggerEffects(g.sdlID, gamepaddb.CurrentPlatformName()) {
+		return ErrTriggerEffectNotSupported
+	}
+	s, ok := g.native.(triggerEffectSetter)
+	if !ok {
+		return ErrTriggerEffectNotSupported
+	}
+	return s.setTriggerEffect(trigger, effect)
+}
@@ -182,6 +182,19 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 		_CFNumberGetValue(_CFNumberRef(prop), kCFNumberSInt32Type, unsafe.Pointer(&version))
 	}
 
+	connType := ConnectionTypeUnknown
+	if prop := _IOHIDDeviceGetProperty(device, _CFStringCreateWithCString(kCFAllocatorDefault, kIOHIDTransportKey, kCFStringEncodingUTF8)); prop != 0 {
+		var cstr [256]byte
+		_CFStringGetCString(_CFStringRef(prop), cstr[:], kCFStringEncodingUTF8)
+		switch transport := strings.TrimRight(string(cstr[:]), "\x00"); {
+		case transport == "USB":
+			connType = ConnectionTypeWired
+		case strings.HasPrefix(transport, "Bluetooth"):
+			// This includes "Bluetooth Low Energy".
+			connType = ConnectionTypeWireless
+		}
+	}
+
 	var sdlID string
 	if vendor != 0 && product != 0 {
 		sdlID = fmt.Sprintf("03000000%02x%02x0000%02x%02x0000%02x%02x0000",
@@ -198,7 +211,8 @@ func (g *nativeGamepadsImpl) addDevice(device _IOHIDDeviceRef, gamepads *gamepad
 	}
 
 	n := &nativeGamepadImpl{
-		device: device,
+		device:   device,
+		connType: connType,
 	}
 	gp := gamepads.add(name, sdlID)
 	gp.native = n
@@ -306,16 +320,21 @@ func (e elements) Swap(i, j int) {
 }
 
 type nativeGamepadImpl struct {
-	device  _IOHIDDeviceRef
-	axes    elements
-	buttons elements
-	hats    elements
+	device   _IOHIDDeviceRef
+	connType ConnectionType
+	axes     elements
+	buttons  elements
+	hats     elements
 
 	axisValues   []float64
 	buttonValues []bool
 	hatValues    []int
 }
 
+func (g *nativeGamepadImpl) connectionType() ConnectionType {
+	return g.connType
+}
+
 func (g *nativeGamepadImpl) elementValue(e *element) int {
 	var valueRef _IOHIDValueRef
 	if _IOHIDDeviceGetValue(g.device, e.native, &valueRef) == kIOReturnSuccess {
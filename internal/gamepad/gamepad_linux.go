@@ -17,11 +17,13 @@
 package gamepad
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -177,8 +179,9 @@ func (*nativeGamepadsImpl) openGamepad(gamepads *gamepads, path string) (err err
 	}
 
 	n := &nativeGamepadImpl{
-		path: path,
-		fd:   fd,
+		path:    path,
+		fd:      fd,
+		bustype: id.bustype,
 	}
 	gp := gamepads.add(name, sdlID)
 	gp.native = n
@@ -289,6 +292,7 @@ func (g *nativeGamepadsImpl) update(gamepads *gamepads) error {
 type nativeGamepadImpl struct {
 	fd      int
 	path    string
+	bustype uint16
 	keyMap  [_KEY_CNT - _BTN_MISC]int
 	absMap  [_ABS_CNT]int
 	absInfo [_ABS_CNT]input_absinfo
@@ -304,6 +308,124 @@ type nativeGamepadImpl struct {
 
 	stdAxisMap   map[gamepaddb.StandardAxis]mappingInput
 	stdButtonMap map[gamepaddb.StandardButton]mappingInput
+
+	// outputReportSeq is a sequence number of output reports sent via Bluetooth.
+	outputReportSeq uint8
+}
+
+func (g *nativeGamepadImpl) connectionType() ConnectionType {
+	switch g.bustype {
+	case _BUS_USB:
+		return ConnectionTypeWired
+	case _BUS_BLUETOOTH:
+		return ConnectionTypeWireless
+	}
+	return ConnectionTypeUnknown
+}
+
+// hidDeviceDir returns the sysfs directory of the HID device that the event device belongs to.
+func (g *nativeGamepadImpl) hidDeviceDir() string {
+	// /sys/class/input/eventN/device is the input device, and its device is the HID device.
+	return filepath.Join("/sys/class/input", filepath.Base(g.path), "device", "device")
+}
+
+func (g *nativeGamepadImpl) setLEDColor(red, green, blue uint8) error {
+	dir := filepath.Join(g.hidDeviceDir(), "leds")
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrLEDNotSupported
+		}
+		return fmt.Errorf("gamepad: reading %s failed: %w", dir, err)
+	}
+
+	// hid-playstation exposes a multicolor LED named "<device>:rgb:indicator".
+	for _, ent := range ents {
+		if !strings.HasSuffix(ent.Name(), ":rgb:indicator") {
+			continue
+		}
+		led := filepath.Join(dir, ent.Name())
+		if err := writeSysfsFile(filepath.Join(led, "multi_intensity"), fmt.Sprintf("%d %d %d", red, green, blue)); err != nil {
+			return err
+		}
+		if err := writeSysfsFile(filepath.Join(led, "brightness"), "255"); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// hid-sony exposes LEDs named "<device>:red", "<device>:green" and "<device>:blue".
+	var found bool
+	for _, ent := range ents {
+		var v uint8
+		switch {
+		case strings.HasSuffix(ent.Name(), ":red"):
+			v = red
+		case strings.HasSuffix(ent.Name(), ":green"):
+			v = green
+		case strings.HasSuffix(ent.Name(), ":blue"):
+			v = blue
+		default:
+			continue
+		}
+		if err := writeSysfsFile(filepath.Join(dir, ent.Name(), "brightness"), fmt.Sprintf("%d", v)); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return ErrLEDNotSupported
+	}
+	return nil
+}
+
+func (g *nativeGamepadImpl) setTriggerEffect(trigger Trigger, effect TriggerEffect) error {
+	var bluetooth bool
+	switch g.bustype {
+	case _BUS_USB:
+	case _BUS_BLUETOOTH:
+		bluetooth = true
+	default:
+		return ErrTriggerEffectNotSupported
+	}
+
+	// The kernel driver doesn't support adaptive triggers. Send an output report via hidraw directly.
+	dir := filepath.Join(g.hidDeviceDir(), "hidraw")
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrTriggerEffectNotSupported
+		}
+		return fmt.Errorf("gamepad: reading %s failed: %w", dir, err)
+	}
+	if len(ents) == 0 {
+		return ErrTriggerEffectNotSupported
+	}
+	path := filepath.Join("/dev", ents[0].Name())
+
+	fd, err := unix.Open(path, unix.O_WRONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("gamepad: Open %s failed: %w", path, err)
+	}
+	defer func() {
+		_ = unix.Close(fd)
+	}()
+
+	report := dualSenseTriggerEffectReport(trigger, effect, bluetooth, g.outputReportSeq)
+	g.outputReportSeq = (g.outputReportSeq + 1) & 0xf
+	if _, err := unix.Write(fd, report); err != nil {
+		return fmt.Errorf("gamepad: Write %s failed: %w", path, err)
+	}
+	return nil
+}
+
+// writeSysfsFile writes value to the sysfs attribute file path.
+// Writing LED attributes usually requires a udev rule to grant the permission.
+func writeSysfsFile(path string, value string) error {
+	if err := os.WriteFile(path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("gamepad: writing to %s failed: %w", path, err)
+	}
+	return nil
 }
 
 func (g *nativeGamepadImpl) close() {
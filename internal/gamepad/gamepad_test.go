@@ -0,0 +1,224 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
+	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
+)
+
+func TestSessionIDs(t *testing.T) {
+	const (
+		idA = "03000000de2800000112000001000000"
+		idB = "030000005e0400008e02000014010000"
+	)
+
+	var s gamepad.SessionIDs
+	now := time.Now()
+
+	// Two identical gamepads get distinct session IDs.
+	a1 := s.Acquire(idA, now)
+	a2 := s.Acquire(idA, now)
+	b1 := s.Acquire(idB, now)
+	if a1 == a2 {
+		t.Errorf("identical gamepads must have distinct session IDs: %q", a1)
+	}
+	if got, want := a1, idA+"-1"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := a2, idA+"-2"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := b1, idB+"-1"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// A gamepad reconnected in a short time reuses the session ID.
+	s.Release(idA, a1, now)
+	now = now.Add(time.Second)
+	if got, want := s.Acquire(idA, now), a1; got != want {
+		t.Errorf("reconnect: got: %q, want: %q", got, want)
+	}
+
+	// A gamepad with a different SDL ID doesn't reuse the session ID.
+	s.Release(idA, a1, now)
+	if got, want := s.Acquire(idB, now), idB+"-2"; got != want {
+		t.Errorf("other gamepad: got: %q, want: %q", got, want)
+	}
+
+	// The most recently disconnected gamepad is preferred.
+	s.Release(idA, a2, now.Add(time.Second))
+	now = now.Add(2 * time.Second)
+	if got, want := s.Acquire(idA, now), a2; got != want {
+		t.Errorf("reconnect after two disconnections: got: %q, want: %q", got, want)
+	}
+	if got, want := s.Acquire(idA, now), a1; got != want {
+		t.Errorf("reconnect after two disconnections: got: %q, want: %q", got, want)
+	}
+
+	// A gamepad reconnected after a long time gets a new session ID.
+	s.Release(idA, a1, now)
+	now = now.Add(gamepad.SessionReuseDuration + time.Second)
+	if got, want := s.Acquire(idA, now), idA+"-3"; got != want {
+		t.Errorf("reconnect after a long time: got: %q, want: %q", got, want)
+	}
+}
+
+func TestRawAxes(t *testing.T) {
+	const (
+		sdlID = "ebitengine0000000000000000000010"
+		name  = "Synthetic Flight Stick"
+	)
+
+	// Map only two axes to the standard layout. The other axes like a throttle or a rudder don't fit the layout.
+	if err := gamepaddb.Update([]byte(sdlID + "," + name + ",a:b0,leftx:a0,lefty:a1,\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	var gs gamepad.Gamepads
+	axes := []float64{-1, -0.75, -0.5, -0.25, 0, 0.25, 0.5, 0.75}
+	g := gs.AddForTesting(name, sdlID, axes)
+	if !g.IsStandardLayoutAvailable() {
+		t.Fatal("the standard layout must be available")
+	}
+	if got, want := g.StandardAxisValue(gamepaddb.StandardAxisLeftStickHorizontal), axes[0]; got != want {
+		t.Errorf("StandardAxisValue(StandardAxisLeftStickHorizontal): got: %f, want: %f", got, want)
+	}
+
+	// All the raw axes are available regardless of the standard layout mapping.
+	if got, want := g.AxisCount(), len(axes); got != want {
+		t.Errorf("AxisCount(): got: %d, want: %d", got, want)
+	}
+	for i, want := range axes {
+		if got := g.Axis(i); got != want {
+			t.Errorf("Axis(%d): got: %f, want: %f", i, got, want)
+		}
+	}
+	if got := g.Axis(len(axes)); got != 0 {
+		t.Errorf("Axis(%d): got: %f, want: 0", len(axes), got)
+	}
+
+	// The number of the axes can change when the gamepad is reconnected.
+	gs.Remove(g)
+	axes = []float64{0.5, -0.5, 1, -1}
+	g = gs.AddForTesting(name, sdlID, axes)
+	if got, want := g.AxisCount(), len(axes); got != want {
+		t.Errorf("AxisCount() after reconnection: got: %d, want: %d", got, want)
+	}
+	for i, want := range axes {
+		if got := g.Axis(i); got != want {
+			t.Errorf("Axis(%d) after reconnection: got: %f, want: %f", i, got, want)
+		}
+	}
+	if got := g.Axis(7); got != 0 {
+		t.Errorf("Axis(7) after reconnection: got: %f, want: 0", got)
+	}
+}
+
+func TestSetLEDColorNotSupported(t *testing.T) {
+	var gs gamepad.Gamepads
+	for _, sdlID := range []string{
+		// An unknown gamepad.
+		"ebitengine0000000000000000000011",
+		// DualSense, but the synthetic gamepad doesn't have a backend to control the LED.
+		"030000004c050000e60c000000000000",
+	} {
+		g := gs.AddForTesting("Synthetic Gamepad", sdlID, nil)
+		if err := g.SetLEDColor(0xff, 0x80, 0); !errors.Is(err, gamepad.ErrLEDNotSupported) {
+			t.Errorf("SetLEDColor for %s: got: %v, want: %v", sdlID, err, gamepad.ErrLEDNotSupported)
+		}
+		gs.Remove(g)
+	}
+}
+
+func TestSetTriggerEffectNotSupported(t *testing.T) {
+	var gs gamepad.Gamepads
+	g := gs.AddForTesting("Synthetic Gamepad", "ebitengine0000000000000000000012", nil)
+	defer gs.Remove(g)
+
+	effect := gamepad.TriggerEffect{
+		Type:          gamepad.TriggerEffectTypeResistance,
+		StartPosition: 0.5,
+		Strength:      1,
+	}
+	if err := g.SetTriggerEffect(gamepad.TriggerRight, effect); !errors.Is(err, gamepad.ErrTriggerEffectNotSupported) {
+		t.Errorf("SetTriggerEffect: got: %v, want: %v", err, gamepad.ErrTriggerEffectNotSupported)
+	}
+	if err := g.SetTriggerEffect(gamepad.Trigger(2), effect); err == nil {
+		t.Errorf("SetTriggerEffect with an invalid trigger must return an error")
+	}
+}
+
+func TestStandardButtonFromRaw(t *testing.T) {
+	const (
+		sdlID = "ebitengine0000000000000000000013"
+		name  = "Synthetic Gamepad"
+	)
+
+	if err := gamepaddb.Update([]byte(sdlID + "," + name + ",a:b2,b:b0,dpup:h0.1,dpleft:h0.8,\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	var gs gamepad.Gamepads
+	g := gs.AddForTesting(name, sdlID, nil)
+	defer gs.Remove(g)
+
+	if got, ok := g.StandardButtonFromRaw(2); !ok || got != gamepaddb.StandardButtonRightBottom {
+		t.Errorf("StandardButtonFromRaw(2): got: %d, %t, want: %d, true", got, ok, gamepaddb.StandardButtonRightBottom)
+	}
+	if got, ok := g.StandardButtonFromRaw(1); ok {
+		t.Errorf("StandardButtonFromRaw(1): got: %d, %t, want: false", got, ok)
+	}
+	if got, ok := g.StandardButtonFromRawHat(0, gamepaddb.HatLeft); !ok || got != gamepaddb.StandardButtonLeftLeft {
+		t.Errorf("StandardButtonFromRawHat(0, HatLeft): got: %d, %t, want: %d, true", got, ok, gamepaddb.StandardButtonLeftLeft)
+	}
+	if got, ok := g.StandardButtonFromRawHat(0, gamepaddb.HatDown); ok {
+		t.Errorf("StandardButtonFromRawHat(0, HatDown): got: %d, %t, want: false", got, ok)
+	}
+}
+
+func TestMappingIDAfterUpdate(t *testing.T) {
+	const (
+		sdlID = "ebitengine0000000000000000000016"
+		name  = "Synthetic Gamepad"
+	)
+
+	var gs gamepad.Gamepads
+	g := gs.AddForTesting(name, sdlID, nil)
+	defer gs.Remove(g)
+
+	if g.IsStandardLayoutAvailable() {
+		t.Fatal("the standard layout must not be available before the mapping is added")
+	}
+	if got, want := g.Name(), name; got != want {
+		t.Errorf("Name(): got: %q, want: %q", got, want)
+	}
+
+	// A mapping added after the connection must take effect for the connected gamepad.
+	const mappedName = "Synthetic Gamepad (Mapped)"
+	if err := gamepaddb.Update([]byte(sdlID + "," + mappedName + ",a:b0,\n")); err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsStandardLayoutAvailable() {
+		t.Error("the standard layout must be available after the mapping is added")
+	}
+	if got, want := g.Name(), mappedName; got != want {
+		t.Errorf("Name() after Update: got: %q, want: %q", got, want)
+	}
+}
@@ -59,6 +59,8 @@ func standardButtonToGamepadInputGamepadButton(b gamepaddb.StandardButton) (_Gam
 		return _GameInputGamepadDPadRight, true
 	case gamepaddb.StandardButtonCenterCenter:
 		return 0, false
+	case gamepaddb.StandardButtonTouchpad:
+		return 0, false
 	}
 	return 0, false
 }
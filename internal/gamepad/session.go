@@ -0,0 +1,82 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+import (
+	"fmt"
+	"time"
+)
+
+// sessionReuseDuration is the duration in which a reconnected gamepad reuses the session ID of a disconnected
+// gamepad with the same SDL ID.
+const sessionReuseDuration = 5 * time.Second
+
+type disconnectedSession struct {
+	sdlID     string
+	sessionID string
+	time      time.Time
+}
+
+// sessionIDs assigns session IDs to gamepads.
+//
+// A session ID is the SDL ID with a connection counter, so two identical gamepads get distinct session IDs.
+// When a gamepad is reconnected within sessionReuseDuration after a gamepad with the same SDL ID is disconnected,
+// the session ID of the disconnected gamepad is reused.
+type sessionIDs struct {
+	counts       map[string]int
+	disconnected []disconnectedSession
+}
+
+func (s *sessionIDs) acquire(sdlID string, now time.Time) string {
+	s.disconnected = removeExpiredSessions(s.disconnected, now)
+
+	// Prefer the most recently disconnected gamepad.
+	for i := len(s.disconnected) - 1; i >= 0; i-- {
+		d := s.disconnected[i]
+		if d.sdlID != sdlID {
+			continue
+		}
+		s.disconnected = append(s.disconnected[:i], s.disconnected[i+1:]...)
+		return d.sessionID
+	}
+
+	if s.counts == nil {
+		s.counts = map[string]int{}
+	}
+	s.counts[sdlID]++
+	return fmt.Sprintf("%s-%d", sdlID, s.counts[sdlID])
+}
+
+func (s *sessionIDs) release(sdlID string, sessionID string, now time.Time) {
+	s.disconnected = removeExpiredSessions(s.disconnected, now)
+	s.disconnected = append(s.disconnected, disconnectedSession{
+		sdlID:     sdlID,
+		sessionID: sessionID,
+		time:      now,
+	})
+}
+
+func removeExpiredSessions(sessions []disconnectedSession, now time.Time) []disconnectedSession {
+	var n int
+	for _, d := range sessions {
+		if now.Sub(d.time) > sessionReuseDuration {
+			continue
+		}
+		sessions[n] = d
+		n++
+	}
+	clear(sessions[n:])
+	return sessions[:n]
+}
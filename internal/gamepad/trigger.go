@@ -0,0 +1,55 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepad
+
+// Trigger represents an adaptive trigger of a gamepad.
+type Trigger int
+
+const (
+	TriggerLeft Trigger = iota
+	TriggerRight
+)
+
+// TriggerEffectType represents a type of an adaptive trigger's force feedback.
+type TriggerEffectType int
+
+const (
+	// TriggerEffectTypeOff turns off the force feedback.
+	TriggerEffectTypeOff TriggerEffectType = iota
+
+	// TriggerEffectTypeResistance makes the trigger resist from StartPosition to the end with Strength.
+	TriggerEffectTypeResistance
+
+	// TriggerEffectTypeWeapon makes the trigger resist from StartPosition and snap at EndPosition,
+	// like pulling a trigger of a gun.
+	TriggerEffectTypeWeapon
+)
+
+// TriggerEffect represents a force feedback of an adaptive trigger.
+type TriggerEffect struct {
+	Type TriggerEffectType
+
+	// StartPosition is the position where the effect starts.
+	// The value is in between 0 and 1.
+	StartPosition float64
+
+	// EndPosition is the position where the effect ends.
+	// The value is in between 0 and 1, and is used only for TriggerEffectTypeWeapon.
+	EndPosition float64
+
+	// Strength is the strength of the resistance.
+	// The value is in between 0 and 1.
+	Strength float64
+}
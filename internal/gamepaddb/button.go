@@ -36,7 +36,11 @@ const (
 	StandardButtonLeftRight
 	StandardButtonCenterCenter
 
-	StandardButtonMax = StandardButtonCenterCenter
+	// StandardButtonTouchpad is the click of a touchpad.
+	// This is not defined in the web standard, but browsers report this as the 18th button of some gamepads.
+	StandardButtonTouchpad
+
+	StandardButtonMax = StandardButtonTouchpad
 )
 
 type StandardAxis int
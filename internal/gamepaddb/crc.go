@@ -0,0 +1,91 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"fmt"
+)
+
+// sdlIDLength is the length of an SDL GUID string.
+const sdlIDLength = 32
+
+// crc16 computes a CRC-16 value in the same way as SDL_crc16.
+//
+// See https://github.com/libsdl-org/SDL/blob/release-2.30.x/src/stdlib/SDL_crc16.c
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		r := byte(crc) ^ b
+		var c uint16
+		for i := 0; i < 8; i++ {
+			if (uint16(r)^c)&1 != 0 {
+				c = 0xa001 ^ c>>1
+			} else {
+				c >>= 1
+			}
+			r >>= 1
+		}
+		crc = c ^ crc>>8
+	}
+	return crc
+}
+
+// idWithCRC returns the SDL ID whose CRC part is replaced with the given CRC.
+// The CRC part is the second 16-bit value in little endian.
+func idWithCRC(id string, crc uint16) string {
+	if len(id) != sdlIDLength {
+		return id
+	}
+	return id[:4] + fmt.Sprintf("%02x%02x", byte(crc), byte(crc>>8)) + id[8:]
+}
+
+func hasMappingEntry(id string) bool {
+	_, ok := gamepadNames[id]
+	return ok
+}
+
+// MappingID returns the SDL ID to look up a mapping for the gamepad with the given SDL ID and name.
+//
+// Some mappings are specific to the CRC of the device name, in order to distinguish devices with the same GUID.
+// If there is a mapping for the CRC of the name, MappingID returns the SDL ID with the CRC.
+// Otherwise, MappingID returns the SDL ID without the CRC.
+//
+// If there is no mapping for the SDL ID and the name-based fallback is enabled by EnableNameFallback,
+// MappingID returns a pseudo ID of the fallback mapping matching the name, if any.
+func MappingID(id string, name string) string {
+	ensureLoaded()
+	if nameFallbackEnabled.Load() {
+		ensureNameFallbacksLoaded()
+	}
+
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	if len(id) == sdlIDLength {
+		if crcID := idWithCRC(id, crc16([]byte(name))); hasMappingEntry(crcID) {
+			return crcID
+		}
+		if hasMappingEntry(id) {
+			return id
+		}
+		if noCRCID := idWithCRC(id, 0); hasMappingEntry(noCRCID) {
+			return noCRCID
+		}
+	}
+	if fid := nameFallbackID(name); fid != "" {
+		return fid
+	}
+	return id
+}
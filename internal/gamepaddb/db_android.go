@@ -20,11 +20,9 @@ import (
 	_ "embed"
 )
 
-//go:embed gamecontrollerdb_android.txt
-var controllerBytes []byte
+//go:embed gamecontrollerdb_android.txt.gz
+var compressedControllerBytesForPlatform []byte
 
 func init() {
-	if err := Update(controllerBytes); err != nil {
-		panic(err)
-	}
+	registerControllerBytes(compressedControllerBytesForPlatform, nil)
 }
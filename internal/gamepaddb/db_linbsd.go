@@ -22,11 +22,9 @@ import (
 	_ "embed"
 )
 
-//go:embed gamecontrollerdb_linbsd.txt
-var controllerBytes []byte
+//go:embed gamecontrollerdb_linbsd.txt.gz
+var compressedControllerBytesForPlatform []byte
 
 func init() {
-	if err := Update(controllerBytes); err != nil {
-		panic(err)
-	}
+	registerControllerBytes(compressedControllerBytesForPlatform, nil)
 }
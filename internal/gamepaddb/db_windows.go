@@ -22,8 +22,8 @@ import (
 	_ "embed"
 )
 
-//go:embed gamecontrollerdb_windows.txt
-var controllerBytes []byte
+//go:embed gamecontrollerdb_windows.txt.gz
+var compressedControllerBytesForPlatform []byte
 
 var additionalGLFWGamepads = []byte(`
 78696e70757401000000000000000000,XInput Gamepad (GLFW),platform:Windows,a:b0,b:b1,x:b2,y:b3,leftshoulder:b4,rightshoulder:b5,back:b6,start:b7,leftstick:b8,rightstick:b9,leftx:a0,lefty:a1,rightx:a2,righty:a3,lefttrigger:a4,righttrigger:a5,dpup:h0.1,dpright:h0.2,dpdown:h0.4,dpleft:h0.8,
@@ -36,10 +36,5 @@ var additionalGLFWGamepads = []byte(`
 `)
 
 func init() {
-	if err := Update(controllerBytes); err != nil {
-		panic(err)
-	}
-	if err := Update(additionalGLFWGamepads); err != nil {
-		panic(err)
-	}
+	registerControllerBytes(compressedControllerBytesForPlatform, additionalGLFWGamepads)
 }
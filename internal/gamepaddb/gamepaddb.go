@@ -20,12 +20,16 @@ package gamepaddb
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"math"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 type platform int
@@ -83,9 +87,69 @@ var (
 	gamepadNames          = map[string]string{}
 	gamepadButtonMappings = map[string]map[StandardButton]mapping{}
 	gamepadAxisMappings   = map[string]map[StandardAxis]mapping{}
+	overriddenIDs         = map[string]struct{}{}
+	axisDeadzones         [StandardAxisMax + 1]float64
 	mappingsM             sync.RWMutex
 )
 
+var (
+	// compressedControllerBytes is the gzip-compressed mappings for the current platform.
+	compressedControllerBytes []byte
+
+	// additionalControllerBytes is the uncompressed mappings applied after compressedControllerBytes.
+	additionalControllerBytes []byte
+
+	loadOnce sync.Once
+
+	// mappingsGeneration is incremented whenever the result of MappingID might change.
+	mappingsGeneration atomic.Uint64
+)
+
+// MappingsGeneration returns a counter that is incremented whenever the mappings are modified.
+//
+// A caller can cache the result of MappingID and reuse it as long as MappingsGeneration returns the same value.
+func MappingsGeneration() uint64 {
+	return mappingsGeneration.Load()
+}
+
+// registerControllerBytes registers the embedded mappings for the current platform.
+// registerControllerBytes is called from an init function in a generated file.
+func registerControllerBytes(compressed []byte, additional []byte) {
+	compressedControllerBytes = compressed
+	additionalControllerBytes = additional
+}
+
+// ensureLoaded decompresses and applies the embedded mappings if they are not applied yet.
+//
+// The embedded mappings are loaded lazily at the first access so that the startup is not slowed down.
+// ensureLoaded must not be called with mappingsM locked.
+func ensureLoaded() {
+	loadOnce.Do(func() {
+		bs, err := decompressControllerBytes(compressedControllerBytes)
+		if err != nil {
+			panic(fmt.Sprintf("gamepaddb: decompressing the embedded mappings failed: %v", err))
+		}
+		if err := update(bs); err != nil {
+			panic(err)
+		}
+		if err := update(additionalControllerBytes); err != nil {
+			panic(err)
+		}
+	})
+}
+
+func decompressControllerBytes(compressed []byte) ([]byte, error) {
+	if len(compressed) == 0 {
+		return nil, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
 func parseLine(line string, platform platform) (id string, name string, buttons map[StandardButton]mapping, axes map[StandardAxis]mapping, err error) {
 	line = strings.TrimSpace(line)
 	if len(line) == 0 {
@@ -98,6 +162,7 @@ func parseLine(line string, platform platform) (id string, name string, buttons
 	if len(tokens) < 2 {
 		return "", "", nil, nil, fmt.Errorf("gamepaddb: syntax error")
 	}
+	id = tokens[0]
 
 	for _, token := range tokens[2:] {
 		if len(token) == 0 {
@@ -139,9 +204,20 @@ func parseLine(line string, platform platform) (id string, name string, buttons
 			continue
 		}
 
+		// The crc field specifies the CRC of the device name, which the mapping is specific to.
+		// Embed the CRC into the ID in the same way as SDL's GUID.
+		if tks[0] == "crc" {
+			crc, err := strconv.ParseUint(tks[1], 16, 16)
+			if err != nil {
+				return "", "", nil, nil, fmt.Errorf("gamepaddb: unexpected crc value: %s", tks[1])
+			}
+			id = idWithCRC(id, uint16(crc))
+			continue
+		}
+
 		gb, err := parseMappingElement(tks[1])
 		if err != nil {
-			return "", "", nil, nil, err
+			return "", "", nil, nil, fmt.Errorf("gamepaddb: invalid mapping element %q: %w", token, err)
 		}
 
 		if b, ok := toStandardGamepadButton(tks[0]); ok {
@@ -164,10 +240,14 @@ func parseLine(line string, platform platform) (id string, name string, buttons
 		// There is no corresponding button in the Web standard gamepad layout.
 	}
 
-	return tokens[0], tokens[1], buttons, axes, nil
+	return id, tokens[1], buttons, axes, nil
 }
 
 func parseMappingElement(str string) (mapping, error) {
+	if len(str) == 0 {
+		return mapping{}, fmt.Errorf("gamepaddb: empty mapping")
+	}
+
 	switch {
 	case str[0] == 'a' || strings.HasPrefix(str, "+a") || strings.HasPrefix(str, "-a"):
 		var tilda bool
@@ -292,6 +372,8 @@ func toStandardGamepadButton(str string) (StandardButton, bool) {
 		return StandardButtonFrontBottomLeft, true
 	case "righttrigger":
 		return StandardButtonFrontBottomRight, true
+	case "touchpad":
+		return StandardButtonTouchpad, true
 	default:
 		return 0, false
 	}
@@ -337,6 +419,8 @@ func axisMappings(id string) map[StandardAxis]mapping {
 }
 
 func HasStandardLayoutMapping(id string) bool {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -351,6 +435,8 @@ type GamepadState interface {
 }
 
 func Name(id string) string {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -358,6 +444,8 @@ func Name(id string) string {
 }
 
 func HasStandardAxis(id string, axis StandardAxis) bool {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -370,6 +458,8 @@ func HasStandardAxis(id string, axis StandardAxis) bool {
 }
 
 func StandardAxisValue(id string, axis StandardAxis, state GamepadState) float64 {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -390,11 +480,11 @@ func StandardAxisValue(id string, axis StandardAxis, state GamepadState) float64
 		}
 		v := state.Axis(mapping.Index)*mapping.AxisScale + mapping.AxisOffset
 		if v > 1 {
-			return 1
+			v = 1
 		} else if v < -1 {
-			return -1
+			v = -1
 		}
-		return v
+		return applyDeadzone(v, axisDeadzones[axis])
 	case mappingTypeButton:
 		if state.Button(mapping.Index) {
 			return 1
@@ -412,7 +502,68 @@ func StandardAxisValue(id string, axis StandardAxis, state GamepadState) float64
 	return 0
 }
 
+// SetAxisDeadzone sets the deadzone threshold for the given standard axis.
+//
+// An axis value whose absolute value is less than or equal to threshold is reported as 0.
+// An axis value outside of the deadzone is rescaled so that the value changes continuously from 0 at the threshold to 1.
+//
+// threshold must be in [0, 1). The default threshold is 0, which means no deadzone.
+func SetAxisDeadzone(axis StandardAxis, threshold float64) {
+	if axis < 0 || axis > StandardAxisMax {
+		panic(fmt.Sprintf("gamepaddb: invalid axis: %d", axis))
+	}
+	if threshold < 0 || threshold >= 1 {
+		panic(fmt.Sprintf("gamepaddb: threshold must be in [0, 1) but %f", threshold))
+	}
+
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	axisDeadzones[axis] = threshold
+}
+
+// AxisDeadzone returns the deadzone threshold for the given standard axis.
+func AxisDeadzone(axis StandardAxis) float64 {
+	if axis < 0 || axis > StandardAxisMax {
+		return 0
+	}
+
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	return axisDeadzones[axis]
+}
+
+// ApplyAxisDeadzone applies the deadzone of the given standard axis to the value.
+// ApplyAxisDeadzone is used for axis values that are not resolved by the database mappings.
+func ApplyAxisDeadzone(axis StandardAxis, value float64) float64 {
+	if axis < 0 || axis > StandardAxisMax {
+		return value
+	}
+
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	return applyDeadzone(value, axisDeadzones[axis])
+}
+
+func applyDeadzone(value float64, threshold float64) float64 {
+	if threshold == 0 {
+		return value
+	}
+	if math.Abs(value) <= threshold {
+		return 0
+	}
+	v := (math.Abs(value) - threshold) / (1 - threshold)
+	if value < 0 {
+		return -v
+	}
+	return v
+}
+
 func HasStandardButton(id string, button StandardButton) bool {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -425,6 +576,8 @@ func HasStandardButton(id string, button StandardButton) bool {
 }
 
 func StandardButtonValue(id string, button StandardButton, state GamepadState) float64 {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -477,6 +630,8 @@ func standardButtonValue(id string, button StandardButton, state GamepadState) f
 const ButtonPressedThreshold = 30.0 / 255.0
 
 func IsStandardButtonPressed(id string, button StandardButton, state GamepadState) bool {
+	ensureLoaded()
+
 	mappingsM.RLock()
 	defer mappingsM.RUnlock()
 
@@ -508,6 +663,11 @@ func IsStandardButtonPressed(id string, button StandardButton, state GamepadStat
 //
 // Update works atomically. If an error happens, nothing is updated.
 func Update(mappingData []byte) error {
+	ensureLoaded()
+	return update(mappingData)
+}
+
+func update(mappingData []byte) error {
 	mappingsM.Lock()
 	defer mappingsM.Unlock()
 
@@ -543,11 +703,51 @@ func Update(mappingData []byte) error {
 	}
 
 	for _, l := range lines {
+		// Overrides take precedence over any other mappings.
+		if _, ok := overriddenIDs[l.id]; ok {
+			continue
+		}
 		gamepadNames[l.id] = l.name
 		gamepadButtonMappings[l.id] = l.buttons
 		gamepadAxisMappings[l.id] = l.axes
 	}
+	mappingInfos = nil
+	mappingsGeneration.Add(1)
+
+	return nil
+}
+
+// AddOverride adds a gamepad mapping that takes precedence over the other mappings.
+// The string must be one line in the format of SDL_GameControllerDB.
+//
+// An override replaces the existing mapping for the same ID, and is never replaced by Update.
+// An override is kept for the process lifetime.
+//
+// If the mapping is invalid or not for the current platform, AddOverride returns an error.
+func AddOverride(mapping string) error {
+	ensureLoaded()
+
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	mapping = strings.TrimSpace(mapping)
+	if strings.ContainsAny(mapping, "\r\n") {
+		return fmt.Errorf("gamepaddb: an override must be one line")
+	}
+	id, name, buttons, axes, err := parseLine(mapping, currentPlatform())
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return fmt.Errorf("gamepaddb: the mapping is empty or not for the current platform: %q", mapping)
+	}
 
+	overriddenIDs[id] = struct{}{}
+	gamepadNames[id] = name
+	gamepadButtonMappings[id] = buttons
+	gamepadAxisMappings[id] = axes
+	mappingInfos = nil
+	mappingsGeneration.Add(1)
 	return nil
 }
 
@@ -15,8 +15,13 @@
 package gamepaddb_test
 
 import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepaddb"
 )
@@ -80,3 +85,544 @@ func TestGLFWGamepadMappings(t *testing.T) {
 		t.Errorf("got: %q, want: %q", got, want)
 	}
 }
+
+func TestMappingIDWithCRC(t *testing.T) {
+	const id = "03000000ebe100000100000000000000"
+
+	// 0xbb3d is the CRC-16 value of "123456789".
+	mappings := id + ",Generic Gamepad,a:b0,b:b1,\n" +
+		id + ",Specific Gamepad,a:b1,b:b0,crc:bb3d,\n"
+	if err := gamepaddb.Update([]byte(mappings)); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Name     string
+		WantName string
+	}{
+		{Name: "123456789", WantName: "Specific Gamepad"},
+		{Name: "Another Gamepad", WantName: "Generic Gamepad"},
+	}
+	for _, c := range cases {
+		mid := gamepaddb.MappingID(id, c.Name)
+		if got, want := gamepaddb.Name(mid), c.WantName; got != want {
+			t.Errorf("Name(MappingID(%q, %q)): got: %q, want: %q", id, c.Name, got, want)
+		}
+		if !gamepaddb.HasStandardLayoutMapping(mid) {
+			t.Errorf("HasStandardLayoutMapping(MappingID(%q, %q)) must be true", id, c.Name)
+		}
+	}
+
+	// An SDL ID which already has the CRC falls back to the mapping without the CRC.
+	const crcID = "0300ffffebe100000100000000000000"
+	if got, want := gamepaddb.Name(gamepaddb.MappingID(crcID, "Another Gamepad")), "Generic Gamepad"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	if err := gamepaddb.Update([]byte(id + ",Gamepad,crc:foo,")); err == nil {
+		t.Errorf("Update with an invalid crc value should return an error but not")
+	}
+}
+
+type testGamepadState struct {
+	axes    []float64
+	buttons []bool
+}
+
+func (s *testGamepadState) IsAxisReady(index int) bool {
+	return index < len(s.axes)
+}
+
+func (s *testGamepadState) Axis(index int) float64 {
+	return s.axes[index]
+}
+
+func (s *testGamepadState) Button(index int) bool {
+	return s.buttons[index]
+}
+
+func (s *testGamepadState) Hat(index int) int {
+	return 0
+}
+
+func TestAddOverride(t *testing.T) {
+	const id = "ebitengine0000000000000000000010"
+
+	if err := gamepaddb.Update([]byte(id + ",Original Gamepad,a:b0,b:b1,")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gamepaddb.AddOverride(id + ",Overridden Gamepad,a:b3,b:b2,"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Updating the mappings must not replace the override.
+	if err := gamepaddb.Update([]byte(id + ",Original Gamepad,a:b0,b:b1,")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := gamepaddb.Name(id), "Overridden Gamepad"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	state := &testGamepadState{
+		buttons: []bool{false, false, false, true},
+	}
+	if !gamepaddb.IsStandardButtonPressed(id, gamepaddb.StandardButtonRightBottom, state) {
+		t.Errorf("StandardButtonRightBottom must be pressed with the overridden mapping")
+	}
+	if gamepaddb.IsStandardButtonPressed(id, gamepaddb.StandardButtonRightRight, state) {
+		t.Errorf("StandardButtonRightRight must not be pressed with the overridden mapping")
+	}
+
+	for _, mapping := range []string{
+		"",
+		id + ",Gamepad,a:c0,",
+		id + ",Gamepad,a:bx,",
+		id + ",Gamepad,dpup:h0,",
+		id + ",Gamepad,a:,",
+		id + ",Gamepad,a:b0,\n" + id + ",Gamepad,a:b0,",
+		id + ",Gamepad,a:b0,platform:Foo,",
+	} {
+		if err := gamepaddb.AddOverride(mapping); err == nil {
+			t.Errorf("AddOverride(%q) must return an error", mapping)
+		}
+	}
+}
+
+func TestListMappings(t *testing.T) {
+	if got := gamepaddb.ListMappings("Foo"); got != nil {
+		t.Errorf("ListMappings(%q): got: %v, want: nil", "Foo", got)
+	}
+
+	if runtime.GOOS != "windows" {
+		t.Skip("the well-known mapping is only for Windows")
+	}
+
+	infos := gamepaddb.ListMappings("Windows")
+	if len(infos) == 0 {
+		t.Fatalf("ListMappings(%q) must return one or more mappings", "Windows")
+	}
+	if got := gamepaddb.ListMappings("Mac OS X"); got != nil {
+		t.Errorf("ListMappings(%q): got: %v, want: nil", "Mac OS X", got)
+	}
+
+	const id = "78696e70757401000000000000000000"
+	var found bool
+	for _, info := range infos {
+		if info.ID != id {
+			continue
+		}
+		found = true
+		if got, want := info.Name, "XInput Gamepad (GLFW)"; got != want {
+			t.Errorf("got: %q, want: %q", got, want)
+		}
+		if len(info.Buttons) == 0 || len(info.Axes) == 0 {
+			t.Errorf("the mapping must have buttons and axes: %v", info)
+		}
+	}
+	if !found {
+		t.Errorf("ListMappings(%q) must include %s", "Windows", id)
+	}
+}
+
+func TestAxisDeadzone(t *testing.T) {
+	const id = "ebitengine0000000000000000000011"
+
+	if err := gamepaddb.Update([]byte(id + ",Deadzone Gamepad,leftx:a0,lefty:a1,")); err != nil {
+		t.Fatal(err)
+	}
+
+	defer gamepaddb.SetAxisDeadzone(gamepaddb.StandardAxisLeftStickHorizontal, 0)
+	gamepaddb.SetAxisDeadzone(gamepaddb.StandardAxisLeftStickHorizontal, 0.2)
+
+	value := func(axis gamepaddb.StandardAxis, v float64) float64 {
+		return gamepaddb.StandardAxisValue(id, axis, &testGamepadState{
+			axes: []float64{v, v},
+		})
+	}
+
+	for _, tc := range []struct {
+		in   float64
+		want float64
+	}{
+		{0, 0},
+		{0.1, 0},
+		{-0.2, 0},
+		{0.6, 0.5},
+		{-0.6, -0.5},
+		{1, 1},
+		{-1, -1},
+	} {
+		got := value(gamepaddb.StandardAxisLeftStickHorizontal, tc.in)
+		if math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("value(%f): got: %f, want: %f", tc.in, got, tc.want)
+		}
+	}
+
+	// The value must be continuous at the threshold.
+	if got := value(gamepaddb.StandardAxisLeftStickHorizontal, 0.2+1e-9); got > 1e-6 {
+		t.Errorf("the value just outside of the deadzone must be close to 0 but %f", got)
+	}
+
+	// The deadzone is per axis. The other axes must not be affected.
+	if got, want := value(gamepaddb.StandardAxisLeftStickVertical, 0.1), 0.1; got != want {
+		t.Errorf("got: %f, want: %f", got, want)
+	}
+	if got, want := gamepaddb.AxisDeadzone(gamepaddb.StandardAxisLeftStickVertical), 0.0; got != want {
+		t.Errorf("got: %f, want: %f", got, want)
+	}
+}
+
+func TestStandardButtonFromRaw(t *testing.T) {
+	const id = "ebitengine0000000000000000000012"
+
+	if err := gamepaddb.Update([]byte(id + ",Raw Gamepad,a:b0,b:b1,x:b2,y:b3,back:b6,start:b7,dpup:h0.1,dpright:h0.2,dpdown:h0.4,dpleft:h0.8,lefttrigger:a2,")); err != nil {
+		t.Fatal(err)
+	}
+
+	platform := gamepaddb.CurrentPlatformName()
+	if platform == "" {
+		t.Skip("the current platform doesn't have a mapping database")
+	}
+
+	for raw, want := range map[int]gamepaddb.StandardButton{
+		0: gamepaddb.StandardButtonRightBottom,
+		1: gamepaddb.StandardButtonRightRight,
+		2: gamepaddb.StandardButtonRightLeft,
+		3: gamepaddb.StandardButtonRightTop,
+		6: gamepaddb.StandardButtonCenterLeft,
+		7: gamepaddb.StandardButtonCenterRight,
+	} {
+		got, ok := gamepaddb.StandardButtonFromRaw(id, platform, raw)
+		if !ok || got != want {
+			t.Errorf("StandardButtonFromRaw(%d): got: (%v, %t), want: (%v, true)", raw, got, ok, want)
+		}
+	}
+	for _, raw := range []int{4, 5, 8, -1} {
+		if got, ok := gamepaddb.StandardButtonFromRaw(id, platform, raw); ok {
+			t.Errorf("StandardButtonFromRaw(%d): got: (%v, %t), want: (_, false)", raw, got, ok)
+		}
+	}
+
+	for hatState, want := range map[int]gamepaddb.StandardButton{
+		gamepaddb.HatUp:    gamepaddb.StandardButtonLeftTop,
+		gamepaddb.HatRight: gamepaddb.StandardButtonLeftRight,
+		gamepaddb.HatDown:  gamepaddb.StandardButtonLeftBottom,
+		gamepaddb.HatLeft:  gamepaddb.StandardButtonLeftLeft,
+	} {
+		got, ok := gamepaddb.StandardButtonFromRawHat(id, platform, 0, hatState)
+		if !ok || got != want {
+			t.Errorf("StandardButtonFromRawHat(0, %d): got: (%v, %t), want: (%v, true)", hatState, got, ok, want)
+		}
+	}
+	if got, ok := gamepaddb.StandardButtonFromRawHat(id, platform, 1, gamepaddb.HatUp); ok {
+		t.Errorf("StandardButtonFromRawHat(1, HatUp): got: (%v, %t), want: (_, false)", got, ok)
+	}
+
+	if got, ok := gamepaddb.StandardButtonFromRaw(id, "Foo", 0); ok {
+		t.Errorf("StandardButtonFromRaw with an unknown platform: got: (%v, %t), want: (_, false)", got, ok)
+	}
+}
+
+func TestCompressedControllerDB(t *testing.T) {
+	for _, suffix := range []string{"android", "ios", "linbsd", "macos", "windows"} {
+		original, err := os.ReadFile("gamecontrollerdb_" + suffix + ".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		compressed, err := os.ReadFile("gamecontrollerdb_" + suffix + ".txt.gz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := gamepaddb.DecompressControllerBytesForTesting(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, original) {
+			t.Errorf("the decompressed bytes for %s don't match with the original", suffix)
+		}
+	}
+}
+
+func TestSupportsLED(t *testing.T) {
+	platform := gamepaddb.CurrentPlatformName()
+	if platform == "" {
+		t.Skip("the current platform is unknown")
+	}
+
+	for _, tc := range []struct {
+		id   string
+		want bool
+	}{
+		// DualSense (USB)
+		{"030000004c050000e60c000000000000", true},
+		// DualSense (Bluetooth)
+		{"050000004c050000e60c000000810000", true},
+		// DualShock 4
+		{"030000004c050000c405000000000000", true},
+		{"030000004c050000cc09000011010000", true},
+		// PS3 Controller
+		{"030000004c0500006802000011010000", false},
+		// XInput
+		{"78696e70757401000000000000000000", false},
+		// Invalid IDs
+		{"", false},
+		{"030000004c050000e60c", false},
+	} {
+		if got := gamepaddb.SupportsLED(tc.id, platform); got != tc.want {
+			t.Errorf("SupportsLED(%q): got: %t, want: %t", tc.id, got, tc.want)
+		}
+	}
+
+	if gamepaddb.SupportsLED("030000004c050000e60c000000000000", "Foo") {
+		t.Errorf("SupportsLED with an unknown platform must return false")
+	}
+}
+
+func TestSupportsTriggerEffects(t *testing.T) {
+	platform := gamepaddb.CurrentPlatformName()
+	if platform == "" {
+		t.Skip("the current platform is unknown")
+	}
+
+	for _, tc := range []struct {
+		id   string
+		want bool
+	}{
+		// DualSense (USB)
+		{"030000004c050000e60c000000000000", true},
+		// DualSense (Bluetooth)
+		{"050000004c050000e60c000000810000", true},
+		// DualShock 4
+		{"030000004c050000c405000000000000", false},
+		// XInput
+		{"78696e70757401000000000000000000", false},
+		// Invalid IDs
+		{"", false},
+	} {
+		if got := gamepaddb.SupportsTriggerEffects(tc.id, platform); got != tc.want {
+			t.Errorf("SupportsTriggerEffects(%q): got: %t, want: %t", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	defer gamepaddb.SetWatchIntervalForTesting(time.Millisecond)()
+	defer gamepaddb.StopWatchingFile()
+
+	const id = "ebitengine0000000000000000000020"
+	path := filepath.Join(t.TempDir(), "gamecontrollerdb.txt")
+
+	if err := os.WriteFile(path, []byte(id+",Watched Gamepad 1,a:b0,b:b1,\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := gamepaddb.WatchFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gamepaddb.Name(id), "Watched Gamepad 1"; got != want {
+		t.Errorf("Name(%q): got: %q, want: %q", id, got, want)
+	}
+
+	// Make sure the modification time changes even on a file system with a coarse time resolution.
+	modTime := time.Now().Add(time.Hour)
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime = modTime.Add(time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor := func(cond func() bool) bool {
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+			if cond() {
+				return true
+			}
+			time.Sleep(time.Millisecond)
+		}
+		return false
+	}
+
+	write(id + ",Watched Gamepad 2,a:b1,b:b0,\n")
+	if !waitFor(func() bool { return gamepaddb.Name(id) == "Watched Gamepad 2" }) {
+		t.Errorf("Name(%q): got: %q, want: %q", id, gamepaddb.Name(id), "Watched Gamepad 2")
+	}
+
+	// An invalid file must be reported without changing the mappings.
+	write(id + ",Watched Gamepad 3,a:foo,\n")
+	if !waitFor(func() bool { return gamepaddb.WatchError() != nil }) {
+		t.Errorf("WatchError must return an error for an invalid file")
+	}
+	if got, want := gamepaddb.Name(id), "Watched Gamepad 2"; got != want {
+		t.Errorf("Name(%q): got: %q, want: %q", id, got, want)
+	}
+
+	// A fixed file must be reloaded and clear the error.
+	write(id + ",Watched Gamepad 4,a:b0,b:b1,\n")
+	if !waitFor(func() bool { return gamepaddb.Name(id) == "Watched Gamepad 4" }) {
+		t.Errorf("Name(%q): got: %q, want: %q", id, gamepaddb.Name(id), "Watched Gamepad 4")
+	}
+	if err := gamepaddb.WatchError(); err != nil {
+		t.Errorf("WatchError: got: %v, want: nil", err)
+	}
+}
+
+func TestTouchpadButton(t *testing.T) {
+	const (
+		idTouchpad   = "ebitengine0000000000000000000030"
+		idNoTouchpad = "ebitengine0000000000000000000031"
+	)
+	mappings := idTouchpad + ",Touchpad Gamepad,a:b0,b:b1,touchpad:b2,\n" +
+		idNoTouchpad + ",Gamepad,a:b0,b:b1,\n"
+	if err := gamepaddb.Update([]byte(mappings)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !gamepaddb.HasStandardButton(idTouchpad, gamepaddb.StandardButtonTouchpad) {
+		t.Errorf("HasStandardButton(%q, StandardButtonTouchpad) must return true", idTouchpad)
+	}
+	if gamepaddb.HasStandardButton(idNoTouchpad, gamepaddb.StandardButtonTouchpad) {
+		t.Errorf("HasStandardButton(%q, StandardButtonTouchpad) must return false", idNoTouchpad)
+	}
+
+	for _, pressed := range []bool{false, true} {
+		state := &testGamepadState{
+			buttons: []bool{false, false, pressed},
+		}
+		if got := gamepaddb.IsStandardButtonPressed(idTouchpad, gamepaddb.StandardButtonTouchpad, state); got != pressed {
+			t.Errorf("IsStandardButtonPressed(%q, StandardButtonTouchpad): got: %t, want: %t", idTouchpad, got, pressed)
+		}
+		if gamepaddb.IsStandardButtonPressed(idNoTouchpad, gamepaddb.StandardButtonTouchpad, state) {
+			t.Errorf("IsStandardButtonPressed(%q, StandardButtonTouchpad) must return false", idNoTouchpad)
+		}
+	}
+}
+
+func TestMappingCompleteness(t *testing.T) {
+	const (
+		fullID    = "ebitengine0000000000000000000040"
+		partialID = "ebitengine0000000000000000000041"
+		unknownID = "ebitengine0000000000000000000042"
+	)
+
+	mappings := fullID + ",Full Gamepad,a:b0,b:b1,x:b2,y:b3,leftshoulder:b4,rightshoulder:b5,lefttrigger:b6,righttrigger:b7,back:b8,start:b9,leftstick:b10,rightstick:b11,dpup:h0.1,dpright:h0.2,dpdown:h0.4,dpleft:h0.8,guide:b12,touchpad:b13,leftx:a0,lefty:a1,rightx:a2,righty:a3,\n" +
+		partialID + ",Partial Gamepad,a:b0,b:b1,touchpad:b2,leftx:a0,lefty:a1,\n"
+	if err := gamepaddb.Update([]byte(mappings)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		id   string
+		want float64
+	}{
+		{id: fullID, want: 1},
+		{id: partialID, want: 4.0 / 21.0},
+		{id: unknownID, want: 0},
+	} {
+		if got := gamepaddb.MappingCompleteness(tc.id); got != tc.want {
+			t.Errorf("MappingCompleteness(%q): got: %v, want: %v", tc.id, got, tc.want)
+		}
+	}
+
+	for _, info := range gamepaddb.ListMappings(gamepaddb.CurrentPlatformName()) {
+		if info.ID != partialID {
+			continue
+		}
+		if got, want := info.Completeness, 4.0/21.0; got != want {
+			t.Errorf("ListMappings: Completeness for %q: got: %v, want: %v", info.ID, got, want)
+		}
+	}
+}
+
+func TestVendorAndProductID(t *testing.T) {
+	for _, tc := range []struct {
+		id      string
+		vendor  uint16
+		product uint16
+		ok      bool
+	}{
+		// Xbox 360 Controller
+		{"030000005e0400008e02000010010000", 0x045e, 0x028e, true},
+		// Xbox One Controller
+		{"030000005e040000ea02000000000000", 0x045e, 0x02ea, true},
+		// DualShock 4
+		{"030000004c050000c405000000010000", 0x054c, 0x05c4, true},
+		// DualSense (Bluetooth) with a CRC
+		{"05009b514c050000e60c000000810000", 0x054c, 0x0ce6, true},
+		// XInput
+		{"78696e70757401000000000000000000", 0, 0, false},
+		// An ID with a device name
+		{"05000000576972656c65737320436f6e", 0, 0, false},
+		// An ID with zero IDs
+		{"03000000000000000000000000000000", 0, 0, false},
+		// Invalid IDs
+		{"", 0, 0, false},
+		{"030000005e0400008e02", 0, 0, false},
+		{"03000000zz0400008e02000010010000", 0, 0, false},
+	} {
+		vendor, ok := gamepaddb.VendorID(tc.id)
+		if vendor != tc.vendor || ok != tc.ok {
+			t.Errorf("VendorID(%q): got: (0x%04x, %t), want: (0x%04x, %t)", tc.id, vendor, ok, tc.vendor, tc.ok)
+		}
+		product, ok := gamepaddb.ProductID(tc.id)
+		if product != tc.product || ok != tc.ok {
+			t.Errorf("ProductID(%q): got: (0x%04x, %t), want: (0x%04x, %t)", tc.id, product, ok, tc.product, tc.ok)
+		}
+	}
+}
+
+func TestNameFallback(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
+		t.Skipf("the name fallback table doesn't have an Xbox 360 entry for %s", runtime.GOOS)
+	}
+
+	// An SDL ID which is not in the database.
+	const id = "03000000ebe10000ffff000000000000"
+	const name = "Microsoft Xbox 360 Wireless Receiver (XBOX)"
+
+	if got := gamepaddb.MappingID(id, name); got != id {
+		t.Errorf("MappingID without the name fallback: got: %q, want: %q", got, id)
+	}
+	if gamepaddb.HasStandardLayoutMapping(id) {
+		t.Errorf("HasStandardLayoutMapping(%q) must be false", id)
+	}
+
+	gamepaddb.EnableNameFallback(true)
+	defer gamepaddb.EnableNameFallback(false)
+
+	for _, name := range []string{"Xbox 360 Controller", name} {
+		mid := gamepaddb.MappingID(id, name)
+		if mid == id {
+			t.Errorf("MappingID(%q, %q) must not return the original ID", id, name)
+			continue
+		}
+		if !gamepaddb.HasStandardLayoutMapping(mid) {
+			t.Errorf("HasStandardLayoutMapping(MappingID(%q, %q)) must be true", id, name)
+		}
+		for _, b := range []gamepaddb.StandardButton{
+			gamepaddb.StandardButtonRightBottom,
+			gamepaddb.StandardButtonRightRight,
+			gamepaddb.StandardButtonCenterRight,
+		} {
+			if !gamepaddb.HasStandardButton(mid, b) {
+				t.Errorf("HasStandardButton(MappingID(%q, %q), %d) must be true", id, name, b)
+			}
+		}
+		if !gamepaddb.HasStandardAxis(mid, gamepaddb.StandardAxisLeftStickHorizontal) {
+			t.Errorf("HasStandardAxis(MappingID(%q, %q), StandardAxisLeftStickHorizontal) must be true", id, name)
+		}
+	}
+
+	// The pattern must match whole words.
+	if got := gamepaddb.MappingID(id, "Xbox 3600 Controller"); got != id {
+		t.Errorf("got: %q, want: %q", got, id)
+	}
+	// A known SDL ID is never replaced with the fallback.
+	const knownID = "03000000ebe10000fffe000000000000"
+	if err := gamepaddb.Update([]byte(knownID + ",Known Gamepad,a:b0,\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := gamepaddb.MappingID(knownID, "Xbox 360 Controller"); got != knownID {
+		t.Errorf("got: %q, want: %q", got, knownID)
+	}
+}
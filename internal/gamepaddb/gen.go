@@ -19,6 +19,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	_ "embed"
 	"fmt"
 	"log"
@@ -63,8 +64,8 @@ import (
 	_ "embed"
 )
 
-//go:embed gamecontrollerdb_{{.FileNameSuffix}}.txt
-var controllerBytes []byte
+//go:embed gamecontrollerdb_{{.FileNameSuffix}}.txt.gz
+var compressedControllerBytesForPlatform []byte
 
 {{if .HasGLFWGamepads}}
 var additionalGLFWGamepads = []byte(` + "`" + `
@@ -79,12 +80,7 @@ var additionalGLFWGamepads = []byte(` + "`" + `
 {{end}}
 
 func init() {
-	if err := Update(controllerBytes); err != nil {
-		panic(err)
-	}{{if .HasGLFWGamepads}}
-	if err := Update(additionalGLFWGamepads); err != nil {
-		panic(err)
-	}{{end}}
+	registerControllerBytes(compressedControllerBytesForPlatform, {{if .HasGLFWGamepads}}additionalGLFWGamepads{{else}}nil{{end}})
 }
 `
 
@@ -138,11 +134,21 @@ func run() error {
 			return fmt.Errorf("failed to find controller db for platform %s in gamecontrollerdb.txt", sdlPlatformName)
 		}
 
-		// Write each chunk into separate text file for embedding into respective generated files.
+		// Write each chunk into separate text file.
+		// The text file is not embedded, but is kept to make the changes reviewable.
 		if err = os.WriteFile(fmt.Sprintf("gamecontrollerdb_%s.txt", platform.filenameSuffix), []byte(controllerDB), 0666); err != nil {
 			return err
 		}
 
+		// Write the gzip-compressed chunk for embedding into respective generated files.
+		compressed, err := compress([]byte(controllerDB))
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(fmt.Sprintf("gamecontrollerdb_%s.txt.gz", platform.filenameSuffix), compressed, 0666); err != nil {
+			return err
+		}
+
 		path := fmt.Sprintf("db_%s.go", platform.filenameSuffix)
 		tmpl, err := template.New(path).Parse(dbTemplate)
 		if err != nil {
@@ -179,6 +185,21 @@ func run() error {
 	return nil
 }
 
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func splitDBsByPlatform(controllerDB []byte) (map[string]string, error) {
 	s := bufio.NewScanner(bytes.NewReader(controllerDB))
 	dbs := map[string]string{}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"strconv"
+)
+
+// vendorAndProductIDs returns the USB vendor ID and the product ID encoded in the SDL ID.
+//
+// The standard SDL ID layout is 16 bytes in hex: a bus type (2 bytes), a CRC (2 bytes),
+// a vendor ID (2 bytes), zero padding (2 bytes), a product ID (2 bytes), zero padding (2 bytes), a version (2 bytes),
+// a driver signature and driver data (2 bytes). Each value is in little endian.
+// Older layouts, like XInput's or ones with a device name, don't have the zero padding, and the IDs are not available.
+func vendorAndProductIDs(id string) (vendor, product uint16, ok bool) {
+	if len(id) != sdlIDLength {
+		return 0, 0, false
+	}
+	// The SDL ID without a vendor and a product doesn't have zero padding after them.
+	if id[12:16] != "0000" || id[20:24] != "0000" {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(id[10:12]+id[8:10], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	p, err := strconv.ParseUint(id[18:20]+id[16:18], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	if v == 0 && p == 0 {
+		return 0, 0, false
+	}
+	return uint16(v), uint16(p), true
+}
+
+// VendorID returns the USB vendor ID encoded in the SDL ID (GUID).
+// VendorID returns false if the SDL ID doesn't have a vendor ID.
+func VendorID(guid string) (uint16, bool) {
+	v, _, ok := vendorAndProductIDs(guid)
+	return v, ok
+}
+
+// ProductID returns the USB product ID encoded in the SDL ID (GUID).
+// ProductID returns false if the SDL ID doesn't have a product ID.
+func ProductID(guid string) (uint16, bool) {
+	_, p, ok := vendorAndProductIDs(guid)
+	return p, ok
+}
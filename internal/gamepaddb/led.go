@@ -0,0 +1,62 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"fmt"
+)
+
+// ledDevices is a set of gamepads that have an RGB LED light bar.
+// A key is the USB vendor ID and the product ID in the format of "vvvv:pppp".
+var ledDevices = map[string]struct{}{
+	"054c:05c4": {}, // DualShock 4 (CUH-ZCT1)
+	"054c:09cc": {}, // DualShock 4 (CUH-ZCT2)
+	"054c:0ba0": {}, // DualShock 4 USB Wireless Adapter
+	"054c:0ce6": {}, // DualSense
+	"054c:0df2": {}, // DualSense Edge
+}
+
+// vendorAndProductFromSDLID returns the USB vendor ID and the product ID in the format of "vvvv:pppp" from the SDL ID.
+// The IDs are stored in little endian in the SDL ID.
+func vendorAndProductFromSDLID(id string) (string, bool) {
+	v, p, ok := vendorAndProductIDs(id)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%04x:%04x", v, p), true
+}
+
+// CurrentPlatformName returns the platform name of the current platform used in SDL_GameControllerDB.
+// CurrentPlatformName returns an empty string if the current platform is unknown.
+func CurrentPlatformName() string {
+	return currentPlatform().sdlName()
+}
+
+// SupportsLED reports whether the gamepad with the given SDL ID (GUID) is known to have an RGB LED light bar.
+// The gamepad is looked up by the USB vendor and product IDs in guid.
+//
+// platform must be CurrentPlatformName(), as the light bar is controlled via the running platform's driver.
+// For other platforms, SupportsLED returns false.
+func SupportsLED(guid string, platform string) bool {
+	if platform == "" || platform != currentPlatform().sdlName() {
+		return false
+	}
+	vp, ok := vendorAndProductFromSDLID(guid)
+	if !ok {
+		return false
+	}
+	_, ok = ledDevices[vp]
+	return ok
+}
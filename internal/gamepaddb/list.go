@@ -0,0 +1,140 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"slices"
+)
+
+// MappingInfo represents a summary of a gamepad mapping.
+type MappingInfo struct {
+	// ID is the SDL ID (GUID) of the gamepad.
+	ID string
+
+	// Name is the device name of the gamepad.
+	Name string
+
+	// Buttons is the mapped standard buttons in ascending order.
+	Buttons []StandardButton
+
+	// Axes is the mapped standard axes in ascending order.
+	Axes []StandardAxis
+
+	// Completeness is the fraction of the standard layout elements that are mapped.
+	// See MappingCompleteness.
+	Completeness float64
+}
+
+// mappingInfos is a cache of the result of ListMappings.
+// mappingInfos is reset when the mappings are updated.
+var mappingInfos []MappingInfo
+
+func (p platform) sdlName() string {
+	switch p {
+	case platformWindows:
+		return "Windows"
+	case platformMacOS:
+		return "Mac OS X"
+	case platformUnix:
+		return "Linux"
+	case platformAndroid:
+		return "Android"
+	case platformIOS:
+		return "iOS"
+	}
+	return ""
+}
+
+// ListMappings returns all the known gamepad mappings for the given platform, sorted by the IDs.
+//
+// platform must be CurrentPlatformName(), as only the mappings for the running platform are loaded.
+// For other platforms, ListMappings returns nil.
+//
+// The returned slice is shared among calls until the mappings are updated, and must not be modified.
+func ListMappings(platform string) []MappingInfo {
+	if platform == "" || platform != currentPlatform().sdlName() {
+		return nil
+	}
+
+	ensureLoaded()
+
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	if mappingInfos != nil {
+		return mappingInfos
+	}
+
+	infos := make([]MappingInfo, 0, len(gamepadNames))
+	for id, name := range gamepadNames {
+		info := MappingInfo{
+			ID:   id,
+			Name: name,
+		}
+		for b := range gamepadButtonMappings[id] {
+			info.Buttons = append(info.Buttons, b)
+		}
+		slices.Sort(info.Buttons)
+		for a := range gamepadAxisMappings[id] {
+			info.Axes = append(info.Axes, a)
+		}
+		slices.Sort(info.Axes)
+		info.Completeness = completeness(gamepadButtonMappings[id], gamepadAxisMappings[id])
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b MappingInfo) int {
+		if a.ID < b.ID {
+			return -1
+		}
+		if a.ID > b.ID {
+			return 1
+		}
+		return 0
+	})
+	mappingInfos = infos
+	return infos
+}
+
+// MappingCompleteness returns the fraction of the standard layout elements mapped for the given SDL ID (GUID)
+// in the loaded mappings, in [0, 1].
+//
+// The standard layout elements are the 17 buttons and the 4 axes defined in the web standard.
+// StandardButtonTouchpad is an extension and is not counted.
+//
+// MappingCompleteness returns 0 if there is no mapping for guid.
+func MappingCompleteness(guid string) float64 {
+	ensureLoaded()
+
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	return completeness(gamepadButtonMappings[guid], gamepadAxisMappings[guid])
+}
+
+func completeness(buttons map[StandardButton]mapping, axes map[StandardAxis]mapping) float64 {
+	const (
+		buttonCount = int(StandardButtonCenterCenter) + 1
+		axisCount   = int(StandardAxisMax) + 1
+	)
+
+	var n int
+	for b := range buttons {
+		if b <= StandardButtonCenterCenter {
+			n++
+		}
+	}
+	n += len(axes)
+	return float64(n) / float64(buttonCount+axisCount)
+}
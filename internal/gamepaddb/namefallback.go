@@ -0,0 +1,140 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+//go:embed namefallback.txt
+var nameFallbackBytes []byte
+
+// nameFallbackIDPrefix is the prefix of the pseudo IDs for the name-based fallback mappings.
+// A pseudo ID never conflicts with an SDL ID, which consists of hexadecimal digits.
+const nameFallbackIDPrefix = "namefallback:"
+
+type nameFallback struct {
+	pattern string
+	id      string
+}
+
+var (
+	nameFallbackEnabled atomic.Bool
+
+	// nameFallbacks is the name-based fallback mappings in the priority order.
+	nameFallbacks []nameFallback
+
+	nameFallbackLoadOnce sync.Once
+)
+
+// EnableNameFallback enables or disables the name-based fallback mappings.
+//
+// When the fallback is enabled and there is no mapping for an SDL ID, MappingID tries to find a mapping by the device name
+// from a small curated table. This is useful for the platforms reporting unreliable SDL IDs with consistent device names.
+// As the matching is heuristic, the fallback is disabled by default.
+func EnableNameFallback(enabled bool) {
+	if nameFallbackEnabled.Swap(enabled) != enabled {
+		mappingsGeneration.Add(1)
+	}
+}
+
+// IsNameFallbackEnabled reports whether the name-based fallback mappings are enabled.
+func IsNameFallbackEnabled() bool {
+	return nameFallbackEnabled.Load()
+}
+
+// ensureNameFallbacksLoaded parses and registers the name-based fallback mappings if they are not registered yet.
+// ensureNameFallbacksLoaded must not be called with mappingsM locked.
+func ensureNameFallbacksLoaded() {
+	nameFallbackLoadOnce.Do(func() {
+		if err := loadNameFallbacks(nameFallbackBytes); err != nil {
+			panic(fmt.Sprintf("gamepaddb: parsing the name fallback mappings failed: %v", err))
+		}
+	})
+}
+
+func loadNameFallbacks(data []byte) error {
+	mappingsM.Lock()
+	defer mappingsM.Unlock()
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		pattern, _, buttons, axes, err := parseLine(s.Text(), currentPlatform())
+		if err != nil {
+			return err
+		}
+		if pattern == "" {
+			continue
+		}
+		// The pseudo IDs are not registered in gamepadNames so that they are not listed as regular mappings.
+		id := nameFallbackIDPrefix + pattern
+		gamepadButtonMappings[id] = buttons
+		gamepadAxisMappings[id] = axes
+		nameFallbacks = append(nameFallbacks, nameFallback{
+			pattern: normalizeDeviceName(pattern),
+			id:      id,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	mappingsGeneration.Add(1)
+	return nil
+}
+
+// normalizeDeviceName lowers the case of the name and replaces each sequence of non-alphanumeric characters with one space.
+func normalizeDeviceName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// nameFallbackID returns the pseudo ID of the name-based fallback mapping for the device name.
+// nameFallbackID returns an empty string if the fallback is disabled or no pattern matches.
+func nameFallbackID(name string) string {
+	if !nameFallbackEnabled.Load() {
+		return ""
+	}
+	n := normalizeDeviceName(name)
+	if n == "" {
+		return ""
+	}
+	// Pad the name with spaces so that a pattern matches only whole words.
+	n = " " + n + " "
+	for _, f := range nameFallbacks {
+		if strings.Contains(n, " "+f.pattern+" ") {
+			return f.id
+		}
+	}
+	return ""
+}
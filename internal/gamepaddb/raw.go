@@ -0,0 +1,64 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+// StandardButtonFromRaw returns the standard button mapped to the raw button rawIndex of the gamepad with the given SDL ID (GUID).
+// This is the inverse of the mapping, e.g., "a:b2" resolves the raw button 2 to StandardButtonRightBottom.
+//
+// StandardButtonFromRaw returns false if no standard button is mapped to the raw button.
+// platform must be CurrentPlatformName(), as only the mappings for the running platform are loaded.
+//
+// To resolve a direction of a hat, use StandardButtonFromRawHat.
+func StandardButtonFromRaw(guid string, platform string, rawIndex int) (StandardButton, bool) {
+	return standardButtonFromRaw(guid, platform, func(m mapping) bool {
+		return m.Type == mappingTypeButton && m.Index == rawIndex
+	})
+}
+
+// StandardButtonFromRawHat returns the standard button mapped to the direction hatState of the raw hat hat,
+// like "h0.1" in SDL_GameControllerDB.
+// hatState must be one of HatUp, HatRight, HatDown, and HatLeft.
+//
+// StandardButtonFromRawHat returns false if no standard button is mapped to the hat direction,
+// or platform is not CurrentPlatformName().
+func StandardButtonFromRawHat(guid string, platform string, hat int, hatState int) (StandardButton, bool) {
+	return standardButtonFromRaw(guid, platform, func(m mapping) bool {
+		return m.Type == mappingTypeHat && m.Index == hat && m.HatState == hatState
+	})
+}
+
+func standardButtonFromRaw(guid string, platform string, match func(m mapping) bool) (StandardButton, bool) {
+	if platform == "" || platform != currentPlatform().sdlName() {
+		return 0, false
+	}
+
+	ensureLoaded()
+
+	mappingsM.RLock()
+	defer mappingsM.RUnlock()
+
+	// Iterate the buttons in order so that the result is deterministic even when multiple buttons share the same raw button.
+	mappings := buttonMappings(guid)
+	for b := StandardButton(0); b <= StandardButtonMax; b++ {
+		m, ok := mappings[b]
+		if !ok {
+			continue
+		}
+		if match(m) {
+			return b, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+// triggerEffectDevices is a set of gamepads that have adaptive triggers with force feedback.
+// A key is the USB vendor ID and the product ID in the format of "vvvv:pppp".
+var triggerEffectDevices = map[string]struct{}{
+	"054c:0ce6": {}, // DualSense
+	"054c:0df2": {}, // DualSense Edge
+}
+
+// SupportsTriggerEffects reports whether the gamepad with the given SDL ID (GUID) is known to have adaptive triggers
+// with force feedback, like DualSense.
+//
+// platform must be CurrentPlatformName(), as trigger effects are sent as output reports on the running platform.
+// For other platforms, SupportsTriggerEffects returns false.
+func SupportsTriggerEffects(guid string, platform string) bool {
+	if platform == "" || platform != currentPlatform().sdlName() {
+		return false
+	}
+	vp, ok := vendorAndProductFromSDLID(guid)
+	if !ok {
+		return false
+	}
+	_, ok = triggerEffectDevices[vp]
+	return ok
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gamepaddb
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// watchInterval is the interval to check the modification time of the watched file.
+var watchInterval = 500 * time.Millisecond
+
+var (
+	watchStop chan struct{}
+	watchErr  error
+	watchM    sync.Mutex
+)
+
+// WatchFile loads gamepad mappings from the file at path, and reloads them whenever the file is modified.
+// The file must be in the format of SDL_GameControllerDB.
+// The loaded mappings replace the existing mappings with the same IDs, like Update.
+//
+// The file's modification time and size are polled. Rapid changes are coalesced:
+// the file is reloaded after its modification time and size stay unchanged for one polling interval.
+//
+// If the initial load fails, WatchFile returns the error and doesn't watch the file.
+// An error on reloading doesn't stop watching, and is reported by WatchError.
+//
+// Only one file can be watched at a time. WatchFile stops watching the previous file.
+//
+// WatchFile is intended for development.
+func WatchFile(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := Update(bs); err != nil {
+		return err
+	}
+
+	watchM.Lock()
+	defer watchM.Unlock()
+
+	if watchStop != nil {
+		close(watchStop)
+	}
+	watchStop = make(chan struct{})
+	watchErr = nil
+
+	go watchFile(path, fi, watchStop, watchInterval)
+	return nil
+}
+
+// StopWatchingFile stops watching the file specified by WatchFile.
+func StopWatchingFile() {
+	watchM.Lock()
+	defer watchM.Unlock()
+
+	if watchStop != nil {
+		close(watchStop)
+		watchStop = nil
+	}
+	watchErr = nil
+}
+
+// WatchError returns the last error on reloading the file specified by WatchFile.
+// WatchError returns nil if the last reload succeeded.
+func WatchError() error {
+	watchM.Lock()
+	defer watchM.Unlock()
+	return watchErr
+}
+
+func setWatchError(stop chan struct{}, err error) {
+	watchM.Lock()
+	defer watchM.Unlock()
+
+	// Ignore the result of an old watcher.
+	if watchStop != stop {
+		return
+	}
+	watchErr = err
+}
+
+func watchFile(path string, loaded os.FileInfo, stop chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	isSame := func(a, b os.FileInfo) bool {
+		return a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+	}
+
+	var pending os.FileInfo
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			setWatchError(stop, err)
+			continue
+		}
+		if isSame(fi, loaded) {
+			pending = nil
+			continue
+		}
+		// Wait until the file becomes stable.
+		if pending == nil || !isSame(fi, pending) {
+			pending = fi
+			continue
+		}
+
+		loaded = fi
+		pending = nil
+
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			setWatchError(stop, err)
+			continue
+		}
+		if err := Update(bs); err != nil {
+			setWatchError(stop, err)
+			continue
+		}
+		setWatchError(stop, nil)
+	}
+}
@@ -15,3 +15,7 @@
 package graphics
 
 var AdjustDestinationPixelForTesting = adjustDestinationPixel
+
+func ResetNPOTImagesAvailabilityForTesting() {
+	npotImagesAvailability.Store(npotImagesUndecided)
+}
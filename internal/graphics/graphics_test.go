@@ -40,6 +40,46 @@ func TestInternalImageSize(t *testing.T) {
 	}
 }
 
+func TestInternalImageSizeNPOT(t *testing.T) {
+	graphics.SetNPOTImagesAvailableForTesting(true)
+	defer graphics.SetNPOTImagesAvailableForTesting(false)
+
+	testCases := []struct {
+		expected int
+		arg      int
+	}{
+		{16, 1},
+		{16, 16},
+		{255, 255},
+		{256, 256},
+		{257, 257},
+		{1080, 1080},
+	}
+
+	for _, testCase := range testCases {
+		got := graphics.InternalImageSize(testCase.arg)
+		wanted := testCase.expected
+		if wanted != got {
+			t.Errorf("InternalImageSize(%d) = %d, wanted %d", testCase.arg, got, wanted)
+		}
+	}
+}
+
+func TestSetNPOTImagesAvailableAfterInternalImageSize(t *testing.T) {
+	graphics.ResetNPOTImagesAvailabilityForTesting()
+	defer graphics.SetNPOTImagesAvailableForTesting(false)
+
+	// Calculating a size fixes the availability, as an image with this size might already be allocated.
+	_ = graphics.InternalImageSize(255)
+
+	if graphics.SetNPOTImagesAvailable(true) {
+		t.Errorf("SetNPOTImagesAvailable(true) must fail after InternalImageSize is called")
+	}
+	if got, want := graphics.InternalImageSize(255), 256; got != want {
+		t.Errorf("InternalImageSize(255) = %d, wanted %d", got, want)
+	}
+}
+
 func TestAdjustPixel(t *testing.T) {
 	tests := []struct {
 		X     float32
@@ -79,3 +119,34 @@ func BenchmarkAdjustPixel(b *testing.B) {
 		graphics.AdjustDestinationPixelForTesting(float32(i) / 17)
 	}
 }
+
+func TestPremultiplyAlpha(t *testing.T) {
+	src := []byte{
+		0xff, 0x80, 0x00, 0xff,
+		0xff, 0x80, 0x00, 0x80,
+		0xff, 0xff, 0xff, 0x01,
+		0x12, 0x34, 0x56, 0x00,
+	}
+	want := []byte{
+		0xff, 0x80, 0x00, 0xff,
+		0x80, 0x40, 0x00, 0x80,
+		0x01, 0x01, 0x01, 0x01,
+		0x00, 0x00, 0x00, 0x00,
+	}
+
+	dst := make([]byte, len(src))
+	graphics.PremultiplyAlpha(dst, src)
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Errorf("dst[%d]: got: %#02x, want: %#02x", i, dst[i], want[i])
+		}
+	}
+
+	// In-place conversion.
+	graphics.PremultiplyAlpha(src, src)
+	for i := range want {
+		if src[i] != want[i] {
+			t.Errorf("src[%d]: got: %#02x, want: %#02x", i, src[i], want[i])
+		}
+	}
+}
@@ -14,7 +14,57 @@
 
 package graphics
 
+import (
+	"sync/atomic"
+)
+
+const (
+	npotImagesUndecided int32 = iota
+	npotImagesUnavailable
+	npotImagesAvailable
+)
+
+var npotImagesAvailability atomic.Int32
+
+// SetNPOTImagesAvailable sets whether the graphics driver can create internal images whose sizes are not powers of 2.
+//
+// The availability is fixed by the first call of SetNPOTImagesAvailable or InternalImageSize.
+// After that, SetNPOTImagesAvailable does nothing, so that the internal size of an image never changes
+// between the caller's calculation and the driver's allocation.
+// SetNPOTImagesAvailable reports whether the availability is set to the given value.
+func SetNPOTImagesAvailable(available bool) bool {
+	v := npotImagesUnavailable
+	if available {
+		v = npotImagesAvailable
+	}
+	npotImagesAvailability.CompareAndSwap(npotImagesUndecided, v)
+	return npotImagesAvailability.Load() == v
+}
+
+// SetNPOTImagesAvailableForTesting sets whether NPOT images are available regardless of the current availability.
+//
+// The internal sizes of the existing images might be inconsistent after this call.
+func SetNPOTImagesAvailableForTesting(available bool) {
+	v := npotImagesUnavailable
+	if available {
+		v = npotImagesAvailable
+	}
+	npotImagesAvailability.Store(v)
+}
+
+func areNPOTImagesAvailable() bool {
+	v := npotImagesAvailability.Load()
+	if v == npotImagesUndecided {
+		// Fix the availability, as the returned size must not change later.
+		npotImagesAvailability.CompareAndSwap(npotImagesUndecided, npotImagesUnavailable)
+		v = npotImagesAvailability.Load()
+	}
+	return v == npotImagesAvailable
+}
+
 // InternalImageSize returns a nearest appropriate size as an internal image.
+//
+// If NPOT images are not available, InternalImageSize returns a power of 2.
 func InternalImageSize(x int) int {
 	// minInternalImageSize is the minimum size of internal images (texture/framebuffer).
 	//
@@ -28,6 +78,9 @@ func InternalImageSize(x int) int {
 	if x < minInternalImageSize {
 		return minInternalImageSize
 	}
+	if areNPOTImagesAvailable() {
+		return x
+	}
 	r := 1
 	for r < x {
 		r <<= 1
@@ -0,0 +1,44 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphics
+
+// PremultiplyAlpha converts straight-alpha RGBA pixels in src to premultiplied-alpha RGBA pixels and writes them to dst.
+//
+// Each color component is rounded and never exceeds its alpha value.
+// The color components of a fully transparent pixel are always zero.
+//
+// dst and src can be the same slice. len(dst) must be equal to or greater than len(src).
+func PremultiplyAlpha(dst, src []byte) {
+	for i := 0; i+3 < len(src); i += 4 {
+		a := uint32(src[i+3])
+		switch a {
+		case 0:
+			dst[i] = 0
+			dst[i+1] = 0
+			dst[i+2] = 0
+			dst[i+3] = 0
+		case 0xff:
+			dst[i] = src[i]
+			dst[i+1] = src[i+1]
+			dst[i+2] = src[i+2]
+			dst[i+3] = 0xff
+		default:
+			dst[i] = byte((uint32(src[i])*a + 0x7f) / 0xff)
+			dst[i+1] = byte((uint32(src[i+1])*a + 0x7f) / 0xff)
+			dst[i+2] = byte((uint32(src[i+2])*a + 0x7f) / 0xff)
+			dst[i+3] = byte(a)
+		}
+	}
+}
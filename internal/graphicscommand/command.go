@@ -416,6 +416,14 @@ func (c *newShaderCommand) NeedsSync() bool {
 func InitializeGraphicsDriverState(graphicsDriver graphicsdriver.Graphics) (err error) {
 	runOnRenderThread(func() {
 		err = graphicsDriver.Initialize()
+		if err != nil {
+			return
+		}
+		if s, ok := graphicsDriver.(graphicsdriver.NPOTImageSupporter); ok {
+			// If an internal image size is already calculated, e.g. an image is created before the initialization,
+			// the availability is not changed and all the images keep power-of-2 sizes.
+			graphics.SetNPOTImagesAvailable(s.SupportsNPOTImages())
+		}
 	}, true)
 	return
 }
@@ -67,6 +67,37 @@ func FlushCommands(graphicsDriver graphicsdriver.Graphics, endFrame bool) error
 	return nil
 }
 
+// Flush sends the queued commands to the graphics driver without waiting for the GPU.
+// Unlike FlushCommands at the end of a frame, Flush never presents the screen.
+//
+// Flush must be called from the goroutine enqueuing the commands.
+// Flushing in the middle of a frame splits the command batches and might degrade performance.
+func Flush(graphicsDriver graphicsdriver.Graphics) error {
+	return FlushCommands(graphicsDriver, false)
+}
+
+// Finish sends the queued commands to the graphics driver, and blocks until the GPU finishes executing them.
+// If the graphics driver doesn't implement graphicsdriver.Finisher, Finish waits only until the commands are sent.
+//
+// Finish must be called from the goroutine enqueuing the commands.
+// Finish stalls both the CPU and the GPU pipelines. Use this only for debugging or measuring.
+func Finish(graphicsDriver graphicsdriver.Graphics) error {
+	if err := FlushCommands(graphicsDriver, false); err != nil {
+		return err
+	}
+
+	// The render thread executes the tasks in order, so the flushed commands are already executed on the driver here.
+	var err error
+	runOnRenderThread(func() {
+		f, ok := graphicsDriver.(graphicsdriver.Finisher)
+		if !ok {
+			return
+		}
+		err = f.Finish()
+	}, true)
+	return err
+}
+
 // commandQueue is a command queue for drawing commands.
 type commandQueue struct {
 	// commands is a queue of drawing commands.
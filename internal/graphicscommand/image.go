@@ -68,6 +68,15 @@ func NewImage(width, height int, screenFramebuffer bool, attribute string) *Imag
 		id:        genNextImageID(),
 		attribute: attribute,
 	}
+	if screenFramebuffer {
+		i.internalWidth = width
+		i.internalHeight = height
+	} else {
+		// Calculate the internal size here, not lazily, so that the size is the same as the size the driver allocates.
+		// See also graphics.SetNPOTImagesAvailable.
+		i.internalWidth = graphics.InternalImageSize(width)
+		i.internalHeight = graphics.InternalImageSize(height)
+	}
 	c := &newImageCommand{
 		result:    i,
 		width:     width,
@@ -102,15 +111,6 @@ func (i *Image) Dispose() {
 }
 
 func (i *Image) InternalSize() (int, int) {
-	if i.screen {
-		return i.width, i.height
-	}
-	if i.internalWidth == 0 {
-		i.internalWidth = graphics.InternalImageSize(i.width)
-	}
-	if i.internalHeight == 0 {
-		i.internalHeight = graphics.InternalImageSize(i.height)
-	}
 	return i.internalWidth, i.internalHeight
 }
 
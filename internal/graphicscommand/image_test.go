@@ -187,3 +187,130 @@ func TestSuccessiveWritePixels(t *testing.T) {
 		t.Errorf("len(dst.BufferedWritePixelsArgsForTesting()): got %d, want: %d", got, want)
 	}
 }
+
+// recordingGraphics is a graphics driver recording the calls of DrawTriangles and Finish.
+type recordingGraphics struct {
+	graphicsdriver.Graphics
+
+	drawCount         int
+	drawCountAtFinish int
+	finished          bool
+}
+
+func (g *recordingGraphics) DrawTriangles(dst graphicsdriver.ImageID, srcs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shader graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+	g.drawCount++
+	return g.Graphics.DrawTriangles(dst, srcs, shader, dstRegions, indexOffset, blend, uniforms, fillRule)
+}
+
+func (g *recordingGraphics) Finish() error {
+	g.finished = true
+	g.drawCountAtFinish = g.drawCount
+	if f, ok := g.Graphics.(graphicsdriver.Finisher); ok {
+		return f.Finish()
+	}
+	return nil
+}
+
+func TestFinish(t *testing.T) {
+	const w, h = 16, 16
+	src := graphicscommand.NewImage(w, h, false, "")
+	// Use different destinations so that the draw commands are not merged.
+	dsts := []*graphicscommand.Image{
+		graphicscommand.NewImage(w, h, false, ""),
+		graphicscommand.NewImage(w, h, false, ""),
+		graphicscommand.NewImage(w, h, false, ""),
+	}
+
+	vs := quadVertices(w, h)
+	is := graphics.QuadIndices()
+	dr := image.Rect(0, 0, w, h)
+	sr := image.Rect(0, 0, w, h)
+	for _, dst := range dsts {
+		dst.DrawTriangles([graphics.ShaderSrcImageCount]*graphicscommand.Image{src}, vs, is, graphicsdriver.BlendSourceOver, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, nearestFilterShader, nil, graphicsdriver.FillRuleFillAll)
+	}
+
+	g := &recordingGraphics{
+		Graphics: ui.Get().GraphicsDriverForTesting(),
+	}
+	if err := graphicscommand.Finish(g); err != nil {
+		t.Fatal(err)
+	}
+	if !g.finished {
+		t.Fatalf("Finish must call the driver's Finish")
+	}
+	if got, want := g.drawCountAtFinish, 3; got != want {
+		t.Errorf("executed DrawTriangles at Finish: got: %d, want: %d", got, want)
+	}
+}
+
+func TestNPOTAndPaddedImagesRenderSame(t *testing.T) {
+	defer graphics.SetNPOTImagesAvailableForTesting(true)
+
+	ir, err := graphics.CompileShader([]byte(builtinshader.ShaderSource(builtinshader.FilterLinear, builtinshader.AddressClampToZero, false)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shader := graphicscommand.NewShader(ir, "")
+
+	const (
+		sw, sh = 17, 9
+		dw, dh = 23, 13
+	)
+	srcPix := make([]byte, 4*sw*sh)
+	for i := range srcPix {
+		srcPix[i] = byte(i * 37)
+	}
+	// Premultiply alpha.
+	for i := 0; i < len(srcPix); i += 4 {
+		a := srcPix[i+3]
+		srcPix[i] = min(srcPix[i], a)
+		srcPix[i+1] = min(srcPix[i+1], a)
+		srcPix[i+2] = min(srcPix[i+2], a)
+	}
+
+	draw := func(npot bool) []byte {
+		graphics.SetNPOTImagesAvailableForTesting(npot)
+
+		src := graphicscommand.NewImage(sw, sh, false, "")
+		dst := graphicscommand.NewImage(dw, dh, false, "")
+		if w, h := src.InternalSize(); (w == sw && h == 16) != npot {
+			t.Fatalf("npot: %t: the internal size of the source is unexpected: (%d, %d)", npot, w, h)
+		}
+
+		src.WritePixels(graphics.NewManagedBytes(len(srcPix), func(bs []byte) {
+			copy(bs, srcPix)
+		}), image.Rect(0, 0, sw, sh))
+
+		// Scale the source with the linear filter so that the texture coordinates are not on the texel centers.
+		vs := make([]float32, 4*graphics.VertexFloatCount)
+		graphics.QuadVerticesFromDstAndSrc(vs, 0, 0, dw, dh, 0, 0, sw, sh, 1, 1, 1, 1)
+		is := graphics.QuadIndices()
+		dr := image.Rect(0, 0, dw, dh)
+		sr := image.Rect(0, 0, sw, sh)
+		dst.DrawTriangles([graphics.ShaderSrcImageCount]*graphicscommand.Image{src}, vs, is, graphicsdriver.BlendCopy, dr, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, shader, nil, graphicsdriver.FillRuleFillAll)
+
+		pix := make([]byte, 4*dw*dh)
+		if err := dst.ReadPixels(ui.Get().GraphicsDriverForTesting(), []graphicsdriver.PixelsArgs{
+			{
+				Pixels: pix,
+				Region: dr,
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return pix
+	}
+
+	padded := draw(false)
+	unpadded := draw(true)
+	for j := 0; j < dh; j++ {
+		for i := 0; i < dw; i++ {
+			idx := 4 * (i + dw*j)
+			got := color.RGBA{R: unpadded[idx], G: unpadded[idx+1], B: unpadded[idx+2], A: unpadded[idx+3]}
+			want := color.RGBA{R: padded[idx], G: padded[idx+1], B: padded[idx+2], A: padded[idx+3]}
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v (NPOT), want: %v (padded)", i, j, got, want)
+			}
+		}
+	}
+}
@@ -21,8 +21,28 @@ type Blend struct {
 	BlendFactorDestinationAlpha BlendFactor
 	BlendOperationRGB           BlendOperation
 	BlendOperationAlpha         BlendOperation
+
+	// DisabledColorChannels is a set of the color channels not to be written.
+	// The zero value means all the channels are written.
+	DisabledColorChannels ColorChannels
+}
+
+// ColorWriteEnabled reports whether each of the red, green, blue and alpha channels is written.
+func (b Blend) ColorWriteEnabled() (red, green, blue, alpha bool) {
+	c := b.DisabledColorChannels
+	return c&ColorChannelRed == 0, c&ColorChannelGreen == 0, c&ColorChannelBlue == 0, c&ColorChannelAlpha == 0
 }
 
+// ColorChannels is a set of color channels.
+type ColorChannels byte
+
+const (
+	ColorChannelRed ColorChannels = 1 << iota
+	ColorChannelGreen
+	ColorChannelBlue
+	ColorChannelAlpha
+)
+
 // BlendFactor and BlendOperation must be synced with internal/graphicsdriver/playstation5/graphics_playstation5.h.
 
 type BlendFactor byte
@@ -168,4 +188,13 @@ var (
 		BlendOperationRGB:           BlendOperationAdd,
 		BlendOperationAlpha:         BlendOperationAdd,
 	}
+
+	BlendLighterAlpha = Blend{
+		BlendFactorSourceRGB:        BlendFactorSourceAlpha,
+		BlendFactorSourceAlpha:      BlendFactorOne,
+		BlendFactorDestinationRGB:   BlendFactorOne,
+		BlendFactorDestinationAlpha: BlendFactorOne,
+		BlendOperationRGB:           BlendOperationAdd,
+		BlendOperationAlpha:         BlendOperationAdd,
+	}
 )
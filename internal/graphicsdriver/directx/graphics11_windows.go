@@ -137,6 +137,24 @@ func blendOperationToBlendOp11(o graphicsdriver.BlendOperation) _D3D11_BLEND_OP
 	}
 }
 
+func colorWriteMask11(blend graphicsdriver.Blend) uint8 {
+	var mask _D3D11_COLOR_WRITE_ENABLE
+	r, g, b, a := blend.ColorWriteEnabled()
+	if r {
+		mask |= _D3D11_COLOR_WRITE_ENABLE_RED
+	}
+	if g {
+		mask |= _D3D11_COLOR_WRITE_ENABLE_GREEN
+	}
+	if b {
+		mask |= _D3D11_COLOR_WRITE_ENABLE_BLUE
+	}
+	if a {
+		mask |= _D3D11_COLOR_WRITE_ENABLE_ALPHA
+	}
+	return uint8(mask)
+}
+
 type blendStateKey struct {
 	blend     graphicsdriver.Blend
 	writeMask uint8
@@ -660,7 +678,7 @@ func (g *graphics11) genNextShaderID() graphicsdriver.ShaderID {
 func (g *graphics11) blendState(blend graphicsdriver.Blend, stencilMode stencilMode) (*_ID3D11BlendState, error) {
 	var writeMask uint8
 	if stencilMode == noStencil || stencilMode == drawWithStencil {
-		writeMask = uint8(_D3D11_COLOR_WRITE_ENABLE_ALL)
+		writeMask = colorWriteMask11(blend)
 	}
 
 	key := blendStateKey{
@@ -733,6 +733,10 @@ func (g *graphics12) End(present bool) error {
 	return nil
 }
 
+func (g *graphics12) Finish() error {
+	return g.waitForCommandQueue()
+}
+
 func (g *graphics12) presentDesktop() error {
 	return g.graphicsInfra.present(g.vsyncEnabled)
 }
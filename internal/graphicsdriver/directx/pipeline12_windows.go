@@ -138,6 +138,24 @@ func blendOperationToBlendOp12(o graphicsdriver.BlendOperation) _D3D12_BLEND_OP
 	}
 }
 
+func colorWriteMask12(blend graphicsdriver.Blend) uint8 {
+	var mask _D3D12_COLOR_WRITE_ENABLE
+	r, g, b, a := blend.ColorWriteEnabled()
+	if r {
+		mask |= _D3D12_COLOR_WRITE_ENABLE_RED
+	}
+	if g {
+		mask |= _D3D12_COLOR_WRITE_ENABLE_GREEN
+	}
+	if b {
+		mask |= _D3D12_COLOR_WRITE_ENABLE_BLUE
+	}
+	if a {
+		mask |= _D3D12_COLOR_WRITE_ENABLE_ALPHA
+	}
+	return uint8(mask)
+}
+
 type pipelineStates struct {
 	rootSignature *_ID3D12RootSignature
 
@@ -478,7 +496,7 @@ func (p *pipelineStates) newPipelineState(device *_ID3D12Device, vsh, psh *_ID3D
 
 	var writeMask uint8
 	if stencilMode == noStencil || stencilMode == drawWithStencil {
-		writeMask = uint8(_D3D12_COLOR_WRITE_ENABLE_ALL)
+		writeMask = colorWriteMask12(blend)
 	}
 
 	switch stencilMode {
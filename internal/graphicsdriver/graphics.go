@@ -75,6 +75,22 @@ type Resetter interface {
 	Reset() error
 }
 
+// NPOTImageSupporter is an optional interface for a Graphics.
+//
+// If SupportsNPOTImages returns true, internal images are not padded to power-of-2 sizes.
+// SupportsNPOTImages is called after Initialize.
+type NPOTImageSupporter interface {
+	SupportsNPOTImages() bool
+}
+
+// Finisher is an optional interface for a Graphics.
+//
+// Finish blocks until the GPU finishes executing all the commands submitted so far.
+// Finish is called between End and the next Begin.
+type Finisher interface {
+	Finish() error
+}
+
 type Image interface {
 	ID() ImageID
 	Dispose()
@@ -131,6 +131,15 @@ func (g *Graphics) End(present bool) error {
 	return nil
 }
 
+func (g *Graphics) Finish() error {
+	// Command buffers in the same queue are executed in order.
+	// Wait for an empty command buffer so that all the previously committed command buffers are completed.
+	cb := g.cq.CommandBuffer()
+	cb.Commit()
+	cb.WaitUntilCompleted()
+	return nil
+}
+
 func (g *Graphics) SetWindow(window uintptr) {
 	// Note that [NSApp mainWindow] returns nil when the window is borderless.
 	// Then the window is needed to be given explicitly.
@@ -381,6 +390,24 @@ func blendOperationToMetalBlendOperation(o graphicsdriver.BlendOperation) mtl.Bl
 	}
 }
 
+func colorWriteMask(blend graphicsdriver.Blend) mtl.ColorWriteMask {
+	var mask mtl.ColorWriteMask
+	r, g, b, a := blend.ColorWriteEnabled()
+	if r {
+		mask |= mtl.ColorWriteMaskRed
+	}
+	if g {
+		mask |= mtl.ColorWriteMaskGreen
+	}
+	if b {
+		mask |= mtl.ColorWriteMaskBlue
+	}
+	if a {
+		mask |= mtl.ColorWriteMaskAlpha
+	}
+	return mask
+}
+
 func (g *Graphics) Initialize() error {
 	// Creating *State objects are expensive and reuse them whenever possible.
 	// See https://developer.apple.com/library/archive/documentation/Miscellaneous/Conceptual/MetalProgrammingGuide/Cmd-Submiss/Cmd-Submiss.html
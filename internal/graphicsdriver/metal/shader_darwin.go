@@ -51,8 +51,9 @@ func (c *precompiledLibraries) get(hash shaderir.SourceHash) []byte {
 
 var thePrecompiledLibraries precompiledLibraries
 
-func RegisterPrecompiledLibrary(source []byte, bin []byte) {
-	thePrecompiledLibraries.put(shaderir.CalcSourceHash(source), bin)
+// RegisterPrecompiledLibrary registers a precompiled Metal library for the shader with the given source hash.
+func RegisterPrecompiledLibrary(hash shaderir.SourceHash, bin []byte) {
+	thePrecompiledLibraries.put(hash, bin)
 }
 
 type shaderRpsKey struct {
@@ -171,7 +172,7 @@ func (s *Shader) RenderPipelineState(view *view, blend graphicsdriver.Blend, ste
 	rpld.ColorAttachments[0].RGBBlendOperation = blendOperationToMetalBlendOperation(blend.BlendOperationRGB)
 
 	if stencilMode == noStencil || stencilMode == drawWithStencil {
-		rpld.ColorAttachments[0].WriteMask = mtl.ColorWriteMaskAll
+		rpld.ColorAttachments[0].WriteMask = colorWriteMask(blend)
 	} else {
 		rpld.ColorAttachments[0].WriteMask = mtl.ColorWriteMaskNone
 	}
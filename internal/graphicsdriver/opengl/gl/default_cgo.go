@@ -140,6 +140,10 @@ package gl
 //   typedef void (*fn)(GLuint index);
 //   ((fn)(fnptr))(index);
 // }
+// static void glowFinish(uintptr_t fnptr) {
+//   typedef void (*fn)();
+//   ((fn)(fnptr))();
+// }
 // static void glowFlush(uintptr_t fnptr) {
 //   typedef void (*fn)();
 //   ((fn)(fnptr))();
@@ -346,6 +350,7 @@ type defaultContext struct {
 	gpDrawElements             C.uintptr_t
 	gpEnable                   C.uintptr_t
 	gpEnableVertexAttribArray  C.uintptr_t
+	gpFinish                   C.uintptr_t
 	gpFlush                    C.uintptr_t
 	gpFramebufferRenderbuffer  C.uintptr_t
 	gpFramebufferTexture2D     C.uintptr_t
@@ -567,6 +572,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	C.glowEnableVertexAttribArray(c.gpEnableVertexAttribArray, C.GLuint(index))
 }
 
+func (c *defaultContext) Finish() {
+	C.glowFinish(c.gpFinish)
+}
+
 func (c *defaultContext) Flush() {
 	C.glowFlush(c.gpFlush)
 }
@@ -790,6 +799,7 @@ func (c *defaultContext) LoadFunctions() error {
 	c.gpDrawElements = C.uintptr_t(g.get("glDrawElements"))
 	c.gpEnable = C.uintptr_t(g.get("glEnable"))
 	c.gpEnableVertexAttribArray = C.uintptr_t(g.get("glEnableVertexAttribArray"))
+	c.gpFinish = C.uintptr_t(g.get("glFinish"))
 	c.gpFlush = C.uintptr_t(g.get("glFlush"))
 	c.gpFramebufferRenderbuffer = C.uintptr_t(g.get("glFramebufferRenderbuffer"))
 	c.gpFramebufferTexture2D = C.uintptr_t(g.get("glFramebufferTexture2D"))
@@ -57,6 +57,7 @@ type defaultContext struct {
 	fnEnableVertexAttribArray  js.Value
 	fnFramebufferRenderbuffer  js.Value
 	fnFramebufferTexture2D     js.Value
+	fnFinish                   js.Value
 	fnFlush                    js.Value
 	fnGetError                 js.Value
 	fnGetParameter             js.Value
@@ -185,6 +186,7 @@ func NewDefaultContext(v js.Value) (Context, error) {
 		fnEnableVertexAttribArray:  v.Get("enableVertexAttribArray").Call("bind", v),
 		fnFramebufferRenderbuffer:  v.Get("framebufferRenderbuffer").Call("bind", v),
 		fnFramebufferTexture2D:     v.Get("framebufferTexture2D").Call("bind", v),
+		fnFinish:                   v.Get("finish").Call("bind", v),
 		fnFlush:                    v.Get("flush").Call("bind", v),
 		fnGetError:                 v.Get("getError").Call("bind", v),
 		fnGetParameter:             v.Get("getParameter").Call("bind", v),
@@ -390,6 +392,10 @@ func (c *defaultContext) EnableVertexAttribArray(index uint32) {
 	c.fnEnableVertexAttribArray.Invoke(index)
 }
 
+func (c *defaultContext) Finish() {
+	c.fnFinish.Invoke()
+}
+
 func (c *defaultContext) Flush() {
 	c.fnFlush.Invoke()
 }
@@ -18,6 +18,8 @@ package opengl
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"unsafe"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
@@ -90,6 +92,11 @@ func (g *Graphics) End(present bool) error {
 	return nil
 }
 
+func (g *Graphics) Finish() error {
+	g.context.ctx.Finish()
+	return nil
+}
+
 func (g *Graphics) SetTransparent(transparent bool) {
 	// Do nothing.
 }
@@ -120,6 +127,19 @@ func (g *Graphics) genNextShaderID() graphicsdriver.ShaderID {
 	return g.nextShaderID
 }
 
+// SupportsNPOTImages implements graphicsdriver.NPOTImageSupporter.
+//
+// All the OpenGL versions Ebitengine requires (OpenGL 3.2, OpenGL ES 3.0, and WebGL 2) support NPOT textures.
+// For debugging, power-of-2 texture sizes can be forced by the environment variable EBITENGINE_OPENGL=pow2.
+func (g *Graphics) SupportsNPOTImages() bool {
+	for _, t := range strings.Split(os.Getenv("EBITENGINE_OPENGL"), ",") {
+		if strings.TrimSpace(t) == "pow2" {
+			return false
+		}
+	}
+	return true
+}
+
 func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
 	i := &Image{
 		id:       g.genNextImageID(),
@@ -266,6 +286,13 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 		g.context.ctx.Enable(gl.STENCIL_TEST)
 	}
 
+	// The color mask is reset after the draw calls.
+	r, gr, b, a := blend.ColorWriteEnabled()
+	colorMasked := !r || !gr || !b || !a
+	if colorMasked {
+		g.context.ctx.ColorMask(r, gr, b, a)
+	}
+
 	for _, dstRegion := range dstRegions {
 		g.context.ctx.Scissor(
 			int32(dstRegion.Region.Min.X),
@@ -292,12 +319,16 @@ func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.
 		if fillRule != graphicsdriver.FillRuleFillAll {
 			g.context.ctx.StencilFunc(gl.NOTEQUAL, 0x00, 0xff)
 			g.context.ctx.StencilOpSeparate(gl.FRONT_AND_BACK, gl.KEEP, gl.KEEP, gl.KEEP)
-			g.context.ctx.ColorMask(true, true, true, true)
+			g.context.ctx.ColorMask(r, gr, b, a)
 		}
 		g.context.ctx.DrawElements(gl.TRIANGLES, int32(dstRegion.IndexCount), gl.UNSIGNED_INT, indexOffset*int(unsafe.Sizeof(uint32(0))))
 		indexOffset += dstRegion.IndexCount
 	}
 
+	if colorMasked {
+		g.context.ctx.ColorMask(true, true, true, true)
+	}
+
 	if fillRule != graphicsdriver.FillRuleFillAll {
 		g.context.ctx.Disable(gl.STENCIL_TEST)
 	}
@@ -161,12 +161,13 @@ func (g *Graphics) DrawTriangles(dst graphicsdriver.ImageID, srcs [graphics.Shad
 	}
 
 	cBlend := C.ebitengine_Blend{
-		factor_src_rgb:   C.uint8_t(blend.BlendFactorSourceRGB),
-		factor_src_alpha: C.uint8_t(blend.BlendFactorSourceAlpha),
-		factor_dst_rgb:   C.uint8_t(blend.BlendFactorDestinationRGB),
-		factor_dst_alpha: C.uint8_t(blend.BlendFactorDestinationAlpha),
-		operation_rgb:    C.uint8_t(blend.BlendOperationRGB),
-		operation_alpha:  C.uint8_t(blend.BlendOperationAlpha),
+		factor_src_rgb:          C.uint8_t(blend.BlendFactorSourceRGB),
+		factor_src_alpha:        C.uint8_t(blend.BlendFactorSourceAlpha),
+		factor_dst_rgb:          C.uint8_t(blend.BlendFactorDestinationRGB),
+		factor_dst_alpha:        C.uint8_t(blend.BlendFactorDestinationAlpha),
+		operation_rgb:           C.uint8_t(blend.BlendOperationRGB),
+		operation_alpha:         C.uint8_t(blend.BlendOperationAlpha),
+		disabled_color_channels: C.uint8_t(blend.DisabledColorChannels),
 	}
 
 	cUniforms := make([]C.uint32_t, len(uniforms))
@@ -83,6 +83,7 @@ typedef struct ebitengine_Blend {
   uint8_t factor_dst_alpha;
   uint8_t operation_rgb;
   uint8_t operation_alpha;
+  uint8_t disabled_color_channels;
 } ebitengine_Blend;
 
 ebitengine_Error ebitengine_InitializeGraphics(void);
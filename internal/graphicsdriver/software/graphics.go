@@ -0,0 +1,504 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package software offers a graphics driver that rasterizes triangles on the CPU.
+//
+// This driver is slow and is not intended for games.
+// This driver works without GPUs and the results are deterministic, which is useful for pixel-exact tests.
+// The tests of the graphicscommand and restorable packages use this driver.
+//
+// Shader programs are executed by interpreting their intermediate representations.
+package software
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+type Graphics struct {
+	images       map[graphicsdriver.ImageID]*Image
+	shaders      map[graphicsdriver.ShaderID]*Shader
+	nextImageID  graphicsdriver.ImageID
+	nextShaderID graphicsdriver.ShaderID
+
+	vertices []float32
+	indices  []uint32
+}
+
+func NewGraphics() (*Graphics, error) {
+	return &Graphics{
+		images:  map[graphicsdriver.ImageID]*Image{},
+		shaders: map[graphicsdriver.ShaderID]*Shader{},
+	}, nil
+}
+
+func (g *Graphics) Initialize() error {
+	return nil
+}
+
+func (g *Graphics) Begin() error {
+	return nil
+}
+
+func (g *Graphics) End(present bool) error {
+	return nil
+}
+
+func (g *Graphics) SetTransparent(transparent bool) {
+}
+
+func (g *Graphics) SetVertices(vertices []float32, indices []uint32) error {
+	// The given slices might be reused by the caller.
+	g.vertices = append(g.vertices[:0], vertices...)
+	g.indices = append(g.indices[:0], indices...)
+	return nil
+}
+
+func (g *Graphics) NewImage(width, height int) (graphicsdriver.Image, error) {
+	// Allocate the internal size as the other drivers do. The callers calculate texture coordinates based on it.
+	return g.newImage(graphics.InternalImageSize(width), graphics.InternalImageSize(height)), nil
+}
+
+func (g *Graphics) NewScreenFramebufferImage(width, height int) (graphicsdriver.Image, error) {
+	return g.newImage(width, height), nil
+}
+
+func (g *Graphics) newImage(width, height int) *Image {
+	g.nextImageID++
+	i := &Image{
+		id:       g.nextImageID,
+		graphics: g,
+		width:    width,
+		height:   height,
+		pixels:   make([]byte, 4*width*height),
+	}
+	g.images[i.id] = i
+	return i
+}
+
+func (g *Graphics) SetVsyncEnabled(enabled bool) {
+}
+
+func (g *Graphics) NeedsClearingScreen() bool {
+	return true
+}
+
+func (g *Graphics) MaxImageSize() int {
+	return 4096
+}
+
+func (g *Graphics) SupportsNPOTImages() bool {
+	return true
+}
+
+func (g *Graphics) NewShader(program *shaderir.Program) (graphicsdriver.Shader, error) {
+	p, err := newProgram(program)
+	if err != nil {
+		return nil, err
+	}
+	g.nextShaderID++
+	s := &Shader{
+		id:       g.nextShaderID,
+		graphics: g,
+		program:  p,
+	}
+	g.shaders[s.id] = s
+	return s, nil
+}
+
+// vertex is a vertex processed by a vertex shader.
+type vertex struct {
+	// x, y and z are in the window coordinates.
+	x, y, z  float64
+	varyings []value
+}
+
+func (g *Graphics) DrawTriangles(dstID graphicsdriver.ImageID, srcIDs [graphics.ShaderSrcImageCount]graphicsdriver.ImageID, shaderID graphicsdriver.ShaderID, dstRegions []graphicsdriver.DstRegion, indexOffset int, blend graphicsdriver.Blend, uniforms []uint32, fillRule graphicsdriver.FillRule) error {
+	dst, ok := g.images[dstID]
+	if !ok {
+		return fmt.Errorf("software: destination image %d is not found", dstID)
+	}
+	shader, ok := g.shaders[shaderID]
+	if !ok {
+		return fmt.Errorf("software: shader %d is not found", shaderID)
+	}
+
+	var srcs [graphics.ShaderSrcImageCount]*Image
+	for i, srcID := range srcIDs {
+		if srcID == graphicsdriver.InvalidImageID {
+			continue
+		}
+		src, ok := g.images[srcID]
+		if !ok {
+			return fmt.Errorf("software: source image %d is not found", srcID)
+		}
+		srcs[i] = src
+	}
+
+	it := newInterpreter(shader.program, uniforms, srcs)
+	vertices := map[uint32]*vertex{}
+	vertexAt := func(index uint32) *vertex {
+		if v, ok := vertices[index]; ok {
+			return v
+		}
+		n := graphics.VertexFloatCount
+		pos, varyings := it.vertex(g.vertices[int(index)*n : int(index+1)*n])
+		// Convert the clip coordinates to the window coordinates.
+		// Unlike OpenGL, the Y direction is not flipped: Ebitengine's projection matrix maps the upper edge of
+		// an image to -1, and the row 0 of the pixels corresponds to the upper edge.
+		w := float64(pos.f[3])
+		v := &vertex{
+			x:        (float64(pos.f[0])/w + 1) / 2 * float64(dst.width),
+			y:        (float64(pos.f[1])/w + 1) / 2 * float64(dst.height),
+			z:        (float64(pos.f[2])/w + 1) / 2,
+			varyings: make([]value, len(varyings)),
+		}
+		for i, varying := range varyings {
+			v.varyings[i] = varying.copy()
+		}
+		vertices[index] = v
+		return v
+	}
+
+	for _, dstRegion := range dstRegions {
+		indices := g.indices[indexOffset : indexOffset+dstRegion.IndexCount]
+		indexOffset += dstRegion.IndexCount
+
+		region := dstRegion.Region.Intersect(image.Rect(0, 0, dst.width, dst.height))
+		if region.Empty() {
+			continue
+		}
+
+		// The stencil buffer works in the same way as the OpenGL driver's:
+		// count the windings, and then draw all the triangles where the counts pass the test.
+		var stencil []int
+		if fillRule != graphicsdriver.FillRuleFillAll {
+			stencil = make([]int, region.Dx()*region.Dy())
+			for i := 0; i+2 < len(indices); i += 3 {
+				rasterize(vertexAt(indices[i]), vertexAt(indices[i+1]), vertexAt(indices[i+2]), region, func(x, y int, weights [3]float64, winding int) {
+					stencil[(y-region.Min.Y)*region.Dx()+(x-region.Min.X)] += winding
+				})
+			}
+		}
+
+		passes := func(x, y int) bool {
+			if stencil == nil {
+				return true
+			}
+			c := stencil[(y-region.Min.Y)*region.Dx()+(x-region.Min.X)]
+			if fillRule == graphicsdriver.FillRuleNonZero && c == 0 {
+				return false
+			}
+			if fillRule == graphicsdriver.FillRuleEvenOdd && c%2 == 0 {
+				return false
+			}
+			return true
+		}
+
+		for i := 0; i+2 < len(indices); i += 3 {
+			vs := [3]*vertex{vertexAt(indices[i]), vertexAt(indices[i+1]), vertexAt(indices[i+2])}
+
+			if !shader.program.usesDerivatives {
+				rasterize(vs[0], vs[1], vs[2], region, func(x, y int, weights [3]float64, winding int) {
+					if !passes(x, y) {
+						return
+					}
+					position, varyings := fragmentInputs(vs, x, y, weights)
+					clr, ok := it.fragment(position, varyings)
+					if !ok {
+						return
+					}
+					dst.blend(x, y, clr, blend)
+				})
+				continue
+			}
+
+			// The derivatives need the neighboring pixels. Execute the fragment shader for each 2x2 quad like GPUs.
+			// The pixels in a quad that are not covered are executed only to calculate the derivatives.
+			var quads []image.Point
+			quadSet := map[image.Point]struct{}{}
+			covered := map[image.Point]struct{}{}
+			rasterize(vs[0], vs[1], vs[2], region, func(x, y int, weights [3]float64, winding int) {
+				if !passes(x, y) {
+					return
+				}
+				covered[image.Pt(x, y)] = struct{}{}
+				q := image.Pt(x&^1, y&^1)
+				if _, ok := quadSet[q]; !ok {
+					quadSet[q] = struct{}{}
+					quads = append(quads, q)
+				}
+			})
+			for _, q := range quads {
+				var positions [4]value
+				var varyings [4][]value
+				for lane := range 4 {
+					x, y := q.X+lane%2, q.Y+lane/2
+					positions[lane], varyings[lane] = fragmentInputs(vs, x, y, barycentric(vs[0], vs[1], vs[2], float64(x)+0.5, float64(y)+0.5))
+				}
+				clrs, oks := it.fragmentQuad(positions, varyings)
+				for lane := range 4 {
+					x, y := q.X+lane%2, q.Y+lane/2
+					if _, ok := covered[image.Pt(x, y)]; !ok || !oks[lane] {
+						continue
+					}
+					dst.blend(x, y, clrs[lane], blend)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// fragmentInputs returns the fragment coordinate and the interpolated varying variables at the pixel (x, y).
+func fragmentInputs(vs [3]*vertex, x, y int, weights [3]float64) (value, []value) {
+	position := value{typ: shaderir.Vec4}
+	position.f[0] = float32(x) + 0.5
+	position.f[1] = float32(y) + 0.5
+	position.f[2] = float32(interpolate(weights, vs[0].z, vs[1].z, vs[2].z))
+	position.f[3] = 1
+
+	varyings := make([]value, len(vs[0].varyings))
+	for j := range varyings {
+		v := value{typ: vs[0].varyings[j].typ}
+		for k := 0; k < componentCount(v.typ); k++ {
+			v.f[k] = float32(interpolate(weights, float64(vs[0].varyings[j].f[k]), float64(vs[1].varyings[j].f[k]), float64(vs[2].varyings[j].f[k])))
+		}
+		// Integers are not interpolated.
+		v.i = vs[0].varyings[j].i
+		varyings[j] = v
+	}
+	return position, varyings
+}
+
+// rasterize calls f for each pixel in the region whose center is covered by the triangle.
+//
+// f takes the barycentric weights of the vertices at the pixel center and the winding of the triangle,
+// which is 1 for a clockwise triangle on the screen and -1 for a counter-clockwise one.
+//
+// A pixel center exactly on an edge shared by two triangles is covered by only one of them.
+func rasterize(v0, v1, v2 *vertex, region image.Rectangle, f func(x, y int, weights [3]float64, winding int)) {
+	area := edge(v0, v1, v2.x, v2.y)
+	if area == 0 || math.IsNaN(area) || math.IsInf(area, 0) {
+		return
+	}
+	winding := 1
+	if area < 0 {
+		winding = -1
+	}
+	sign := float64(winding)
+
+	// owns reports whether a pixel center exactly on the edge from a to b is covered.
+	// The two triangles sharing an edge see the edge in the opposite directions, so only one of them owns it.
+	owns := func(a, b *vertex) bool {
+		dx, dy := sign*(b.x-a.x), sign*(b.y-a.y)
+		return dy > 0 || (dy == 0 && dx < 0)
+	}
+	owns0, owns1, owns2 := owns(v1, v2), owns(v2, v0), owns(v0, v1)
+
+	minX := max(region.Min.X, int(math.Floor(min(v0.x, v1.x, v2.x))))
+	minY := max(region.Min.Y, int(math.Floor(min(v0.y, v1.y, v2.y))))
+	maxX := min(region.Max.X, int(math.Ceil(max(v0.x, v1.x, v2.x))))
+	maxY := min(region.Max.Y, int(math.Ceil(max(v0.y, v1.y, v2.y))))
+
+	for y := minY; y < maxY; y++ {
+		cy := float64(y) + 0.5
+		for x := minX; x < maxX; x++ {
+			cx := float64(x) + 0.5
+			e0 := sign * edge(v1, v2, cx, cy)
+			e1 := sign * edge(v2, v0, cx, cy)
+			e2 := sign * edge(v0, v1, cx, cy)
+			if e0 < 0 || e1 < 0 || e2 < 0 {
+				continue
+			}
+			if (e0 == 0 && !owns0) || (e1 == 0 && !owns1) || (e2 == 0 && !owns2) {
+				continue
+			}
+			a := sign * area
+			f(x, y, [3]float64{e0 / a, e1 / a, e2 / a}, winding)
+		}
+	}
+}
+
+// barycentric returns the barycentric weights of the vertices at (x, y).
+// The weights can be negative when (x, y) is outside of the triangle.
+func barycentric(v0, v1, v2 *vertex, x, y float64) [3]float64 {
+	area := edge(v0, v1, v2.x, v2.y)
+	return [3]float64{edge(v1, v2, x, y) / area, edge(v2, v0, x, y) / area, edge(v0, v1, x, y) / area}
+}
+
+// edge returns the doubled signed area of the triangle (a, b, (x, y)).
+func edge(a, b *vertex, x, y float64) float64 {
+	// Explicit conversions prevent the compiler from fusing multiplications and additions,
+	// which would make the results platform-dependent.
+	return float64((b.x-a.x)*(y-a.y)) - float64((b.y-a.y)*(x-a.x))
+}
+
+func interpolate(weights [3]float64, v0, v1, v2 float64) float64 {
+	return float64(weights[0]*v0) + float64(weights[1]*v1) + float64(weights[2]*v2)
+}
+
+type Image struct {
+	id       graphicsdriver.ImageID
+	graphics *Graphics
+
+	// width and height are the allocated size.
+	width  int
+	height int
+
+	pixels []byte
+}
+
+func (i *Image) ID() graphicsdriver.ImageID {
+	return i.id
+}
+
+func (i *Image) Dispose() {
+	delete(i.graphics.images, i.id)
+}
+
+func (i *Image) ReadPixels(args []graphicsdriver.PixelsArgs) error {
+	for _, a := range args {
+		if !a.Region.In(image.Rect(0, 0, i.width, i.height)) {
+			return fmt.Errorf("software: region %v is out of the image bounds", a.Region)
+		}
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			idx := 4 * ((a.Region.Min.Y+j)*i.width + a.Region.Min.X)
+			copy(a.Pixels[4*j*w:4*(j+1)*w], i.pixels[idx:idx+4*w])
+		}
+	}
+	return nil
+}
+
+func (i *Image) WritePixels(args []graphicsdriver.PixelsArgs) error {
+	for _, a := range args {
+		if !a.Region.In(image.Rect(0, 0, i.width, i.height)) {
+			return fmt.Errorf("software: region %v is out of the image bounds", a.Region)
+		}
+		w := a.Region.Dx()
+		for j := 0; j < a.Region.Dy(); j++ {
+			idx := 4 * ((a.Region.Min.Y+j)*i.width + a.Region.Min.X)
+			copy(i.pixels[idx:idx+4*w], a.Pixels[4*j*w:4*(j+1)*w])
+		}
+	}
+	return nil
+}
+
+// blend blends the color clr, which is premultiplied-alpha, to the pixel at (x, y).
+func (i *Image) blend(x, y int, clr value, blend graphicsdriver.Blend) {
+	idx := 4 * (y*i.width + x)
+
+	// The output of a fragment shader is clamped for normalized unsigned integer formats.
+	var s, d [4]float32
+	for k := range 4 {
+		s[k] = clamp01(clr.f[k])
+		d[k] = float32(i.pixels[idx+k]) / 255
+	}
+
+	var result [4]float32
+	for k := range 3 {
+		result[k] = blendComponent(s[k], d[k], blendFactor(blend.BlendFactorSourceRGB, s, d, k), blendFactor(blend.BlendFactorDestinationRGB, s, d, k), blend.BlendOperationRGB)
+	}
+	result[3] = blendComponent(s[3], d[3], blendFactor(blend.BlendFactorSourceAlpha, s, d, 3), blendFactor(blend.BlendFactorDestinationAlpha, s, d, 3), blend.BlendOperationAlpha)
+
+	r, g, b, a := blend.ColorWriteEnabled()
+	for k, enabled := range [...]bool{r, g, b, a} {
+		if !enabled {
+			continue
+		}
+		i.pixels[idx+k] = uint8(math.Round(float64(clamp01(result[k])) * 255))
+	}
+}
+
+func clamp01(x float32) float32 {
+	// NaN is treated as 0.
+	if !(x > 0) {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
+
+// blendFactor returns the factor for the k-th component of the source color s and the destination color d.
+func blendFactor(factor graphicsdriver.BlendFactor, s, d [4]float32, k int) float32 {
+	switch factor {
+	case graphicsdriver.BlendFactorZero:
+		return 0
+	case graphicsdriver.BlendFactorOne:
+		return 1
+	case graphicsdriver.BlendFactorSourceColor:
+		return s[k]
+	case graphicsdriver.BlendFactorOneMinusSourceColor:
+		return 1 - s[k]
+	case graphicsdriver.BlendFactorSourceAlpha:
+		return s[3]
+	case graphicsdriver.BlendFactorOneMinusSourceAlpha:
+		return 1 - s[3]
+	case graphicsdriver.BlendFactorDestinationColor:
+		return d[k]
+	case graphicsdriver.BlendFactorOneMinusDestinationColor:
+		return 1 - d[k]
+	case graphicsdriver.BlendFactorDestinationAlpha:
+		return d[3]
+	case graphicsdriver.BlendFactorOneMinusDestinationAlpha:
+		return 1 - d[3]
+	case graphicsdriver.BlendFactorSourceAlphaSaturated:
+		if k == 3 {
+			return 1
+		}
+		return min(s[3], 1-d[3])
+	default:
+		panic(fmt.Sprintf("software: invalid blend factor: %d", factor))
+	}
+}
+
+func blendComponent(s, d, sf, df float32, operation graphicsdriver.BlendOperation) float32 {
+	switch operation {
+	case graphicsdriver.BlendOperationAdd:
+		return float32(s*sf) + float32(d*df)
+	case graphicsdriver.BlendOperationSubtract:
+		return float32(s*sf) - float32(d*df)
+	case graphicsdriver.BlendOperationReverseSubtract:
+		return float32(d*df) - float32(s*sf)
+	case graphicsdriver.BlendOperationMin:
+		return min(s, d)
+	case graphicsdriver.BlendOperationMax:
+		return max(s, d)
+	default:
+		panic(fmt.Sprintf("software: invalid blend operation: %d", operation))
+	}
+}
+
+type Shader struct {
+	id       graphicsdriver.ShaderID
+	graphics *Graphics
+	program  *program
+}
+
+func (s *Shader) ID() graphicsdriver.ShaderID {
+	return s.id
+}
+
+func (s *Shader) Dispose() {
+	delete(s.graphics.shaders, s.id)
+}
@@ -0,0 +1,439 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software_test
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver/software"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+func newShader(t *testing.T, g *software.Graphics, src []byte) (graphicsdriver.Shader, *shaderir.Program) {
+	t.Helper()
+	ir, err := graphics.CompileShader(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := g.NewShader(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, ir
+}
+
+func newImage(t *testing.T, g *software.Graphics, width, height int, clr [4]byte) graphicsdriver.Image {
+	t.Helper()
+	img, err := g.NewImage(width, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pix := make([]byte, 4*width*height)
+	for i := 0; i < len(pix); i += 4 {
+		copy(pix[i:i+4], clr[:])
+	}
+	if err := img.WritePixels([]graphicsdriver.PixelsArgs{{Pixels: pix, Region: image.Rect(0, 0, width, height)}}); err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func readPixels(t *testing.T, img graphicsdriver.Image, width, height int) []byte {
+	t.Helper()
+	pix := make([]byte, 4*width*height)
+	if err := img.ReadPixels([]graphicsdriver.PixelsArgs{{Pixels: pix, Region: image.Rect(0, 0, width, height)}}); err != nil {
+		t.Fatal(err)
+	}
+	return pix
+}
+
+// uniforms returns uniform values with the preserved uniform variables, in the same way as the graphicscommand package.
+func uniforms(ir *shaderir.Program, dstWidth, dstHeight, srcWidth, srcHeight int) []uint32 {
+	var n int
+	for _, t := range ir.Uniforms {
+		n += t.DwordCount()
+	}
+	u := make([]uint32, n)
+	f := func(x float32) uint32 {
+		return math.Float32bits(x)
+	}
+	// The texture sizes are the internal sizes, while the region sizes are not.
+	dw, dh := graphics.InternalImageSize(dstWidth), graphics.InternalImageSize(dstHeight)
+	sw, sh := graphics.InternalImageSize(srcWidth), graphics.InternalImageSize(srcHeight)
+	u[0], u[1] = f(float32(dw)), f(float32(dh))
+	u[2], u[3] = f(float32(sw)), f(float32(sh))
+	u[12], u[13] = f(float32(dstWidth)), f(float32(dstHeight))
+	u[22], u[23] = f(float32(srcWidth)), f(float32(srcHeight))
+	u[30] = f(2 / float32(dw))
+	u[35] = f(2 / float32(dh))
+	u[40] = f(1)
+	u[42], u[43] = f(-1), f(-1)
+	u[45] = f(1)
+	return u
+}
+
+type drawArgs struct {
+	dst       graphicsdriver.Image
+	dstWidth  int
+	dstHeight int
+	src       graphicsdriver.Image
+	srcWidth  int
+	srcHeight int
+	shader    graphicsdriver.Shader
+	ir        *shaderir.Program
+	geoM      [6]float32
+	blend     graphicsdriver.Blend
+	fillRule  graphicsdriver.FillRule
+	quadCount int
+}
+
+func drawImage(t *testing.T, g *software.Graphics, args *drawArgs) {
+	t.Helper()
+	quadCount := max(args.quadCount, 1)
+	vs := make([]float32, 4*graphics.VertexFloatCount*quadCount)
+	var is []uint32
+	for i := 0; i < quadCount; i++ {
+		m := args.geoM
+		graphics.QuadVerticesFromSrcAndMatrix(vs[4*graphics.VertexFloatCount*i:], 0, 0, float32(args.srcWidth), float32(args.srcHeight), m[0], m[1], m[2], m[3], m[4], m[5], 1, 1, 1, 1)
+		for _, idx := range graphics.QuadIndices() {
+			is = append(is, uint32(4*i)+idx)
+		}
+	}
+	if err := g.SetVertices(vs, is); err != nil {
+		t.Fatal(err)
+	}
+	srcs := [graphics.ShaderSrcImageCount]graphicsdriver.ImageID{args.src.ID()}
+	dstRegions := []graphicsdriver.DstRegion{
+		{
+			Region:     image.Rect(0, 0, args.dstWidth, args.dstHeight),
+			IndexCount: len(is),
+		},
+	}
+	if err := g.DrawTriangles(args.dst.ID(), srcs, args.shader.ID(), dstRegions, 0, args.blend, uniforms(args.ir, args.dstWidth, args.dstHeight, args.srcWidth, args.srcHeight), args.fillRule); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompositeModes(t *testing.T) {
+	// The source and destination colors are premultiplied-alpha.
+	srcColor := [4]byte{0x80, 0x40, 0x00, 0x80}
+	dstColor := [4]byte{0x00, 0x40, 0x80, 0xc0}
+
+	testCases := []struct {
+		name  string
+		blend graphicsdriver.Blend
+		want  [4]byte
+	}{
+		{"SourceOver", graphicsdriver.BlendSourceOver, [4]byte{0x80, 0x60, 0x40, 0xe0}},
+		{"Clear", graphicsdriver.BlendClear, [4]byte{0x00, 0x00, 0x00, 0x00}},
+		{"Copy", graphicsdriver.BlendCopy, [4]byte{0x80, 0x40, 0x00, 0x80}},
+		{"Destination", graphicsdriver.BlendDestination, [4]byte{0x00, 0x40, 0x80, 0xc0}},
+		{"DestinationOver", graphicsdriver.BlendDestinationOver, [4]byte{0x20, 0x50, 0x80, 0xe0}},
+		{"SourceIn", graphicsdriver.BlendSourceIn, [4]byte{0x60, 0x30, 0x00, 0x60}},
+		{"DestinationIn", graphicsdriver.BlendDestinationIn, [4]byte{0x00, 0x20, 0x40, 0x60}},
+		{"SourceOut", graphicsdriver.BlendSourceOut, [4]byte{0x20, 0x10, 0x00, 0x20}},
+		{"DestinationOut", graphicsdriver.BlendDestinationOut, [4]byte{0x00, 0x20, 0x40, 0x60}},
+		{"SourceAtop", graphicsdriver.BlendSourceAtop, [4]byte{0x60, 0x50, 0x40, 0xc0}},
+		{"DestinationAtop", graphicsdriver.BlendDestinationAtop, [4]byte{0x20, 0x30, 0x40, 0x80}},
+		{"Xor", graphicsdriver.BlendXor, [4]byte{0x20, 0x30, 0x40, 0x7f}},
+		{"Lighter", graphicsdriver.BlendLighter, [4]byte{0x80, 0x80, 0x80, 0xff}},
+		{"LighterAlpha", graphicsdriver.BlendLighterAlpha, [4]byte{0x40, 0x60, 0x80, 0xff}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := software.NewGraphics()
+			if err != nil {
+				t.Fatal(err)
+			}
+			const w, h = 4, 4
+			shader, ir := newShader(t, g, builtinshader.ShaderSource(builtinshader.FilterNearest, builtinshader.AddressUnsafe, false))
+			src := newImage(t, g, w, h, srcColor)
+			dst := newImage(t, g, w, h, dstColor)
+			drawImage(t, g, &drawArgs{
+				dst:       dst,
+				dstWidth:  w,
+				dstHeight: h,
+				src:       src,
+				srcWidth:  w,
+				srcHeight: h,
+				shader:    shader,
+				ir:        ir,
+				geoM:      [6]float32{1, 0, 0, 1, 0, 0},
+				blend:     tc.blend,
+			})
+
+			pix := readPixels(t, dst, w, h)
+			for i := 0; i < len(pix); i += 4 {
+				if got := [4]byte(pix[i : i+4]); got != tc.want {
+					t.Errorf("pixel (%d, %d): got: %#v, want: %#v", (i/4)%w, (i/4)/w, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	// A 2x2 source image with distinct colors.
+	srcPix := []byte{
+		0xff, 0, 0, 0xff, 0, 0xff, 0, 0xff,
+		0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+	red := [4]byte{0xff, 0, 0, 0xff}
+	green := [4]byte{0, 0xff, 0, 0xff}
+	blue := [4]byte{0, 0, 0xff, 0xff}
+	white := [4]byte{0xff, 0xff, 0xff, 0xff}
+
+	testCases := []struct {
+		name string
+		geoM [6]float32
+		// want returns the expected color at the destination pixel (x, y).
+		want func(x, y int) [4]byte
+	}{
+		{
+			name: "scale and translate",
+			geoM: [6]float32{2, 0, 0, 2, 1, 1},
+			want: func(x, y int) [4]byte {
+				if x < 1 || x >= 5 || y < 1 || y >= 5 {
+					return [4]byte{}
+				}
+				return [2][2][4]byte{{red, green}, {blue, white}}[(y-1)/2][(x-1)/2]
+			},
+		},
+		{
+			// Rotate by 90 degrees clockwise around the origin and then translate.
+			name: "rotate",
+			geoM: [6]float32{0, -2, 2, 0, 5, 1},
+			want: func(x, y int) [4]byte {
+				if x < 1 || x >= 5 || y < 1 || y >= 5 {
+					return [4]byte{}
+				}
+				return [2][2][4]byte{{blue, red}, {white, green}}[(y-1)/2][(x-1)/2]
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := software.NewGraphics()
+			if err != nil {
+				t.Fatal(err)
+			}
+			const w, h = 6, 6
+			shader, ir := newShader(t, g, builtinshader.ShaderSource(builtinshader.FilterNearest, builtinshader.AddressUnsafe, false))
+			src := newImage(t, g, 2, 2, [4]byte{})
+			if err := src.WritePixels([]graphicsdriver.PixelsArgs{{Pixels: srcPix, Region: image.Rect(0, 0, 2, 2)}}); err != nil {
+				t.Fatal(err)
+			}
+			dst := newImage(t, g, w, h, [4]byte{})
+			drawImage(t, g, &drawArgs{
+				dst:       dst,
+				dstWidth:  w,
+				dstHeight: h,
+				src:       src,
+				srcWidth:  2,
+				srcHeight: 2,
+				shader:    shader,
+				ir:        ir,
+				geoM:      tc.geoM,
+				blend:     graphicsdriver.BlendSourceOver,
+			})
+
+			pix := readPixels(t, dst, w, h)
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					idx := 4 * (j*w + i)
+					if got, want := [4]byte(pix[idx:idx+4]), tc.want(i, j); got != want {
+						t.Errorf("pixel (%d, %d): got: %#v, want: %#v", i, j, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFillRule(t *testing.T) {
+	// Two identical quads make the winding count 2 for every covered pixel.
+	testCases := []struct {
+		fillRule graphicsdriver.FillRule
+		want     [4]byte
+	}{
+		{graphicsdriver.FillRuleFillAll, [4]byte{0xff, 0xff, 0xff, 0xff}},
+		{graphicsdriver.FillRuleNonZero, [4]byte{0xff, 0xff, 0xff, 0xff}},
+		{graphicsdriver.FillRuleEvenOdd, [4]byte{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fillRule.String(), func(t *testing.T) {
+			g, err := software.NewGraphics()
+			if err != nil {
+				t.Fatal(err)
+			}
+			const w, h = 4, 4
+			shader, ir := newShader(t, g, builtinshader.ShaderSource(builtinshader.FilterNearest, builtinshader.AddressUnsafe, false))
+			src := newImage(t, g, w, h, [4]byte{0xff, 0xff, 0xff, 0xff})
+			dst := newImage(t, g, w, h, [4]byte{})
+			drawImage(t, g, &drawArgs{
+				dst:       dst,
+				dstWidth:  w,
+				dstHeight: h,
+				src:       src,
+				srcWidth:  w,
+				srcHeight: h,
+				shader:    shader,
+				ir:        ir,
+				geoM:      [6]float32{1, 0, 0, 1, 0, 0},
+				blend:     graphicsdriver.BlendCopy,
+				fillRule:  tc.fillRule,
+				quadCount: 2,
+			})
+
+			pix := readPixels(t, dst, w, h)
+			for i := 0; i < len(pix); i += 4 {
+				if got := [4]byte(pix[i : i+4]); got != tc.want {
+					t.Errorf("pixel (%d, %d): got: %#v, want: %#v", (i/4)%w, (i/4)/w, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestShaderControlFlow(t *testing.T) {
+	const src = `//kage:unit pixels
+
+package main
+
+func split(x float) (float, float) {
+	return x / 2, x / 4
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	if int(dstPos.x)%2 == 1 {
+		discard()
+	}
+	sum := 0.0
+	for i := 0; i < 3; i++ {
+		sum += 0.25
+	}
+	a, b := split(sum)
+	return vec4(a, b, sum, 1)
+}
+`
+
+	g, err := software.NewGraphics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const w, h = 4, 2
+	shader, ir := newShader(t, g, []byte(src))
+	srcImg := newImage(t, g, w, h, [4]byte{})
+	dst := newImage(t, g, w, h, [4]byte{})
+	drawImage(t, g, &drawArgs{
+		dst:       dst,
+		dstWidth:  w,
+		dstHeight: h,
+		src:       srcImg,
+		srcWidth:  w,
+		srcHeight: h,
+		shader:    shader,
+		ir:        ir,
+		geoM:      [6]float32{1, 0, 0, 1, 0, 0},
+		blend:     graphicsdriver.BlendCopy,
+	})
+
+	pix := readPixels(t, dst, w, h)
+	for i := 0; i < len(pix); i += 4 {
+		x := (i / 4) % w
+		want := [4]byte{0x60, 0x30, 0xbf, 0xff}
+		if x%2 == 1 {
+			want = [4]byte{}
+		}
+		if got := [4]byte(pix[i : i+4]); got != want {
+			t.Errorf("pixel (%d, %d): got: %#v, want: %#v", x, (i/4)/w, got, want)
+		}
+	}
+}
+
+func TestDerivatives(t *testing.T) {
+	const src = `//kage:unit pixels
+
+package main
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	d := dfdx(dstPos.x * dstPos.x)
+	// A derivative of a value depending on another derivative.
+	e := dfdx(d * dstPos.x)
+	return vec4(d/16, e/16, fwidth(dstPos.y), 1)
+}
+`
+
+	g, err := software.NewGraphics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const w, h = 4, 2
+	shader, ir := newShader(t, g, []byte(src))
+	srcImg := newImage(t, g, w, h, [4]byte{})
+	dst := newImage(t, g, w, h, [4]byte{})
+	drawImage(t, g, &drawArgs{
+		dst:       dst,
+		dstWidth:  w,
+		dstHeight: h,
+		src:       srcImg,
+		srcWidth:  w,
+		srcHeight: h,
+		shader:    shader,
+		ir:        ir,
+		geoM:      [6]float32{1, 0, 0, 1, 0, 0},
+		blend:     graphicsdriver.BlendCopy,
+	})
+
+	pix := readPixels(t, dst, w, h)
+	for i := 0; i < len(pix); i += 4 {
+		x := (i / 4) % w
+		// In a 2x2 quad, dfdx(x*x) is (x+1.5)^2 - (x+0.5)^2 where x is the even X coordinate of the quad.
+		want := [4]byte{0x20, 0x20, 0xff, 0xff}
+		if x >= 2 {
+			want = [4]byte{0x60, 0x60, 0xff, 0xff}
+		}
+		if got := [4]byte(pix[i : i+4]); got != want {
+			t.Errorf("pixel (%d, %d): got: %#v, want: %#v", x, (i/4)/w, got, want)
+		}
+	}
+}
+
+func TestBuiltinShaders(t *testing.T) {
+	g, err := software.NewGraphics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, src := range builtinshader.Sources() {
+		if len(src) == 0 {
+			continue
+		}
+		ir, err := graphics.CompileShader(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.NewShader(ir); err != nil {
+			t.Errorf("source #%d: NewShader failed: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,1272 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package software
+
+import (
+	"fmt"
+	"go/constant"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+)
+
+// value is a value in a shader program.
+//
+// Scalars, vectors and matrices store their components in f, i or b depending on the type.
+// Matrices are in column-major order.
+// Arrays store their elements in elems.
+type value struct {
+	typ   shaderir.BasicType
+	f     [16]float32
+	i     [4]int32
+	b     bool
+	elems []value
+}
+
+func (v value) copy() value {
+	if v.elems == nil {
+		return v
+	}
+	elems := make([]value, len(v.elems))
+	for i, e := range v.elems {
+		elems[i] = e.copy()
+	}
+	v.elems = elems
+	return v
+}
+
+func (v value) float(i int) float32 {
+	switch {
+	case isIntType(v.typ):
+		return float32(v.i[i])
+	case v.typ == shaderir.Bool:
+		if v.b {
+			return 1
+		}
+		return 0
+	}
+	return v.f[i]
+}
+
+func (v value) int(i int) int32 {
+	switch {
+	case isIntType(v.typ):
+		return v.i[i]
+	case v.typ == shaderir.Bool:
+		if v.b {
+			return 1
+		}
+		return 0
+	}
+	return int32(v.f[i])
+}
+
+func boolValue(b bool) value {
+	return value{typ: shaderir.Bool, b: b}
+}
+
+func componentCount(t shaderir.BasicType) int {
+	switch t {
+	case shaderir.Bool, shaderir.Int, shaderir.Float:
+		return 1
+	case shaderir.Vec2, shaderir.IVec2:
+		return 2
+	case shaderir.Vec3, shaderir.IVec3:
+		return 3
+	case shaderir.Vec4, shaderir.IVec4, shaderir.Mat2:
+		return 4
+	case shaderir.Mat3:
+		return 9
+	case shaderir.Mat4:
+		return 16
+	default:
+		return 0
+	}
+}
+
+func isIntType(t shaderir.BasicType) bool {
+	switch t {
+	case shaderir.Int, shaderir.IVec2, shaderir.IVec3, shaderir.IVec4:
+		return true
+	}
+	return false
+}
+
+func isScalarType(t shaderir.BasicType) bool {
+	switch t {
+	case shaderir.Bool, shaderir.Int, shaderir.Float:
+		return true
+	}
+	return false
+}
+
+func isMatrixType(t shaderir.BasicType) bool {
+	switch t {
+	case shaderir.Mat2, shaderir.Mat3, shaderir.Mat4:
+		return true
+	}
+	return false
+}
+
+func matrixSize(t shaderir.BasicType) int {
+	switch t {
+	case shaderir.Mat2:
+		return 2
+	case shaderir.Mat3:
+		return 3
+	case shaderir.Mat4:
+		return 4
+	}
+	return 0
+}
+
+func floatType(n int) shaderir.BasicType {
+	switch n {
+	case 1:
+		return shaderir.Float
+	case 2:
+		return shaderir.Vec2
+	case 3:
+		return shaderir.Vec3
+	case 4:
+		return shaderir.Vec4
+	}
+	panic(fmt.Sprintf("software: unexpected component count: %d", n))
+}
+
+func intType(n int) shaderir.BasicType {
+	switch n {
+	case 1:
+		return shaderir.Int
+	case 2:
+		return shaderir.IVec2
+	case 3:
+		return shaderir.IVec3
+	case 4:
+		return shaderir.IVec4
+	}
+	panic(fmt.Sprintf("software: unexpected component count: %d", n))
+}
+
+func zeroValue(t shaderir.Type) value {
+	if t.Main == shaderir.Array {
+		elems := make([]value, t.Length)
+		for i := range elems {
+			elems[i] = zeroValue(t.Sub[0])
+		}
+		return value{typ: shaderir.Array, elems: elems}
+	}
+	return value{typ: t.Main}
+}
+
+func constantValue(c constant.Value) value {
+	switch c.Kind() {
+	case constant.Bool:
+		return boolValue(constant.BoolVal(c))
+	case constant.Int:
+		i, _ := constant.Int64Val(c)
+		return value{typ: shaderir.Int, i: [4]int32{int32(i)}}
+	default:
+		f, _ := constant.Float32Val(constant.ToFloat(c))
+		return value{typ: shaderir.Float, f: [16]float32{f}}
+	}
+}
+
+func uniformValue(t shaderir.Type, dwords []uint32) value {
+	if t.Main == shaderir.Array {
+		n := t.Sub[0].DwordCount()
+		elems := make([]value, t.Length)
+		for i := range elems {
+			elems[i] = uniformValue(t.Sub[0], dwords[i*n:(i+1)*n])
+		}
+		return value{typ: shaderir.Array, elems: elems}
+	}
+	v := value{typ: t.Main}
+	for i := 0; i < componentCount(t.Main); i++ {
+		if isIntType(t.Main) {
+			v.i[i] = int32(dwords[i])
+		} else {
+			v.f[i] = math.Float32frombits(dwords[i])
+		}
+	}
+	return v
+}
+
+// program is a shader program prepared for interpretation.
+type program struct {
+	ir          *shaderir.Program
+	funcs       map[int]*shaderir.Func
+	localCounts map[*shaderir.Block]int
+
+	// usesDerivatives reports whether the program calls the derivative functions (dfdx, dfdy or fwidth).
+	usesDerivatives bool
+}
+
+func newProgram(ir *shaderir.Program) (*program, error) {
+	p := &program{
+		ir:          ir,
+		funcs:       map[int]*shaderir.Func{},
+		localCounts: map[*shaderir.Block]int{},
+	}
+
+	add := func(block *shaderir.Block, paramCount int) error {
+		if block == nil {
+			return nil
+		}
+		n := paramCount
+		var err error
+		walkBlock(block, func(block *shaderir.Block) {
+			n = max(n, block.LocalVarIndexOffset+len(block.LocalVars))
+		}, func(s *shaderir.Stmt) {
+			switch s.Type {
+			case shaderir.Init:
+				n = max(n, s.InitIndex+1)
+			case shaderir.For:
+				n = max(n, s.ForVarIndex+1)
+			}
+		}, func(e *shaderir.Expr) {
+			switch e.Type {
+			case shaderir.LocalVariable:
+				n = max(n, e.Index+1)
+			case shaderir.StructMember:
+				if err == nil {
+					err = fmt.Errorf("software: structs are not supported")
+				}
+			case shaderir.BuiltinFuncExpr:
+				switch e.BuiltinFunc {
+				case shaderir.Dfdx, shaderir.Dfdy, shaderir.Fwidth:
+					p.usesDerivatives = true
+				}
+			}
+		})
+		if err != nil {
+			return err
+		}
+		p.localCounts[block] = n
+		return nil
+	}
+
+	if err := add(ir.VertexFunc.Block, len(ir.Attributes)+1+len(ir.Varyings)); err != nil {
+		return nil, err
+	}
+	if err := add(ir.FragmentFunc.Block, 1+len(ir.Varyings)); err != nil {
+		return nil, err
+	}
+	for i := range ir.Funcs {
+		f := &ir.Funcs[i]
+		p.funcs[f.Index] = f
+		if err := add(f.Block, len(f.InParams)+len(f.OutParams)); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func walkBlock(block *shaderir.Block, fb func(block *shaderir.Block), fs func(s *shaderir.Stmt), fe func(e *shaderir.Expr)) {
+	fb(block)
+	for i := range block.Stmts {
+		s := &block.Stmts[i]
+		fs(s)
+		for j := range s.Exprs {
+			walkExpr(&s.Exprs[j], fe)
+		}
+		for _, b := range s.Blocks {
+			walkBlock(b, fb, fs, fe)
+		}
+	}
+}
+
+func walkExpr(expr *shaderir.Expr, f func(e *shaderir.Expr)) {
+	f(expr)
+	for i := range expr.Exprs {
+		walkExpr(&expr.Exprs[i], f)
+	}
+}
+
+type flow int
+
+const (
+	flowNext flow = iota
+	flowBreak
+	flowContinue
+	flowReturn
+	flowDiscard
+)
+
+// interpreter executes a shader program for one draw call.
+type interpreter struct {
+	program   *program
+	uniforms  []value
+	textures  [graphics.ShaderSrcImageCount]*Image
+	discarded bool
+
+	// derivativeArgs is the arguments of the derivative function calls in the current invocation, in the call order.
+	derivativeArgs []value
+
+	// derivatives is the derivatives returned by the derivative function calls in the current invocation,
+	// in the call order.
+	derivatives []derivative
+}
+
+// derivative is a pair of the partial derivatives of a value in the window coordinates.
+type derivative struct {
+	dx value
+	dy value
+}
+
+func newInterpreter(program *program, uniforms []uint32, textures [graphics.ShaderSrcImageCount]*Image) *interpreter {
+	it := &interpreter{
+		program:  program,
+		uniforms: make([]value, len(program.ir.Uniforms)),
+		textures: textures,
+	}
+	var idx int
+	for i, t := range program.ir.Uniforms {
+		n := t.DwordCount()
+		it.uniforms[i] = uniformValue(t, uniforms[idx:idx+n])
+		idx += n
+	}
+	return it
+}
+
+// vertex executes the vertex shader with the given attributes.
+// vertex returns the position and the varying variables.
+func (it *interpreter) vertex(attributes []float32) (value, []value) {
+	ir := it.program.ir
+	block := ir.VertexFunc.Block
+	na := len(ir.Attributes)
+
+	locals := make([]value, it.program.localCounts[block])
+	var idx int
+	for i, t := range ir.Attributes {
+		v := value{typ: t.Main}
+		n := componentCount(t.Main)
+		copy(v.f[:n], attributes[idx:idx+n])
+		idx += n
+		locals[i] = v
+	}
+	locals[na] = value{typ: shaderir.Vec4}
+	for i, t := range ir.Varyings {
+		locals[na+1+i] = zeroValue(t)
+	}
+
+	it.execBlock(block, block, locals)
+	return locals[na], locals[na+1 : na+1+len(ir.Varyings)]
+}
+
+// fragment executes the fragment shader with the given fragment coordinate and varying variables.
+// fragment returns false if the fragment is discarded.
+func (it *interpreter) fragment(position value, varyings []value) (value, bool) {
+	block := it.program.ir.FragmentFunc.Block
+
+	locals := make([]value, it.program.localCounts[block])
+	locals[0] = position
+	copy(locals[1:], varyings)
+
+	it.discarded = false
+	_, v := it.execBlock(block, block, locals)
+	if it.discarded {
+		return value{}, false
+	}
+	return v, true
+}
+
+// fragmentQuad executes the fragment shader for a 2x2 quad of pixels in the order of
+// (x, y), (x+1, y), (x, y+1) and (x+1, y+1).
+//
+// The derivative functions are calculated as the differences between the neighboring pixels in the quad, as GPUs do.
+// As the invocations are executed one by one, the quad is executed repeatedly:
+// each execution calculates the derivatives from the arguments recorded in the previous execution.
+// The k-th derivative is correct after k+1 executions, and the executions stop when the derivatives don't change or
+// all the derivatives are correct.
+func (it *interpreter) fragmentQuad(positions [4]value, varyings [4][]value) ([4]value, [4]bool) {
+	var clrs [4]value
+	var oks [4]bool
+	var derivatives [4][]derivative
+	for count := 1; ; count++ {
+		var args [4][]value
+		var callCount int
+		for lane := range 4 {
+			it.derivativeArgs = nil
+			it.derivatives = derivatives[lane]
+			clrs[lane], oks[lane] = it.fragment(positions[lane], varyings[lane])
+			args[lane] = it.derivativeArgs
+			callCount = max(callCount, len(args[lane]))
+		}
+		if count > callCount {
+			break
+		}
+
+		var changed bool
+		for lane := range 4 {
+			// dfdx is the difference in the same row, and dfdy is the difference in the same column.
+			x0, x1 := lane&^1, lane|1
+			y0, y1 := lane&^2, lane|2
+			n := min(len(args[x0]), len(args[x1]), len(args[y0]), len(args[y1]))
+			ds := make([]derivative, len(args[lane]))
+			for k := range ds {
+				if k >= n {
+					// The neighbor pixels didn't reach this call due to the divergent control flow.
+					// The result is undefined in GLSL. Use 0 to be deterministic.
+					ds[k] = derivative{dx: zeroDerivative(args[lane][k]), dy: zeroDerivative(args[lane][k])}
+					continue
+				}
+				ds[k] = derivative{
+					dx: difference(args[x1][k], args[x0][k]),
+					dy: difference(args[y1][k], args[y0][k]),
+				}
+			}
+			if !sameDerivatives(ds, derivatives[lane]) {
+				changed = true
+			}
+			derivatives[lane] = ds
+		}
+		if !changed {
+			break
+		}
+	}
+
+	it.derivativeArgs = nil
+	it.derivatives = nil
+	return clrs, oks
+}
+
+func zeroDerivative(v value) value {
+	return value{typ: v.typ}
+}
+
+func difference(lhs, rhs value) value {
+	r := value{typ: lhs.typ}
+	for i := 0; i < componentCount(lhs.typ); i++ {
+		r.f[i] = lhs.f[i] - rhs.f[i]
+	}
+	return r
+}
+
+func sameDerivatives(lhs, rhs []derivative) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		if lhs[i].dx.f != rhs[i].dx.f || lhs[i].dy.f != rhs[i].dy.f {
+			return false
+		}
+	}
+	return true
+}
+
+// callDerivative calls the derivative function f with the argument v.
+func (it *interpreter) callDerivative(f shaderir.BuiltinFunc, v value) value {
+	k := len(it.derivativeArgs)
+	it.derivativeArgs = append(it.derivativeArgs, v)
+	if k >= len(it.derivatives) {
+		// The derivative is not calculated yet. See fragmentQuad.
+		return zeroDerivative(v)
+	}
+
+	d := it.derivatives[k]
+	switch f {
+	case shaderir.Dfdx:
+		return d.dx
+	case shaderir.Dfdy:
+		return d.dy
+	case shaderir.Fwidth:
+		r := value{typ: v.typ}
+		for i := 0; i < componentCount(v.typ); i++ {
+			r.f[i] = float32(math.Abs(float64(d.dx.f[i]))) + float32(math.Abs(float64(d.dy.f[i])))
+		}
+		return r
+	}
+	panic(fmt.Sprintf("software: unexpected derivative function: %s", f))
+}
+
+func (it *interpreter) execBlock(topBlock, block *shaderir.Block, locals []value) (flow, value) {
+	for i, t := range block.LocalVars {
+		locals[block.LocalVarIndexOffset+i] = zeroValue(t)
+	}
+
+	for i := range block.Stmts {
+		s := &block.Stmts[i]
+		switch s.Type {
+		case shaderir.ExprStmt:
+			it.eval(locals, &s.Exprs[0])
+		case shaderir.BlockStmt:
+			if f, v := it.execBlock(topBlock, s.Blocks[0], locals); f != flowNext {
+				return f, v
+			}
+		case shaderir.Assign:
+			it.assign(locals, &s.Exprs[0], it.eval(locals, &s.Exprs[1]))
+		case shaderir.Init:
+			locals[s.InitIndex] = zeroValue(it.program.ir.LocalVariableType(topBlock, block, s.InitIndex))
+		case shaderir.If:
+			var b *shaderir.Block
+			if it.eval(locals, &s.Exprs[0]).b {
+				b = s.Blocks[0]
+			} else if len(s.Blocks) > 1 {
+				b = s.Blocks[1]
+			}
+			if b != nil {
+				if f, v := it.execBlock(topBlock, b, locals); f != flowNext {
+					return f, v
+				}
+			}
+		case shaderir.For:
+			init, end, delta := constantValue(s.ForInit), constantValue(s.ForEnd), constantValue(s.ForDelta)
+			if s.ForVarType.Main == shaderir.Float {
+				init, end, delta = toFloat(init), toFloat(end), toFloat(delta)
+			}
+			locals[s.ForVarIndex] = init
+			for binary(s.ForOp, locals[s.ForVarIndex], end).b {
+				f, v := it.execBlock(topBlock, s.Blocks[0], locals)
+				if f == flowBreak {
+					break
+				}
+				if f == flowReturn || f == flowDiscard {
+					return f, v
+				}
+				locals[s.ForVarIndex] = binary(shaderir.Add, locals[s.ForVarIndex], delta)
+			}
+		case shaderir.Continue:
+			return flowContinue, value{}
+		case shaderir.Break:
+			return flowBreak, value{}
+		case shaderir.Return:
+			if len(s.Exprs) > 0 {
+				return flowReturn, it.eval(locals, &s.Exprs[0])
+			}
+			return flowReturn, value{}
+		case shaderir.Discard:
+			it.discarded = true
+			return flowDiscard, value{}
+		default:
+			panic(fmt.Sprintf("software: unexpected statement type: %d", s.Type))
+		}
+	}
+	return flowNext, value{}
+}
+
+func (it *interpreter) eval(locals []value, e *shaderir.Expr) value {
+	switch e.Type {
+	case shaderir.NumberExpr:
+		return constantValue(e.Const)
+	case shaderir.UniformVariable:
+		return it.uniforms[e.Index]
+	case shaderir.TextureVariable:
+		return value{typ: shaderir.Texture, i: [4]int32{int32(e.Index)}}
+	case shaderir.LocalVariable:
+		return locals[e.Index]
+	case shaderir.Unary:
+		v := it.eval(locals, &e.Exprs[0])
+		switch e.Op {
+		case shaderir.Add:
+			return v
+		case shaderir.Sub:
+			for i := 0; i < componentCount(v.typ); i++ {
+				v.f[i] = -v.f[i]
+			}
+			for i := range v.i {
+				v.i[i] = -v.i[i]
+			}
+			return v
+		case shaderir.NotOp:
+			return boolValue(!v.b)
+		}
+		panic(fmt.Sprintf("software: unexpected unary operator: %d", e.Op))
+	case shaderir.Binary:
+		lhs := it.eval(locals, &e.Exprs[0])
+		// Evaluate the operators && and || in the short-circuit way.
+		switch e.Op {
+		case shaderir.AndAnd:
+			if !lhs.b {
+				return boolValue(false)
+			}
+			return boolValue(it.eval(locals, &e.Exprs[1]).b)
+		case shaderir.OrOr:
+			if lhs.b {
+				return boolValue(true)
+			}
+			return boolValue(it.eval(locals, &e.Exprs[1]).b)
+		}
+		return binary(e.Op, lhs, it.eval(locals, &e.Exprs[1]))
+	case shaderir.Selection:
+		if it.eval(locals, &e.Exprs[0]).b {
+			return it.eval(locals, &e.Exprs[1])
+		}
+		return it.eval(locals, &e.Exprs[2])
+	case shaderir.Call:
+		return it.call(locals, e.Exprs)
+	case shaderir.FieldSelector:
+		return swizzle(it.eval(locals, &e.Exprs[0]), e.Exprs[1].Swizzling)
+	case shaderir.Index:
+		return index(it.eval(locals, &e.Exprs[0]), int(it.eval(locals, &e.Exprs[1]).int(0)))
+	}
+	panic(fmt.Sprintf("software: unexpected expression type: %d", e.Type))
+}
+
+func (it *interpreter) assign(locals []value, lhs *shaderir.Expr, v value) {
+	switch lhs.Type {
+	case shaderir.LocalVariable:
+		locals[lhs.Index] = v.copy()
+	case shaderir.FieldSelector:
+		base := it.eval(locals, &lhs.Exprs[0])
+		for i, idx := range swizzleIndices(lhs.Exprs[1].Swizzling) {
+			if isIntType(base.typ) {
+				base.i[idx] = v.int(i)
+			} else {
+				base.f[idx] = v.float(i)
+			}
+		}
+		it.assign(locals, &lhs.Exprs[0], base)
+	case shaderir.Index:
+		base := it.eval(locals, &lhs.Exprs[0]).copy()
+		idx := int(it.eval(locals, &lhs.Exprs[1]).int(0))
+		switch {
+		case base.typ == shaderir.Array:
+			if idx >= 0 && idx < len(base.elems) {
+				base.elems[idx] = v.copy()
+			}
+		case isMatrixType(base.typ):
+			if n := matrixSize(base.typ); idx >= 0 && idx < n {
+				copy(base.f[idx*n:(idx+1)*n], v.f[:n])
+			}
+		default:
+			if idx >= 0 && idx < componentCount(base.typ) {
+				if isIntType(base.typ) {
+					base.i[idx] = v.int(0)
+				} else {
+					base.f[idx] = v.float(0)
+				}
+			}
+		}
+		it.assign(locals, &lhs.Exprs[0], base)
+	default:
+		panic(fmt.Sprintf("software: unexpected expression type for assignment: %d", lhs.Type))
+	}
+}
+
+func (it *interpreter) call(locals []value, exprs []shaderir.Expr) value {
+	callee := &exprs[0]
+	switch callee.Type {
+	case shaderir.BuiltinFuncExpr:
+		args := make([]value, len(exprs)-1)
+		for i := range args {
+			args[i] = it.eval(locals, &exprs[i+1])
+		}
+		return it.callBuiltin(callee.BuiltinFunc, args)
+	case shaderir.FunctionExpr:
+		f := it.program.funcs[callee.Index]
+		args := exprs[1:]
+		nin := len(f.InParams)
+
+		calleeLocals := make([]value, it.program.localCounts[f.Block])
+		for i := range f.InParams {
+			calleeLocals[i] = it.eval(locals, &args[i]).copy()
+		}
+		for i, t := range f.OutParams {
+			calleeLocals[nin+i] = zeroValue(t)
+		}
+		_, v := it.execBlock(f.Block, f.Block, calleeLocals)
+		// Out-params are always local variables given as arguments.
+		for i := range f.OutParams {
+			it.assign(locals, &args[nin+i], calleeLocals[nin+i])
+		}
+		return v
+	}
+	panic(fmt.Sprintf("software: unexpected callee type: %d", callee.Type))
+}
+
+func swizzleIndices(s string) []int {
+	for _, set := range []string{"xyzw", "rgba", "strq"} {
+		if strings.IndexByte(set, s[0]) < 0 {
+			continue
+		}
+		indices := make([]int, len(s))
+		for i := range s {
+			indices[i] = strings.IndexByte(set, s[i])
+		}
+		return indices
+	}
+	panic(fmt.Sprintf("software: unexpected swizzling: %s", s))
+}
+
+func swizzle(v value, s string) value {
+	indices := swizzleIndices(s)
+	if isIntType(v.typ) {
+		r := value{typ: intType(len(indices))}
+		for i, idx := range indices {
+			r.i[i] = v.i[idx]
+		}
+		return r
+	}
+	r := value{typ: floatType(len(indices))}
+	for i, idx := range indices {
+		r.f[i] = v.f[idx]
+	}
+	return r
+}
+
+func index(v value, idx int) value {
+	switch {
+	case v.typ == shaderir.Array:
+		if idx < 0 || idx >= len(v.elems) {
+			if len(v.elems) == 0 {
+				return value{}
+			}
+			return value{typ: v.elems[0].typ}
+		}
+		return v.elems[idx]
+	case isMatrixType(v.typ):
+		n := matrixSize(v.typ)
+		r := value{typ: floatType(n)}
+		if idx >= 0 && idx < n {
+			copy(r.f[:n], v.f[idx*n:(idx+1)*n])
+		}
+		return r
+	case isIntType(v.typ):
+		r := value{typ: shaderir.Int}
+		if idx >= 0 && idx < componentCount(v.typ) {
+			r.i[0] = v.i[idx]
+		}
+		return r
+	default:
+		r := value{typ: shaderir.Float}
+		if idx >= 0 && idx < componentCount(v.typ) {
+			r.f[0] = v.f[idx]
+		}
+		return r
+	}
+}
+
+func toFloat(v value) value {
+	if !isIntType(v.typ) {
+		return v
+	}
+	n := componentCount(v.typ)
+	r := value{typ: floatType(n)}
+	for i := 0; i < n; i++ {
+		r.f[i] = float32(v.i[i])
+	}
+	return r
+}
+
+// splat returns a value of the type t whose all the components are the scalar v.
+func splat(v value, t shaderir.BasicType) value {
+	r := value{typ: t}
+	for i := 0; i < componentCount(t); i++ {
+		if isIntType(t) {
+			r.i[i] = v.int(0)
+		} else {
+			r.f[i] = v.float(0)
+		}
+	}
+	return r
+}
+
+func binary(op shaderir.Op, lhs, rhs value) value {
+	switch op {
+	case shaderir.LessThanOp, shaderir.LessThanEqualOp, shaderir.GreaterThanOp, shaderir.GreaterThanEqualOp:
+		var c int
+		if isIntType(lhs.typ) && isIntType(rhs.typ) {
+			c = compare(lhs.i[0], rhs.i[0])
+		} else {
+			// NaN is not less than, equal to, or greater than any values.
+			x, y := lhs.float(0), rhs.float(0)
+			if x != x || y != y {
+				return boolValue(false)
+			}
+			c = compare(x, y)
+		}
+		switch op {
+		case shaderir.LessThanOp:
+			return boolValue(c < 0)
+		case shaderir.LessThanEqualOp:
+			return boolValue(c <= 0)
+		case shaderir.GreaterThanOp:
+			return boolValue(c > 0)
+		default:
+			return boolValue(c >= 0)
+		}
+	case shaderir.EqualOp, shaderir.VectorEqualOp:
+		return boolValue(equal(lhs, rhs))
+	case shaderir.NotEqualOp, shaderir.VectorNotEqualOp:
+		return boolValue(!equal(lhs, rhs))
+	case shaderir.MatrixMul:
+		switch {
+		case isMatrixType(lhs.typ) && isMatrixType(rhs.typ):
+			n := matrixSize(lhs.typ)
+			r := value{typ: lhs.typ}
+			for c := 0; c < n; c++ {
+				for row := 0; row < n; row++ {
+					var s float32
+					for k := 0; k < n; k++ {
+						s += float32(lhs.f[k*n+row] * rhs.f[c*n+k])
+					}
+					r.f[c*n+row] = s
+				}
+			}
+			return r
+		case isMatrixType(lhs.typ) && !isScalarType(rhs.typ):
+			n := matrixSize(lhs.typ)
+			r := value{typ: floatType(n)}
+			for row := 0; row < n; row++ {
+				var s float32
+				for k := 0; k < n; k++ {
+					s += float32(lhs.f[k*n+row] * rhs.float(k))
+				}
+				r.f[row] = s
+			}
+			return r
+		case isMatrixType(rhs.typ) && !isScalarType(lhs.typ):
+			n := matrixSize(rhs.typ)
+			r := value{typ: floatType(n)}
+			for c := 0; c < n; c++ {
+				var s float32
+				for k := 0; k < n; k++ {
+					s += float32(lhs.float(k) * rhs.f[c*n+k])
+				}
+				r.f[c] = s
+			}
+			return r
+		}
+		// A matrix and a scalar are multiplied component-wise.
+		return componentWise(shaderir.ComponentWiseMul, lhs, rhs)
+	}
+	return componentWise(op, lhs, rhs)
+}
+
+func compare[T int32 | float32](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func equal(lhs, rhs value) bool {
+	if lhs.typ == shaderir.Bool || rhs.typ == shaderir.Bool {
+		return lhs.b == rhs.b
+	}
+	for i := 0; i < componentCount(lhs.typ); i++ {
+		if isIntType(lhs.typ) && isIntType(rhs.typ) {
+			if lhs.i[i] != rhs.i[i] {
+				return false
+			}
+			continue
+		}
+		if lhs.float(i) != rhs.float(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func componentWise(op shaderir.Op, lhs, rhs value) value {
+	// Broadcast a scalar operand to the other operand's type.
+	if isScalarType(lhs.typ) && !isScalarType(rhs.typ) {
+		lhs = splat(lhs, rhs.typ)
+	} else if !isScalarType(lhs.typ) && isScalarType(rhs.typ) {
+		rhs = splat(rhs, lhs.typ)
+	}
+
+	if isIntType(lhs.typ) && isIntType(rhs.typ) {
+		r := value{typ: lhs.typ}
+		for i := 0; i < componentCount(lhs.typ); i++ {
+			r.i[i] = intBinary(op, lhs.i[i], rhs.i[i])
+		}
+		return r
+	}
+
+	lhs, rhs = toFloat(lhs), toFloat(rhs)
+	r := value{typ: lhs.typ}
+	for i := 0; i < componentCount(lhs.typ); i++ {
+		r.f[i] = floatBinary(op, lhs.f[i], rhs.f[i])
+	}
+	return r
+}
+
+func intBinary(op shaderir.Op, x, y int32) int32 {
+	switch op {
+	case shaderir.Add:
+		return x + y
+	case shaderir.Sub:
+		return x - y
+	case shaderir.ComponentWiseMul:
+		return x * y
+	case shaderir.Div:
+		// The result of division by zero is undefined in GLSL. Use 0 to be deterministic.
+		if y == 0 {
+			return 0
+		}
+		return x / y
+	case shaderir.ModOp:
+		if y == 0 {
+			return 0
+		}
+		return x % y
+	case shaderir.LeftShift:
+		return x << (uint32(y) & 31)
+	case shaderir.RightShift:
+		return x >> (uint32(y) & 31)
+	case shaderir.And:
+		return x & y
+	case shaderir.Xor:
+		return x ^ y
+	case shaderir.Or:
+		return x | y
+	}
+	panic(fmt.Sprintf("software: unexpected binary operator for integers: %d", op))
+}
+
+func floatBinary(op shaderir.Op, x, y float32) float32 {
+	// Explicit conversions prevent the compiler from fusing multiplications and additions,
+	// which would make the results platform-dependent.
+	switch op {
+	case shaderir.Add:
+		return x + y
+	case shaderir.Sub:
+		return x - y
+	case shaderir.ComponentWiseMul:
+		return float32(x * y)
+	case shaderir.Div:
+		return float32(x / y)
+	case shaderir.ModOp:
+		return float32(math.Mod(float64(x), float64(y)))
+	}
+	panic(fmt.Sprintf("software: unexpected binary operator for floats: %d", op))
+}
+
+// widestType returns the type with the most components among the arguments.
+func widestType(args []value) shaderir.BasicType {
+	t := args[0].typ
+	for _, a := range args[1:] {
+		if componentCount(a.typ) > componentCount(t) {
+			t = a.typ
+		}
+	}
+	return t
+}
+
+// mapFloats applies f to the components of the arguments, broadcasting scalar arguments.
+func mapFloats(args []value, f func(x [3]float64) float64) value {
+	n := componentCount(widestType(args))
+	r := value{typ: floatType(n)}
+	for i := 0; i < n; i++ {
+		var x [3]float64
+		for j, a := range args {
+			if isScalarType(a.typ) {
+				x[j] = float64(a.float(0))
+			} else {
+				x[j] = float64(a.float(i))
+			}
+		}
+		r.f[i] = float32(f(x))
+	}
+	return r
+}
+
+// mapInts applies f to the components of the integer arguments, broadcasting scalar arguments.
+func mapInts(args []value, f func(x [3]int32) int32) value {
+	n := componentCount(widestType(args))
+	r := value{typ: intType(n)}
+	for i := 0; i < n; i++ {
+		var x [3]int32
+		for j, a := range args {
+			if isScalarType(a.typ) {
+				x[j] = a.int(0)
+			} else {
+				x[j] = a.int(i)
+			}
+		}
+		r.i[i] = f(x)
+	}
+	return r
+}
+
+func allInts(args []value) bool {
+	for _, a := range args {
+		if !isIntType(a.typ) {
+			return false
+		}
+	}
+	return true
+}
+
+func dot(x, y value) float64 {
+	var s float64
+	for i := 0; i < componentCount(x.typ); i++ {
+		s += float64(x.float(i)) * float64(y.float(i))
+	}
+	return s
+}
+
+func scale(v value, s float64) value {
+	v = toFloat(v)
+	for i := 0; i < componentCount(v.typ); i++ {
+		v.f[i] = float32(float64(v.f[i]) * s)
+	}
+	return v
+}
+
+func construct(t shaderir.BasicType, args []value) value {
+	r := value{typ: t}
+	n := componentCount(t)
+
+	if len(args) == 1 && isScalarType(args[0].typ) {
+		if isMatrixType(t) {
+			// A scalar makes a diagonal matrix.
+			m := matrixSize(t)
+			for i := 0; i < m; i++ {
+				r.f[i*m+i] = args[0].float(0)
+			}
+			return r
+		}
+		return splat(args[0], t)
+	}
+
+	if len(args) == 1 && isMatrixType(t) && isMatrixType(args[0].typ) {
+		// A matrix is resized with the identity matrix.
+		m, am := matrixSize(t), matrixSize(args[0].typ)
+		for c := 0; c < m; c++ {
+			for row := 0; row < m; row++ {
+				switch {
+				case c < am && row < am:
+					r.f[c*m+row] = args[0].f[c*am+row]
+				case c == row:
+					r.f[c*m+row] = 1
+				}
+			}
+		}
+		return r
+	}
+
+	var idx int
+	for _, a := range args {
+		for i := 0; i < componentCount(a.typ) && idx < n; i++ {
+			if isIntType(t) {
+				r.i[idx] = a.int(i)
+			} else {
+				r.f[idx] = a.float(i)
+			}
+			idx++
+		}
+	}
+	return r
+}
+
+func (it *interpreter) callBuiltin(f shaderir.BuiltinFunc, args []value) value {
+	switch f {
+	case shaderir.Len, shaderir.Cap:
+		return value{typ: shaderir.Int, i: [4]int32{int32(len(args[0].elems))}}
+	case shaderir.BoolF:
+		if args[0].typ == shaderir.Bool {
+			return args[0]
+		}
+		return boolValue(args[0].float(0) != 0)
+	case shaderir.IntF:
+		return value{typ: shaderir.Int, i: [4]int32{args[0].int(0)}}
+	case shaderir.FloatF:
+		return value{typ: shaderir.Float, f: [16]float32{args[0].float(0)}}
+	case shaderir.Vec2F:
+		return construct(shaderir.Vec2, args)
+	case shaderir.Vec3F:
+		return construct(shaderir.Vec3, args)
+	case shaderir.Vec4F:
+		return construct(shaderir.Vec4, args)
+	case shaderir.IVec2F:
+		return construct(shaderir.IVec2, args)
+	case shaderir.IVec3F:
+		return construct(shaderir.IVec3, args)
+	case shaderir.IVec4F:
+		return construct(shaderir.IVec4, args)
+	case shaderir.Mat2F:
+		return construct(shaderir.Mat2, args)
+	case shaderir.Mat3F:
+		return construct(shaderir.Mat3, args)
+	case shaderir.Mat4F:
+		return construct(shaderir.Mat4, args)
+	case shaderir.Radians:
+		return mapFloats(args, func(x [3]float64) float64 { return x[0] * math.Pi / 180 })
+	case shaderir.Degrees:
+		return mapFloats(args, func(x [3]float64) float64 { return x[0] * 180 / math.Pi })
+	case shaderir.Sin:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Sin(x[0]) })
+	case shaderir.Cos:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Cos(x[0]) })
+	case shaderir.Tan:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Tan(x[0]) })
+	case shaderir.Asin:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Asin(x[0]) })
+	case shaderir.Acos:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Acos(x[0]) })
+	case shaderir.Atan:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Atan(x[0]) })
+	case shaderir.Atan2:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Atan2(x[0], x[1]) })
+	case shaderir.Pow:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Pow(x[0], x[1]) })
+	case shaderir.Exp:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Exp(x[0]) })
+	case shaderir.Log:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Log(x[0]) })
+	case shaderir.Exp2:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Exp2(x[0]) })
+	case shaderir.Log2:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Log2(x[0]) })
+	case shaderir.Sqrt:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Sqrt(x[0]) })
+	case shaderir.Inversesqrt:
+		return mapFloats(args, func(x [3]float64) float64 { return 1 / math.Sqrt(x[0]) })
+	case shaderir.Abs:
+		if allInts(args) {
+			return mapInts(args, func(x [3]int32) int32 { return max(x[0], -x[0]) })
+		}
+		return mapFloats(args, func(x [3]float64) float64 { return math.Abs(x[0]) })
+	case shaderir.Sign:
+		if allInts(args) {
+			return mapInts(args, func(x [3]int32) int32 { return int32(compare(x[0], 0)) })
+		}
+		return mapFloats(args, func(x [3]float64) float64 {
+			switch {
+			case x[0] > 0:
+				return 1
+			case x[0] < 0:
+				return -1
+			}
+			return 0
+		})
+	case shaderir.Floor:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Floor(x[0]) })
+	case shaderir.Ceil:
+		return mapFloats(args, func(x [3]float64) float64 { return math.Ceil(x[0]) })
+	case shaderir.Fract:
+		return mapFloats(args, func(x [3]float64) float64 { return x[0] - math.Floor(x[0]) })
+	case shaderir.Mod:
+		return mapFloats(args, func(x [3]float64) float64 { return x[0] - x[1]*math.Floor(x[0]/x[1]) })
+	case shaderir.Min:
+		if allInts(args) {
+			return mapInts(args, func(x [3]int32) int32 { return min(x[0], x[1]) })
+		}
+		return mapFloats(args, func(x [3]float64) float64 { return math.Min(x[0], x[1]) })
+	case shaderir.Max:
+		if allInts(args) {
+			return mapInts(args, func(x [3]int32) int32 { return max(x[0], x[1]) })
+		}
+		return mapFloats(args, func(x [3]float64) float64 { return math.Max(x[0], x[1]) })
+	case shaderir.Clamp:
+		if allInts(args) {
+			return mapInts(args, func(x [3]int32) int32 { return min(max(x[0], x[1]), x[2]) })
+		}
+		return mapFloats(args, func(x [3]float64) float64 { return math.Min(math.Max(x[0], x[1]), x[2]) })
+	case shaderir.Mix:
+		return mapFloats(args, func(x [3]float64) float64 { return x[0]*(1-x[2]) + x[1]*x[2] })
+	case shaderir.Step:
+		return mapFloats(args, func(x [3]float64) float64 {
+			if x[1] < x[0] {
+				return 0
+			}
+			return 1
+		})
+	case shaderir.Smoothstep:
+		return mapFloats(args, func(x [3]float64) float64 {
+			t := math.Min(math.Max((x[2]-x[0])/(x[1]-x[0]), 0), 1)
+			return t * t * (3 - 2*t)
+		})
+	case shaderir.Length:
+		return value{typ: shaderir.Float, f: [16]float32{float32(math.Sqrt(dot(args[0], args[0])))}}
+	case shaderir.Distance:
+		d := componentWise(shaderir.Sub, args[0], args[1])
+		return value{typ: shaderir.Float, f: [16]float32{float32(math.Sqrt(dot(d, d)))}}
+	case shaderir.Dot:
+		return value{typ: shaderir.Float, f: [16]float32{float32(dot(args[0], args[1]))}}
+	case shaderir.Cross:
+		x, y := args[0], args[1]
+		return value{typ: shaderir.Vec3, f: [16]float32{
+			float32(float64(x.f[1])*float64(y.f[2]) - float64(x.f[2])*float64(y.f[1])),
+			float32(float64(x.f[2])*float64(y.f[0]) - float64(x.f[0])*float64(y.f[2])),
+			float32(float64(x.f[0])*float64(y.f[1]) - float64(x.f[1])*float64(y.f[0])),
+		}}
+	case shaderir.Normalize:
+		return scale(args[0], 1/math.Sqrt(dot(args[0], args[0])))
+	case shaderir.Faceforward:
+		if dot(args[2], args[1]) < 0 {
+			return args[0]
+		}
+		return scale(args[0], -1)
+	case shaderir.Reflect:
+		i, n := args[0], args[1]
+		return componentWise(shaderir.Sub, i, scale(n, 2*dot(n, i)))
+	case shaderir.Refract:
+		i, n, eta := args[0], args[1], float64(args[2].float(0))
+		d := dot(n, i)
+		k := 1 - eta*eta*(1-d*d)
+		if k < 0 {
+			return value{typ: i.typ}
+		}
+		return componentWise(shaderir.Sub, scale(i, eta), scale(n, eta*d+math.Sqrt(k)))
+	case shaderir.Transpose:
+		m := args[0]
+		n := matrixSize(m.typ)
+		r := value{typ: m.typ}
+		for c := 0; c < n; c++ {
+			for row := 0; row < n; row++ {
+				r.f[c*n+row] = m.f[row*n+c]
+			}
+		}
+		return r
+	case shaderir.TexelAt:
+		return it.texelAt(int(args[0].i[0]), args[1])
+	case shaderir.Dfdx, shaderir.Dfdy, shaderir.Fwidth:
+		return it.callDerivative(f, args[0])
+	}
+	panic(fmt.Sprintf("software: unexpected builtin function: %s", f))
+}
+
+// texelAt returns the color at the given position of the texture in [0, 1].
+func (it *interpreter) texelAt(textureIndex int, pos value) value {
+	r := value{typ: shaderir.Vec4}
+	img := it.textures[textureIndex]
+	if img == nil {
+		return r
+	}
+
+	x, y := float64(pos.f[0]), float64(pos.f[1])
+	if x != x || y != y {
+		return r
+	}
+
+	switch it.program.ir.Unit {
+	case shaderir.Pixels:
+		// This is equivalent to texelFetch. The result for an out-of-range position is undefined in GLSL.
+		// Use 0 to be deterministic.
+		x, y = math.Trunc(x), math.Trunc(y)
+		if x < 0 || y < 0 || x >= float64(img.width) || y >= float64(img.height) {
+			return r
+		}
+	case shaderir.Texels:
+		// Sample the nearest texel with clamping to the edges, as the other drivers do.
+		x = math.Min(math.Max(math.Floor(x*float64(img.width)), 0), float64(img.width-1))
+		y = math.Min(math.Max(math.Floor(y*float64(img.height)), 0), float64(img.height-1))
+	}
+
+	idx := 4 * (int(y)*img.width + int(x))
+	for i := range 4 {
+		r.f[i] = float32(img.pixels[idx+i]) / 255
+	}
+	return r
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"math"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/atlas"
 	"github.com/hajimehoshi/ebiten/v2/internal/buffered"
@@ -34,6 +35,22 @@ func canUseMipmap(imageType atlas.ImageType) bool {
 	return false
 }
 
+// DefaultMaxLevel is the default maximum mipmap level.
+const DefaultMaxLevel = 6
+
+var disabled atomic.Bool
+
+// SetEnabled enables or disables mipmaps globally.
+// While mipmaps are disabled, no new mipmap images are generated and the level 0 image is always used.
+func SetEnabled(enabled bool) {
+	disabled.Store(!enabled)
+}
+
+// IsEnabled reports whether mipmaps are enabled globally.
+func IsEnabled() bool {
+	return !disabled.Load()
+}
+
 // Mipmap is a set of buffered.Image sorted by the order of mipmap level.
 // The level 0 image is a regular image and higher-level images are used for mipmap.
 type Mipmap struct {
@@ -42,6 +59,7 @@ type Mipmap struct {
 	imageType atlas.ImageType
 	orig      *buffered.Image
 	imgs      map[int]imageWithDirtyFlag
+	maxLevel  int
 }
 
 type imageWithDirtyFlag struct {
@@ -55,6 +73,22 @@ func New(width, height int, imageType atlas.ImageType) *Mipmap {
 		height:    height,
 		orig:      buffered.NewImage(width, height, imageType),
 		imageType: imageType,
+		maxLevel:  DefaultMaxLevel,
+	}
+}
+
+// SetMaxLevel sets the maximum mipmap level used when the image is a rendering source.
+// If level is 0, mipmaps are never generated for the image.
+func (m *Mipmap) SetMaxLevel(level int) {
+	m.maxLevel = level
+	for l, img := range m.imgs {
+		if l <= level {
+			continue
+		}
+		if img.img != nil {
+			img.img.Deallocate()
+		}
+		delete(m.imgs, l)
 	}
 }
 
@@ -78,13 +112,13 @@ func (m *Mipmap) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byt
 	return m.orig.ReadPixels(graphicsDriver, pixels, region)
 }
 
-func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, hint restorable.Hint) {
+func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *atlas.Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, mipmapLevel int, hint restorable.Hint) {
 	if len(indices) == 0 {
 		return
 	}
 
 	// Use the fast path if mipmap is not used.
-	if canSkipMipmap || srcs[0] == nil || !canUseMipmap(srcs[0].imageType) {
+	if canSkipMipmap || srcs[0] == nil || !canUseMipmap(srcs[0].imageType) || disabled.Load() {
 		var imgs [graphics.ShaderSrcImageCount]*buffered.Image
 		for i, src := range srcs {
 			if src == nil {
@@ -98,35 +132,46 @@ func (m *Mipmap) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Mipmap, verti
 	}
 
 	level := math.MaxInt32
-	for i := 0; i < len(indices); i += 3 {
-		idx0 := indices[i]
-		idx1 := indices[i+1]
-		idx2 := indices[i+2]
-		dx0 := vertices[graphics.VertexFloatCount*idx0]
-		dy0 := vertices[graphics.VertexFloatCount*idx0+1]
-		sx0 := vertices[graphics.VertexFloatCount*idx0+2]
-		sy0 := vertices[graphics.VertexFloatCount*idx0+3]
-		dx1 := vertices[graphics.VertexFloatCount*idx1]
-		dy1 := vertices[graphics.VertexFloatCount*idx1+1]
-		sx1 := vertices[graphics.VertexFloatCount*idx1+2]
-		sy1 := vertices[graphics.VertexFloatCount*idx1+3]
-		dx2 := vertices[graphics.VertexFloatCount*idx2]
-		dy2 := vertices[graphics.VertexFloatCount*idx2+1]
-		sx2 := vertices[graphics.VertexFloatCount*idx2+2]
-		sy2 := vertices[graphics.VertexFloatCount*idx2+3]
-		if l := mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1); level > l {
-			level = l
-		}
-		if l := mipmapLevelFromDistance(dx1, dy1, dx2, dy2, sx1, sy1, sx2, sy2); level > l {
-			level = l
-		}
-		if l := mipmapLevelFromDistance(dx2, dy2, dx0, dy0, sx2, sy2, sx0, sy0); level > l {
-			level = l
+	if mipmapLevel > 0 {
+		// Use the specified level regardless of the scale.
+		level = mipmapLevel
+	} else {
+		for i := 0; i < len(indices); i += 3 {
+			idx0 := indices[i]
+			idx1 := indices[i+1]
+			idx2 := indices[i+2]
+			dx0 := vertices[graphics.VertexFloatCount*idx0]
+			dy0 := vertices[graphics.VertexFloatCount*idx0+1]
+			sx0 := vertices[graphics.VertexFloatCount*idx0+2]
+			sy0 := vertices[graphics.VertexFloatCount*idx0+3]
+			dx1 := vertices[graphics.VertexFloatCount*idx1]
+			dy1 := vertices[graphics.VertexFloatCount*idx1+1]
+			sx1 := vertices[graphics.VertexFloatCount*idx1+2]
+			sy1 := vertices[graphics.VertexFloatCount*idx1+3]
+			dx2 := vertices[graphics.VertexFloatCount*idx2]
+			dy2 := vertices[graphics.VertexFloatCount*idx2+1]
+			sx2 := vertices[graphics.VertexFloatCount*idx2+2]
+			sy2 := vertices[graphics.VertexFloatCount*idx2+3]
+			if l := mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1); level > l {
+				level = l
+			}
+			if l := mipmapLevelFromDistance(dx1, dy1, dx2, dy2, sx1, sy1, sx2, sy2); level > l {
+				level = l
+			}
+			if l := mipmapLevelFromDistance(dx2, dy2, dx0, dy0, sx2, sy2, sx0, sy0); level > l {
+				level = l
+			}
 		}
 	}
 	if level == math.MaxInt32 {
 		panic("mipmap: level must be calculated at least once but not")
 	}
+	for _, src := range srcs {
+		if src == nil {
+			continue
+		}
+		level = min(level, src.maxLevel)
+	}
 
 	var imgs [graphics.ShaderSrcImageCount]*buffered.Image
 	for i, src := range srcs {
@@ -252,10 +297,18 @@ func (m *Mipmap) Deallocate() {
 	m.orig.Deallocate()
 }
 
+// Repack moves the level 0 images of the given mipmaps into new tightly-packed atlases.
+// See atlas.Repack.
+func Repack(mipmaps []*Mipmap) {
+	imgs := make([]*buffered.Image, 0, len(mipmaps))
+	for _, m := range mipmaps {
+		imgs = append(imgs, m.orig)
+	}
+	buffered.Repack(imgs)
+}
+
 // mipmapLevel returns an appropriate mipmap level for the given distance.
 func mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1 float32) int {
-	const maxLevel = 6
-
 	d := (dx1-dx0)*(dx1-dx0) + (dy1-dy0)*(dy1-dy0)
 	s := (sx1-sx0)*(sx1-sx0) + (sy1-sy0)*(sy1-sy0)
 	if s == 0 {
@@ -297,8 +350,8 @@ func mipmapLevelFromDistance(dx0, dy0, dx1, dy1, sx0, sy0, sx1, sy1 float32) int
 		}
 	}
 
-	if level > maxLevel {
-		level = maxLevel
+	if level > DefaultMaxLevel {
+		level = DefaultMaxLevel
 	}
 
 	return level
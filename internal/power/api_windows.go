@@ -0,0 +1,58 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !playstation5
+
+package power
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	_AC_LINE_OFFLINE = 0
+
+	_BATTERY_FLAG_NO_SYSTEM_BATTERY = 128
+	_BATTERY_FLAG_UNKNOWN           = 255
+
+	_SYSTEM_STATUS_FLAG_BATTERY_SAVER_ON = 1
+)
+
+type _SYSTEM_POWER_STATUS struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+var (
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+
+	procGetSystemPowerStatus = kernel32.NewProc("GetSystemPowerStatus")
+)
+
+func _GetSystemPowerStatus(status *_SYSTEM_POWER_STATUS) error {
+	r, _, e := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(status)))
+	if int32(r) == 0 {
+		if e != nil && e != windows.ERROR_SUCCESS {
+			return e
+		}
+		return windows.ERROR_INVALID_FUNCTION
+	}
+	return nil
+}
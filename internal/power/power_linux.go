@@ -0,0 +1,69 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !android && !nintendosdk && !playstation5
+
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	powerSupplyDir  = "/sys/class/power_supply"
+	platformProfile = "/sys/firmware/acpi/platform_profile"
+)
+
+func State() (onBattery bool, lowPowerMode bool, ok bool) {
+	entries, err := os.ReadDir(powerSupplyDir)
+	if err != nil {
+		return false, false, false
+	}
+
+	var hasBattery, hasOnlineMains bool
+	for _, e := range entries {
+		typ, err := readSysfs(filepath.Join(powerSupplyDir, e.Name(), "type"))
+		if err != nil {
+			continue
+		}
+		switch typ {
+		case "Battery":
+			// Peripheral batteries like a mouse's are reported with scope "Device".
+			if scope, err := readSysfs(filepath.Join(powerSupplyDir, e.Name(), "scope")); err == nil && scope == "Device" {
+				continue
+			}
+			hasBattery = true
+		case "Mains", "USB":
+			if online, err := readSysfs(filepath.Join(powerSupplyDir, e.Name(), "online")); err == nil && online == "1" {
+				hasOnlineMains = true
+			}
+		}
+	}
+
+	if profile, err := readSysfs(platformProfile); err == nil {
+		lowPowerMode = profile == "low-power" || profile == "quiet"
+	}
+
+	return hasBattery && !hasOnlineMains, lowPowerMode, true
+}
+
+func readSysfs(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
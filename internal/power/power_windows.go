@@ -0,0 +1,30 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nintendosdk && !playstation5
+
+package power
+
+func State() (onBattery bool, lowPowerMode bool, ok bool) {
+	var status _SYSTEM_POWER_STATUS
+	if err := _GetSystemPowerStatus(&status); err != nil {
+		return false, false, false
+	}
+	if status.BatteryFlag == _BATTERY_FLAG_UNKNOWN {
+		return false, false, false
+	}
+	onBattery = status.BatteryFlag != _BATTERY_FLAG_NO_SYSTEM_BATTERY && status.ACLineStatus == _AC_LINE_OFFLINE
+	lowPowerMode = status.SystemStatusFlag&_SYSTEM_STATUS_FLAG_BATTERY_SAVER_ON != 0
+	return onBattery, lowPowerMode, true
+}
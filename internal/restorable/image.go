@@ -183,6 +183,42 @@ func NewImage(width, height int, imageType ImageType) *Image {
 	return i
 }
 
+// NewImageWithPixels creates a new image with the given size and the initial pixels.
+//
+// The length of pixels must be 4*width*height.
+// Unlike NewImage followed by WritePixels, NewImageWithPixels doesn't clear the region covered by the pixels.
+// Only the padding region outside of the image size is cleared.
+func NewImageWithPixels(width, height int, pixels []byte, imageType ImageType) *Image {
+	if !graphicsDriverInitialized {
+		panic("restorable: graphics driver must be ready at NewImageWithPixels but not")
+	}
+	if len(pixels) != 4*width*height {
+		panic(fmt.Sprintf("restorable: len(pixels) must be %d but %d", 4*width*height, len(pixels)))
+	}
+
+	i := &Image{
+		image:     graphicscommand.NewImage(width, height, imageType == ImageTypeScreen, ""),
+		width:     width,
+		height:    height,
+		imageType: imageType,
+	}
+
+	// Clear the padding region explicitly so that the edges are defined.
+	iw, ih := i.image.InternalSize()
+	if iw > width {
+		clearImage(i.image, image.Rect(width, 0, iw, ih))
+	}
+	if ih > height {
+		clearImage(i.image, image.Rect(0, height, width, ih))
+	}
+	theImages.add(i)
+
+	i.WritePixels(graphics.NewManagedBytes(len(pixels), func(dst []byte) {
+		copy(dst, pixels)
+	}), image.Rect(0, 0, width, height))
+	return i
+}
+
 // Extend extends the image by the given size.
 // Extend creates a new image with the given size and copies the pixels of the given source image.
 // Extend disposes itself after its call.
@@ -480,7 +516,17 @@ func (i *Image) readPixelsFromGPUIfNeeded(graphicsDriver graphicsdriver.Graphics
 	return nil
 }
 
+// ReadPixels reads the pixels in the given region of the image.
+//
+// Only the given region is read, and the region doesn't have to be the whole image.
+// len(pixels) must be 4*region.Dx()*region.Dy().
+//
+// If the region is not in the image bounds, ReadPixels panics.
 func (i *Image) ReadPixels(graphicsDriver graphicsdriver.Graphics, pixels []byte, region image.Rectangle) error {
+	if !region.In(image.Rect(0, 0, i.width, i.height)) {
+		panic(fmt.Sprintf("restorable: the region %v must be in the image bounds %v at ReadPixels", region, image.Rect(0, 0, i.width, i.height)))
+	}
+
 	if AlwaysReadPixelsFromGPU() || !i.needsRestoration() {
 		if err := i.image.ReadPixels(graphicsDriver, []graphicsdriver.PixelsArgs{
 			{
@@ -83,6 +83,11 @@ func SwapBuffers(graphicsDriver graphicsdriver.Graphics) error {
 	return resolveStaleImages(graphicsDriver, true)
 }
 
+// FlushCommands flushes the queued draw commands without ending the current frame.
+func FlushCommands(graphicsDriver graphicsdriver.Graphics) error {
+	return graphicscommand.FlushCommands(graphicsDriver, false)
+}
+
 // resolveStaleImages flushes the queued draw commands and resolves all stale images.
 // If endFrame is true, the current screen might be used to present when flushing the commands.
 func resolveStaleImages(graphicsDriver graphicsdriver.Graphics, endFrame bool) error {
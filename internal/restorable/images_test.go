@@ -1187,3 +1187,131 @@ func TestOverwriteDstRegion(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestReadPixelsRegion(t *testing.T) {
+	for _, size := range []image.Point{{3, 5}, {17, 31}, {100, 7}, {129, 65}} {
+		w, h := size.X, size.Y
+		img := restorable.NewImage(w, h, restorable.ImageTypeRegular)
+
+		src := make([]byte, 4*w*h)
+		for i := range src {
+			src[i] = byte(i)
+		}
+		img.WritePixels(bytesToManagedBytes(src), image.Rect(0, 0, w, h))
+
+		full := make([]byte, 4*w*h)
+		if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), full, image.Rect(0, 0, w, h)); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range []image.Rectangle{
+			image.Rect(0, 0, 1, 1),
+			image.Rect(1, 2, w, h),
+			image.Rect(w/2, h/3, w-1, h-1),
+			image.Rect(w-1, h-1, w, h),
+		} {
+			pix := make([]byte, 4*r.Dx()*r.Dy())
+			if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), pix, r); err != nil {
+				t.Fatal(err)
+			}
+			for j := r.Min.Y; j < r.Max.Y; j++ {
+				for i := r.Min.X; i < r.Max.X; i++ {
+					idx := 4 * ((j-r.Min.Y)*r.Dx() + i - r.Min.X)
+					got := color.RGBA{R: pix[idx], G: pix[idx+1], B: pix[idx+2], A: pix[idx+3]}
+					idx = 4 * (j*w + i)
+					want := color.RGBA{R: full[idx], G: full[idx+1], B: full[idx+2], A: full[idx+3]}
+					if got != want {
+						t.Errorf("size: %v, region: %v, (%d, %d): got: %v, want: %v", size, r, i, j, got, want)
+					}
+				}
+			}
+		}
+
+		img.Dispose()
+	}
+}
+
+func TestReadPixelsRegionOutOfBounds(t *testing.T) {
+	const w, h = 3, 5
+	img := restorable.NewImage(w, h, restorable.ImageTypeRegular)
+	defer img.Dispose()
+
+	defer func() {
+		if e := recover(); e == nil {
+			t.Errorf("ReadPixels must panic but not")
+		}
+	}()
+	pix := make([]byte, 4*2*2)
+	_ = img.ReadPixels(ui.Get().GraphicsDriverForTesting(), pix, image.Rect(w-1, h-1, w+1, h+1))
+}
+
+func TestNewImageWithPixels(t *testing.T) {
+	const (
+		w = 17
+		h = 31
+	)
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = byte(i)
+	}
+	img := restorable.NewImageWithPixels(w, h, pix, restorable.ImageTypeRegular)
+	defer img.Dispose()
+
+	for k := 0; k < 2; k++ {
+		if k == 1 {
+			if err := restorable.ResolveStaleImages(ui.Get().GraphicsDriverForTesting()); err != nil {
+				t.Fatal(err)
+			}
+			if err := restorable.RestoreIfNeeded(ui.Get().GraphicsDriverForTesting()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got := make([]byte, 4*w*h)
+		if err := img.ReadPixels(ui.Get().GraphicsDriverForTesting(), got, image.Rect(0, 0, w, h)); err != nil {
+			t.Fatal(err)
+		}
+		for i := range got {
+			if got[i] != pix[i] {
+				t.Errorf("pix[%d]: got: %d, want: %d", i, got[i], pix[i])
+			}
+		}
+	}
+}
+
+func benchmarkNewImage(b *testing.B, withPixels bool) {
+	const (
+		w = 64
+		h = 64
+	)
+	pix := make([]byte, 4*w*h)
+	for i := range pix {
+		pix[i] = 0xff
+	}
+	imgs := make([]*restorable.Image, 0, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var img *restorable.Image
+		if withPixels {
+			img = restorable.NewImageWithPixels(w, h, pix, restorable.ImageTypeRegular)
+		} else {
+			img = restorable.NewImage(w, h, restorable.ImageTypeRegular)
+			img.WritePixels(bytesToManagedBytes(pix), image.Rect(0, 0, w, h))
+		}
+		imgs = append(imgs, img)
+	}
+	if err := restorable.ResolveStaleImages(ui.Get().GraphicsDriverForTesting()); err != nil {
+		b.Fatal(err)
+	}
+	b.StopTimer()
+	for _, img := range imgs {
+		img.Dispose()
+	}
+}
+
+func BenchmarkNewImageAndWritePixels(b *testing.B) {
+	benchmarkNewImage(b, false)
+}
+
+func BenchmarkNewImageWithPixels(b *testing.B) {
+	benchmarkNewImage(b, true)
+}
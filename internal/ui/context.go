@@ -246,10 +246,7 @@ func (c *context) drawGame(graphicsDriver graphicsdriver.Graphics, ui *UserInter
 		return false, nil
 	}
 
-	if graphicsDriver.NeedsClearingScreen() {
-		// This clear is needed for fullscreen mode or some mobile platforms (#622).
-		c.screen.clear()
-	}
+	ui.clearScreen(c.screen, graphicsDriver.NeedsClearingScreen())
 
 	c.game.DrawFinalScreen(c.screenScaleAndOffsets())
 
@@ -54,6 +54,9 @@ type Image struct {
 	// modifyCallback is useful to detect whether the image is manipulated or not after a certain time.
 	modifyCallback func()
 
+	// modificationCount is incremented whenever the image's pixels might be changed.
+	modificationCount uint64
+
 	tmpVerticesForFill []float32
 }
 
@@ -68,20 +71,48 @@ func (u *UserInterface) NewImage(width, height int, imageType atlas.ImageType) *
 	}
 }
 
+// SetMaxMipmapLevel sets the maximum mipmap level used when the image is a rendering source.
+// A negative level means that no mipmaps are used.
+func (i *Image) SetMaxMipmapLevel(level int) {
+	i.mipmap.SetMaxLevel(max(min(level, mipmap.DefaultMaxLevel), 0))
+}
+
 func (i *Image) Deallocate() {
 	if i.mipmap == nil {
 		return
 	}
+	i.modificationCount++
 	if i.bigOffscreenBuffer != nil {
 		i.bigOffscreenBuffer.deallocate()
 	}
 	i.mipmap.Deallocate()
 }
 
-func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, antialias bool, hint restorable.Hint) {
+// ModificationCount returns a counter that is incremented whenever the image's pixels might be changed.
+// A caller can cache a result computed from the pixels as long as ModificationCount returns the same value.
+func (i *Image) ModificationCount() uint64 {
+	return i.modificationCount
+}
+
+// RepackImages moves the given images into new tightly-packed atlases.
+// See atlas.Repack.
+func RepackImages(images []*Image) {
+	mipmaps := make([]*mipmap.Mipmap, 0, len(images))
+	for _, i := range images {
+		if i.mipmap == nil {
+			continue
+		}
+		i.flushBufferIfNeeded()
+		mipmaps = append(mipmaps, i.mipmap)
+	}
+	mipmap.Repack(mipmaps)
+}
+
+func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, mipmapLevel int, antialias bool, hint restorable.Hint) {
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
+	i.modificationCount++
 
 	i.lastBlend = blend
 
@@ -99,7 +130,7 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 			i.bigOffscreenBuffer = i.ui.newBigOffscreenImage(i, imageType)
 		}
 
-		i.bigOffscreenBuffer.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap)
+		i.bigOffscreenBuffer.drawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, mipmapLevel)
 		return
 	}
 
@@ -114,13 +145,14 @@ func (i *Image) DrawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertice
 		srcMipmaps[i] = src.mipmap
 	}
 
-	i.mipmap.DrawTriangles(srcMipmaps, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule, canSkipMipmap, hint)
+	i.mipmap.DrawTriangles(srcMipmaps, vertices, indices, blend, dstRegion, srcRegions, shader.shader, uniforms, fillRule, canSkipMipmap, mipmapLevel, hint)
 }
 
 func (i *Image) WritePixels(pix []byte, region image.Rectangle) {
 	if i.modifyCallback != nil {
 		i.modifyCallback()
 	}
+	i.modificationCount++
 	i.flushBufferIfNeeded()
 	i.mipmap.WritePixels(pix, region)
 }
@@ -185,7 +217,7 @@ func (i *Image) Fill(r, g, b, a float32, region image.Rectangle) {
 	}
 	sr := image.Rect(0, 0, i.ui.whiteImage.width, i.ui.whiteImage.height)
 	// i.lastBlend is updated in DrawTriangles.
-	i.DrawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, restorable.HintOverwriteDstRegion)
+	i.DrawTriangles(srcs, i.tmpVerticesForFill, is, blend, region, [graphics.ShaderSrcImageCount]image.Rectangle{sr}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, 0, false, restorable.HintOverwriteDstRegion)
 }
 
 type bigOffscreenImage struct {
@@ -219,7 +251,7 @@ func (i *bigOffscreenImage) deallocate() {
 	i.dirty = false
 }
 
-func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool) {
+func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Image, vertices []float32, indices []uint32, blend graphicsdriver.Blend, dstRegion image.Rectangle, srcRegions [graphics.ShaderSrcImageCount]image.Rectangle, shader *Shader, uniforms []uint32, fillRule graphicsdriver.FillRule, canSkipMipmap bool, mipmapLevel int) {
 	if i.blend != blend {
 		i.flush()
 	}
@@ -255,7 +287,7 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Im
 		is := graphics.QuadIndices()
 		dstRegion := image.Rect(0, 0, i.region.Dx()*bigOffscreenScale, i.region.Dy()*bigOffscreenScale)
 		srcRegion := i.region
-		i.image.DrawTriangles(srcs, i.tmpVerticesForCopying, is, graphicsdriver.BlendCopy, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, restorable.HintOverwriteDstRegion)
+		i.image.DrawTriangles(srcs, i.tmpVerticesForCopying, is, graphicsdriver.BlendCopy, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, NearestFilterShader, nil, graphicsdriver.FillRuleFillAll, true, 0, false, restorable.HintOverwriteDstRegion)
 	}
 
 	for idx := 0; idx < len(vertices); idx += graphics.VertexFloatCount {
@@ -271,7 +303,7 @@ func (i *bigOffscreenImage) drawTriangles(srcs [graphics.ShaderSrcImageCount]*Im
 	dstRegion.Max.X *= bigOffscreenScale
 	dstRegion.Max.Y *= bigOffscreenScale
 
-	i.image.DrawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, false, restorable.HintNone)
+	i.image.DrawTriangles(srcs, vertices, indices, blend, dstRegion, srcRegions, shader, uniforms, fillRule, canSkipMipmap, mipmapLevel, false, restorable.HintNone)
 	i.dirty = true
 }
 
@@ -306,7 +338,7 @@ func (i *bigOffscreenImage) flush() {
 		blend = graphicsdriver.BlendCopy
 		hint = restorable.HintOverwriteDstRegion
 	}
-	i.orig.DrawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, LinearFilterShader, nil, graphicsdriver.FillRuleFillAll, true, false, hint)
+	i.orig.DrawTriangles(srcs, i.tmpVerticesForFlushing, is, blend, dstRegion, [graphics.ShaderSrcImageCount]image.Rectangle{srcRegion}, LinearFilterShader, nil, graphicsdriver.FillRuleFillAll, true, 0, false, hint)
 
 	i.image.clear()
 	i.dirty = false
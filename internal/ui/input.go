@@ -16,6 +16,8 @@ package ui
 
 import (
 	"io/fs"
+	"log"
+	"time"
 	"unicode"
 )
 
@@ -38,6 +40,34 @@ type Touch struct {
 	Y  int
 }
 
+type InputEventType int
+
+const (
+	InputEventTypeKey InputEventType = iota
+	InputEventTypeMouseButton
+)
+
+// InputEvent represents a transition of a key or a mouse button reported by the platform.
+type InputEvent struct {
+	Type        InputEventType
+	Key         Key
+	MouseButton MouseButton
+	Pressed     bool
+	Time        time.Time
+}
+
+// maxInputEvents is the maximum number of input events kept until they are read.
+const maxInputEvents = 1024
+
+// CursorPosition represents a sampled cursor position in the logical coordinate.
+type CursorPosition struct {
+	X float64
+	Y float64
+}
+
+// maxCursorPositionHistory is the maximum number of cursor positions kept until they are read.
+const maxCursorPositionHistory = 1024
+
 type InputState struct {
 	KeyPressed         [KeyMax + 1]bool
 	MouseButtonPressed [MouseButtonMax + 1]bool
@@ -47,8 +77,12 @@ type InputState struct {
 	WheelY             float64
 	Touches            []Touch
 	Runes              []rune
+	Events             []InputEvent
+	CursorHistory      []CursorPosition
 	WindowBeingClosed  bool
 	DroppedFiles       fs.FS
+
+	eventsDropped bool
 }
 
 func (i *InputState) copyAndReset(dst *InputState) {
@@ -60,6 +94,8 @@ func (i *InputState) copyAndReset(dst *InputState) {
 	dst.WheelY = i.WheelY
 	dst.Touches = append(dst.Touches[:0], i.Touches...)
 	dst.Runes = append(dst.Runes[:0], i.Runes...)
+	dst.Events = append(dst.Events[:0], i.Events...)
+	dst.CursorHistory = append(dst.CursorHistory[:0], i.CursorHistory...)
 	dst.WindowBeingClosed = i.WindowBeingClosed
 	dst.DroppedFiles = i.DroppedFiles
 
@@ -67,6 +103,8 @@ func (i *InputState) copyAndReset(dst *InputState) {
 	i.WheelX = 0
 	i.WheelY = 0
 	i.Runes = i.Runes[:0]
+	i.Events = i.Events[:0]
+	i.CursorHistory = i.CursorHistory[:0]
 
 	// Reset the members that are never reset until they are explicitly done.
 	i.WindowBeingClosed = false
@@ -79,3 +117,27 @@ func (i *InputState) appendRune(r rune) {
 	}
 	i.Runes = append(i.Runes, r)
 }
+
+// AppendEvent appends an input event to the queue.
+// If the queue is full, the oldest event is dropped.
+func (i *InputState) AppendEvent(event InputEvent) {
+	if len(i.Events) >= maxInputEvents {
+		if !i.eventsDropped {
+			log.Printf("ebiten: input events were dropped as they were not read for a while")
+			i.eventsDropped = true
+		}
+		n := copy(i.Events, i.Events[1:])
+		i.Events = i.Events[:n]
+	}
+	i.Events = append(i.Events, event)
+}
+
+// appendCursorPosition appends a cursor position to the history.
+// If the history is full, the oldest position is dropped.
+func (i *InputState) appendCursorPosition(x, y float64) {
+	if len(i.CursorHistory) >= maxCursorPositionHistory {
+		n := copy(i.CursorHistory, i.CursorHistory[1:])
+		i.CursorHistory = i.CursorHistory[:n]
+	}
+	i.CursorHistory = append(i.CursorHistory, CursorPosition{X: x, Y: y})
+}
@@ -18,6 +18,7 @@ package ui
 
 import (
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
@@ -31,6 +32,14 @@ var glfwMouseButtonToMouseButton = map[glfw.MouseButton]MouseButton{
 	glfw.MouseButton5:      MouseButton4,
 }
 
+var glfwKeyToUIKey = map[glfw.Key]Key{}
+
+func init() {
+	for uk, gk := range uiKeyToGLFWKey {
+		glfwKeyToUIKey[gk] = uk
+	}
+}
+
 func (u *UserInterface) registerInputCallbacks() error {
 	if _, err := u.window.SetCharModsCallback(func(w *glfw.Window, char rune, mods glfw.ModifierKey) {
 		// As this function is called from GLFW callbacks, the current thread is main.
@@ -41,6 +50,58 @@ func (u *UserInterface) registerInputCallbacks() error {
 		return err
 	}
 
+	if _, err := u.window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		if action == glfw.Repeat {
+			return
+		}
+		uk, ok := glfwKeyToUIKey[key]
+		if !ok {
+			return
+		}
+		// As this function is called from GLFW callbacks, the current thread is main.
+		u.m.Lock()
+		defer u.m.Unlock()
+		u.inputState.AppendEvent(InputEvent{
+			Type:    InputEventTypeKey,
+			Key:     uk,
+			Pressed: action == glfw.Press,
+			Time:    time.Now(),
+		})
+	}); err != nil {
+		return err
+	}
+
+	if _, err := u.window.SetMouseButtonCallback(func(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+		ub, ok := glfwMouseButtonToMouseButton[button]
+		if !ok {
+			return
+		}
+		// As this function is called from GLFW callbacks, the current thread is main.
+		u.m.Lock()
+		defer u.m.Unlock()
+		u.inputState.AppendEvent(InputEvent{
+			Type:        InputEventTypeMouseButton,
+			MouseButton: ub,
+			Pressed:     action == glfw.Press,
+			Time:        time.Now(),
+		})
+	}); err != nil {
+		return err
+	}
+
+	if _, err := u.window.SetCursorPosCallback(func(w *glfw.Window, xpos float64, ypos float64) {
+		// As this function is called from GLFW callbacks, the current thread is main.
+		u.m.Lock()
+		defer u.m.Unlock()
+		if len(u.cursorHistoryInGLFWPixels) >= maxCursorPositionHistory {
+			n := copy(u.cursorHistoryInGLFWPixels, u.cursorHistoryInGLFWPixels[1:])
+			u.cursorHistoryInGLFWPixels = u.cursorHistoryInGLFWPixels[:n]
+		}
+		u.cursorHistoryInGLFWPixels = append(u.cursorHistoryInGLFWPixels, CursorPosition{X: xpos, Y: ypos})
+	}); err != nil {
+		return err
+	}
+
 	if _, err := u.window.SetScrollCallback(func(w *glfw.Window, xoff float64, yoff float64) {
 		// As this function is called from GLFW callbacks, the current thread is main.
 		u.m.Lock()
@@ -92,6 +153,7 @@ func (u *UserInterface) updateInputStateImpl() error {
 	defer func() {
 		u.savedCursorX = math.NaN()
 		u.savedCursorY = math.NaN()
+		u.cursorHistoryInGLFWPixels = u.cursorHistoryInGLFWPixels[:0]
 	}()
 
 	if !math.IsNaN(cx) && !math.IsNaN(cy) {
@@ -109,6 +171,14 @@ func (u *UserInterface) updateInputStateImpl() error {
 		cx2 = dipFromGLFWPixel(cx2, s)
 		cy2 = dipFromGLFWPixel(cy2, s)
 		cx, cy = u.context.clientPositionToLogicalPosition(cx2, cy2, s)
+
+		for _, p := range u.cursorHistoryInGLFWPixels {
+			x, y := u.context.clientPositionToLogicalPosition(dipFromGLFWPixel(p.X, s), dipFromGLFWPixel(p.Y, s), s)
+			if math.IsNaN(x) || math.IsNaN(y) {
+				continue
+			}
+			u.inputState.appendCursorPosition(x, y)
+		}
 	}
 
 	// AdjustPosition can return NaN at the initialization.
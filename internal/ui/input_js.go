@@ -18,6 +18,7 @@ import (
 	"math"
 	"strings"
 	"syscall/js"
+	"time"
 	"unicode"
 )
 
@@ -101,6 +102,8 @@ func eventToKeys(e js.Value) (key0, key1 Key, fromKeyProperty bool) {
 
 func (u *UserInterface) keyDown(event js.Value) {
 	key0, key1, fromKeyProperty := eventToKeys(event)
+	u.appendKeyEvent(key0, true)
+	u.appendKeyEvent(key1, true)
 	if key0 >= 0 {
 		// If the key value comes from a 'key' property, a 'keydown' and 'keyup' event might be fired too quickly.
 		// Record the key duration to prevent immediate resetting a key state by a 'keyup' event.
@@ -126,6 +129,8 @@ func (u *UserInterface) keyDown(event js.Value) {
 
 func (u *UserInterface) keyUp(event js.Value) {
 	key0, key1, fromKeyProperty := eventToKeys(event)
+	u.appendKeyEvent(key0, false)
+	u.appendKeyEvent(key1, false)
 	if key0 >= 0 {
 		if !fromKeyProperty || u.keyDurationsByKeyProperty[key0] == 0 {
 			u.inputState.KeyPressed[key0] = false
@@ -138,14 +143,42 @@ func (u *UserInterface) keyUp(event js.Value) {
 	}
 }
 
+// appendKeyEvent appends a key event unless the key is invalid or the event is a repeat.
+// appendKeyEvent must be called before the key state is updated.
+func (u *UserInterface) appendKeyEvent(key Key, pressed bool) {
+	if key < 0 {
+		return
+	}
+	if pressed && u.inputState.KeyPressed[key] {
+		return
+	}
+	u.inputState.AppendEvent(InputEvent{
+		Type:    InputEventTypeKey,
+		Key:     key,
+		Pressed: pressed,
+		Time:    time.Now(),
+	})
+}
+
 func (u *UserInterface) mouseDown(code int) {
+	u.appendMouseButtonEvent(codeToMouseButton[code], true)
 	u.inputState.MouseButtonPressed[codeToMouseButton[code]] = true
 }
 
 func (u *UserInterface) mouseUp(code int) {
+	u.appendMouseButtonEvent(codeToMouseButton[code], false)
 	u.inputState.MouseButtonPressed[codeToMouseButton[code]] = false
 }
 
+func (u *UserInterface) appendMouseButtonEvent(button MouseButton, pressed bool) {
+	u.inputState.AppendEvent(InputEvent{
+		Type:        InputEventTypeMouseButton,
+		MouseButton: button,
+		Pressed:     pressed,
+		Time:        time.Now(),
+	})
+}
+
 func (u *UserInterface) updateInputFromEvent(e js.Value) error {
 	// Avoid using js.Value.String() as String creates a Uint8Array via a TextEncoder and causes a heavy
 	// overhead (#1437).
@@ -190,11 +223,16 @@ func (u *UserInterface) setMouseCursorFromEvent(e js.Value) {
 	if u.cursorMode == CursorModeCaptured {
 		u.cursorXInClient += e.Get("movementX").Float()
 		u.cursorYInClient += e.Get("movementY").Float()
-		return
+	} else {
+		u.cursorXInClient = u.origCursorXInClient
+		u.cursorYInClient = u.origCursorYInClient
 	}
 
-	u.cursorXInClient = u.origCursorXInClient
-	u.cursorYInClient = u.origCursorYInClient
+	if len(u.cursorHistoryInClient) >= maxCursorPositionHistory {
+		n := copy(u.cursorHistoryInClient, u.cursorHistoryInClient[1:])
+		u.cursorHistoryInClient = u.cursorHistoryInClient[:n]
+	}
+	u.cursorHistoryInClient = append(u.cursorHistoryInClient, CursorPosition{X: u.cursorXInClient, Y: u.cursorYInClient})
 }
 
 func (u *UserInterface) recoverCursorPosition() {
@@ -345,7 +383,13 @@ func (u *UserInterface) updateInputState() error {
 		cx, cy := u.context.clientPositionToLogicalPosition(u.cursorXInClient, u.cursorYInClient, s)
 		u.inputState.CursorX = cx
 		u.inputState.CursorY = cy
+
+		for _, p := range u.cursorHistoryInClient {
+			x, y := u.context.clientPositionToLogicalPosition(p.X, p.Y, s)
+			u.inputState.appendCursorPosition(x, y)
+		}
 	}
+	u.cursorHistoryInClient = u.cursorHistoryInClient[:0]
 
 	u.inputState.Touches = u.inputState.Touches[:0]
 	for _, t := range u.touchesInClient {
@@ -0,0 +1,35 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build android || ios || js || nintendosdk || playstation5
+
+package ui
+
+import (
+	"errors"
+)
+
+var errSteppingNotSupported = errors.New("ui: stepping the game loop is not supported on this environment")
+
+func (u *UserInterface) StartStepping(game Game, options *RunOptions) error {
+	return errSteppingNotSupported
+}
+
+func (u *UserInterface) Step() error {
+	return errSteppingNotSupported
+}
+
+func (u *UserInterface) StopStepping() (bool, error) {
+	return false, nil
+}
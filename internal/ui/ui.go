@@ -82,6 +82,10 @@ type UserInterface struct {
 	terminated                atomic.Bool
 	tick                      atomic.Uint64
 
+	// screenClearColor is the premultiplied color to fill the screen outside of the offscreen.
+	// nil means the default (transparent black).
+	screenClearColor atomic.Pointer[[4]float32]
+
 	whiteImage *Image
 
 	mainThread thread.Thread
@@ -163,6 +167,36 @@ func (u *UserInterface) readPixels(mipmap *mipmap.Mipmap, pixels []byte, region
 	return nil
 }
 
+func (u *UserInterface) FlushGraphicsCommands() error {
+	if !u.running.Load() {
+		panic("ui: FlushGraphicsCommands cannot be called before the game starts")
+	}
+
+	ok, err := atlas.FlushCommands(u.graphicsDriver)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	// FlushCommands failed since this was called in between two frames.
+	// Try this again at the next frame.
+	var err1 error
+	u.context.runInFrame(func() {
+		ok, err := atlas.FlushCommands(u.graphicsDriver)
+		if err != nil {
+			err1 = err
+			return
+		}
+		if !ok {
+			// This never reaches since this function must be called in a frame.
+			panic("ui: FlushCommands unexpectedly failed")
+		}
+	})
+	return err1
+}
+
 func (u *UserInterface) dumpScreenshot(mipmap *mipmap.Mipmap, name string, blackbg bool) (string, error) {
 	return mipmap.DumpScreenshot(u.graphicsDriver, name, blackbg)
 }
@@ -211,6 +245,39 @@ func (u *UserInterface) SetScreenClearedEveryFrame(cleared bool) {
 	u.isScreenClearedEveryFrame.Store(cleared)
 }
 
+func (u *UserInterface) ScreenClearColor() (r, g, b, a float32, ok bool) {
+	c := u.screenClearColor.Load()
+	if c == nil {
+		return 0, 0, 0, 0, false
+	}
+	return c[0], c[1], c[2], c[3], true
+}
+
+func (u *UserInterface) SetScreenClearColor(r, g, b, a float32) {
+	u.screenClearColor.Store(&[4]float32{r, g, b, a})
+}
+
+func (u *UserInterface) ResetScreenClearColor() {
+	u.screenClearColor.Store(nil)
+}
+
+// clearScreen clears the screen before the offscreen is drawn onto it.
+func (u *UserInterface) clearScreen(screen *Image, needsClearing bool) {
+	if r, g, b, a, ok := u.ScreenClearColor(); ok {
+		// Fill the whole screen including the letterboxing area before the offscreen is drawn.
+		screen.Fill(r, g, b, a, image.Rect(0, 0, screen.width, screen.height))
+		return
+	}
+	if needsClearing {
+		// This clear is needed for fullscreen mode or some mobile platforms (#622).
+		screen.clear()
+	}
+}
+
+func (u *UserInterface) ClearScreenForTesting(screen *Image) {
+	u.clearScreen(screen, true)
+}
+
 func (u *UserInterface) setGraphicsLibrary(library GraphicsLibrary) {
 	u.graphicsLibrary.Store(int32(library))
 }
@@ -20,9 +20,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/ebitengine/purego/objc"
 
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
 	"github.com/hajimehoshi/ebiten/v2/internal/cocoa"
 	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -190,9 +192,14 @@ func (*graphicsDriverCreatorImpl) newDirectX() (graphicsdriver.Graphics, error)
 }
 
 func (g *graphicsDriverCreatorImpl) newMetal() (graphicsdriver.Graphics, error) {
+	registerPrecompiledMetalLibrariesOnce.Do(func() {
+		builtinshader.RegisterPrecompiledMetalLibraries(metal.RegisterPrecompiledLibrary)
+	})
 	return metal.NewGraphics(g.colorSpace)
 }
 
+var registerPrecompiledMetalLibrariesOnce sync.Once
+
 func (*graphicsDriverCreatorImpl) newPlayStation5() (graphicsdriver.Graphics, error) {
 	return nil, errors.New("ui: PlayStation 5 is not supported in this environment")
 }
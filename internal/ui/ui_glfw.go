@@ -26,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/file"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/glfw"
@@ -33,6 +34,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
 	"github.com/hajimehoshi/ebiten/v2/internal/hook"
 	"github.com/hajimehoshi/ebiten/v2/internal/microsoftgdk"
+	"github.com/hajimehoshi/ebiten/v2/internal/thread"
 )
 
 func driverCursorModeToGLFWCursorMode(mode CursorMode) int {
@@ -80,6 +82,7 @@ type userInterfaceImpl struct {
 	initWindowFloating         bool
 	initWindowMaximized        bool
 	initWindowMousePassthrough bool
+	initWindowOpacity          float64
 
 	initUnfocused bool
 
@@ -93,11 +96,18 @@ type userInterfaceImpl struct {
 
 	fpsModeInited bool
 
+	// stepping reports whether the game is started by StartStepping and not stopped yet.
+	// stepping must be accessed from the thread calling StartStepping.
+	stepping bool
+
 	inputState   InputState
 	iwindow      glfwWindow
 	savedCursorX float64
 	savedCursorY float64
 
+	// cursorHistoryInGLFWPixels is the cursor positions reported by the platform since the last input update.
+	cursorHistoryInGLFWPixels []CursorPosition
+
 	closeCallback                  glfw.CloseCallback
 	framebufferSizeCallback        glfw.FramebufferSizeCallback
 	defaultFramebufferSizeCallback glfw.FramebufferSizeCallback
@@ -138,6 +148,7 @@ func (u *UserInterface) init() error {
 		initWindowPositionYInDIP: invalidPos,
 		initWindowWidthInDIP:     640,
 		initWindowHeightInDIP:    480,
+		initWindowOpacity:        1,
 		origWindowPosX:           invalidPos,
 		origWindowPosY:           invalidPos,
 		savedCursorX:             math.NaN(),
@@ -562,6 +573,18 @@ func (u *UserInterface) setInitWindowMousePassthrough(enabled bool) {
 	u.initWindowMousePassthrough = enabled
 }
 
+func (u *UserInterface) getInitWindowOpacity() float64 {
+	u.m.RLock()
+	defer u.m.RUnlock()
+	return u.initWindowOpacity
+}
+
+func (u *UserInterface) setInitWindowOpacity(opacity float64) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.initWindowOpacity = opacity
+}
+
 func (u *UserInterface) isWindowClosingHandled() bool {
 	u.m.RLock()
 	v := u.windowClosingHandled
@@ -895,6 +918,12 @@ func (u *UserInterface) createWindow() error {
 		return err
 	}
 
+	if opacity := u.getInitWindowOpacity(); opacity != 1 {
+		if err := u.setWindowOpacity(opacity); err != nil {
+			return err
+		}
+	}
+
 	u.m.Lock()
 	closingHandled := u.windowClosingHandled
 	u.m.Unlock()
@@ -1435,13 +1464,9 @@ func (u *UserInterface) update() (float64, float64, error) {
 
 func (u *UserInterface) loopGame() (ferr error) {
 	defer func() {
-		graphicscommand.Terminate()
-		u.mainThread.Call(func() {
-			if err := glfw.Terminate(); err != nil {
-				ferr = err
-			}
-			u.setTerminated()
-		})
+		if err := u.terminateGame(); err != nil {
+			ferr = err
+		}
 	}()
 
 	for {
@@ -1451,6 +1476,59 @@ func (u *UserInterface) loopGame() (ferr error) {
 	}
 }
 
+func (u *UserInterface) terminateGame() error {
+	graphicscommand.Terminate()
+	var rerr error
+	u.mainThread.Call(func() {
+		if err := glfw.Terminate(); err != nil {
+			rerr = err
+		}
+		u.setTerminated()
+	})
+	return rerr
+}
+
+// StartStepping initializes the game without entering the game loop.
+// After StartStepping succeeds, the caller must call Step repeatedly and then StopStepping.
+// All of them must be called on the same OS thread, which must be the main thread on macOS.
+func (u *UserInterface) StartStepping(game Game, options *RunOptions) error {
+	if u.stepping || u.isRunning() {
+		return errors.New("ui: the game is already running")
+	}
+
+	u.mainThread = thread.NewNoopThread()
+
+	u.setRunning(true)
+	u.context = newContext(game)
+
+	if err := u.initOnMainThread(options); err != nil {
+		u.setRunning(false)
+		return err
+	}
+	u.stepping = true
+	return nil
+}
+
+// Step advances the game by one iteration of the game loop.
+// Step returns an error if the game is not started by StartStepping.
+func (u *UserInterface) Step() error {
+	if !u.stepping {
+		return errors.New("ui: the game is not started by StartStepping")
+	}
+	return u.updateGame()
+}
+
+// StopStepping terminates the game started by StartStepping.
+// StopStepping does nothing and returns false if the game is not started by StartStepping.
+func (u *UserInterface) StopStepping() (bool, error) {
+	if !u.stepping {
+		return false, nil
+	}
+	u.stepping = false
+	u.setRunning(false)
+	return true, u.terminateGame()
+}
+
 func (u *UserInterface) updateGame() error {
 	var unfocused bool
 
@@ -1464,6 +1542,7 @@ func (u *UserInterface) updateGame() error {
 		}
 		unfocused = a == glfw.False
 	}
+	clock.SetFocused(!unfocused)
 
 	var t1, t2 time.Time
 
@@ -2156,6 +2235,31 @@ func (u *UserInterface) setOrigWindowPos(x, y int) {
 	u.origWindowPosY = y
 }
 
+// windowOpacity must be called from the main thread.
+func (u *UserInterface) windowOpacity() (float64, error) {
+	if microsoftgdk.IsXbox() {
+		return 1, nil
+	}
+
+	o, err := u.window.GetOpacity()
+	if err != nil {
+		return 0, err
+	}
+	return float64(o), nil
+}
+
+// setWindowOpacity must be called from the main thread.
+func (u *UserInterface) setWindowOpacity(opacity float64) error {
+	if microsoftgdk.IsXbox() {
+		return nil
+	}
+
+	if err := u.window.SetOpacity(float32(opacity)); err != nil {
+		return err
+	}
+	return nil
+}
+
 // setWindowMousePassthrough must be called from the main thread.
 func (u *UserInterface) setWindowMousePassthrough(enabled bool) error {
 	if microsoftgdk.IsXbox() {
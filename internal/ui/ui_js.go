@@ -21,6 +21,7 @@ import (
 	"syscall/js"
 	"time"
 
+	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/file"
 	"github.com/hajimehoshi/ebiten/v2/internal/gamepad"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -107,6 +108,7 @@ type userInterfaceImpl struct {
 	origCursorXInClient       float64
 	origCursorYInClient       float64
 	touchesInClient           []touchInClient
+	cursorHistoryInClient     []CursorPosition
 
 	savedCursorX              float64
 	savedCursorY              float64
@@ -343,6 +345,8 @@ func (u *UserInterface) updateImpl(force bool) error {
 	// Now there is not a good way to detect the change.
 	// See also https://crbug.com/123694.
 
+	clock.SetFocused(u.isFocused())
+
 	w, h := u.outsideSize()
 	if force {
 		if err := u.context.forceUpdateFrame(u.graphicsDriver, w, h, theMonitor.DeviceScaleFactor(), u); err != nil {
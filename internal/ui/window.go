@@ -43,6 +43,8 @@ type Window interface {
 	IsClosingHandled() bool
 	SetMousePassthrough(enabled bool)
 	IsMousePassthrough() bool
+	SetOpacity(opacity float64)
+	Opacity() float64
 	RequestAttention()
 }
 
@@ -130,5 +132,12 @@ func (*nullWindow) IsMousePassthrough() bool {
 	return false
 }
 
+func (*nullWindow) SetOpacity(opacity float64) {
+}
+
+func (*nullWindow) Opacity() float64 {
+	return 1
+}
+
 func (*nullWindow) RequestAttention() {
 }
@@ -507,6 +507,47 @@ func (w *glfwWindow) IsMousePassthrough() bool {
 	return v
 }
 
+func (w *glfwWindow) SetOpacity(opacity float64) {
+	if w.ui.isTerminated() {
+		return
+	}
+	if !w.ui.isRunning() {
+		w.ui.setInitWindowOpacity(opacity)
+		return
+	}
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		if err := w.ui.setWindowOpacity(opacity); err != nil {
+			w.ui.setError(err)
+			return
+		}
+	})
+}
+
+func (w *glfwWindow) Opacity() float64 {
+	if w.ui.isTerminated() {
+		return 1
+	}
+	if !w.ui.isRunning() {
+		return w.ui.getInitWindowOpacity()
+	}
+	v := 1.0
+	w.ui.mainThread.Call(func() {
+		if w.ui.isTerminated() {
+			return
+		}
+		o, err := w.ui.windowOpacity()
+		if err != nil {
+			w.ui.setError(err)
+			return
+		}
+		v = o
+	})
+	return v
+}
+
 func (w *glfwWindow) RequestAttention() {
 	if w.ui.isTerminated() {
 		return
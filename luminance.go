@@ -0,0 +1,107 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+// luminanceReadbackSize is the maximum width and height of the reduced image that AverageLuminance reads back.
+const luminanceReadbackSize = 8
+
+// AverageLuminance returns the average relative luminance of the image in [0, 1].
+//
+// The luminance is calculated with the Rec. 709 coefficients from the premultiplied-alpha colors,
+// so transparent pixels are treated as black.
+//
+// AverageLuminance reduces the image on GPU by repeatedly halving it with linear filtering,
+// in the same way as mipmaps are generated, until it is at most 8x8 pixels,
+// and then reads back only these pixels and sums them up on CPU.
+// An image whose size is not a power of two is padded with transparent pixels first,
+// and the padded pixels are excluded from the average, so every row and column is weighted equally.
+// This is much cheaper than reading back all the pixels, but the result is approximate
+// as the precision is limited by the 8-bit intermediate images.
+//
+// AverageLuminance reads a pixel from GPU synchronously, which means it flushes the queued draw commands.
+//
+// AverageLuminance returns 0 if the image is disposed.
+//
+// AverageLuminance can't be called outside the main loop (ebiten.Run's updating function) starts.
+func (i *Image) AverageLuminance() float64 {
+	i.copyCheck()
+
+	if i.isDisposed() {
+		return 0
+	}
+
+	var pooled []*Image
+	defer func() {
+		for _, img := range pooled {
+			theImagePool.put(img)
+		}
+	}()
+
+	src := i
+	b := i.Bounds()
+
+	// Pad the image to power-of-two sizes so that every reduction halves the size exactly.
+	// Otherwise, the last row or column of an odd size would be dropped or weighted differently.
+	w, h := ceilPowerOf2(b.Dx()), ceilPowerOf2(b.Dy())
+	if w != b.Dx() || h != b.Dy() {
+		dst := theImagePool.get(w, h)
+		pooled = append(pooled, dst)
+
+		op := &DrawImageOptions{}
+		op.GeoM.Translate(float64(-b.Min.X), float64(-b.Min.Y))
+		op.Blend = BlendCopy
+		dst.DrawImage(src, op)
+
+		src = dst
+	}
+
+	for w > luminanceReadbackSize || h > luminanceReadbackSize {
+		dw, dh := max(w/2, 1), max(h/2, 1)
+		dst := theImagePool.get(dw, dh)
+		pooled = append(pooled, dst)
+
+		op := &DrawImageOptions{}
+		op.GeoM.Translate(float64(-src.Bounds().Min.X), float64(-src.Bounds().Min.Y))
+		op.GeoM.Scale(float64(dw)/float64(w), float64(dh)/float64(h))
+		op.Filter = FilterLinear
+		op.Blend = BlendCopy
+		op.DisableMipmaps = true
+		dst.DrawImage(src, op)
+
+		src = dst
+		w, h = dw, dh
+	}
+
+	pix := make([]byte, 4*w*h)
+	src.ReadPixels(pix)
+	var sum float64
+	for j := 0; j < len(pix); j += 4 {
+		sum += 0.2126*float64(pix[j]) + 0.7152*float64(pix[j+1]) + 0.0722*float64(pix[j+2])
+	}
+
+	// Each read pixel is the average of a block of the padded image. The padded pixels are transparent black,
+	// so the sum of the blocks divided by the number of the original pixels is the average of the original image.
+	blockArea := float64(ceilPowerOf2(b.Dx())*ceilPowerOf2(b.Dy())) / float64(w*h)
+	return min(sum/0xff*blockArea/float64(b.Dx()*b.Dy()), 1)
+}
+
+// ceilPowerOf2 returns the smallest power of two that is equal to or greater than x.
+func ceilPowerOf2(x int) int {
+	p := 1
+	for p < x {
+		p <<= 1
+	}
+	return p
+}
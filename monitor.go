@@ -15,6 +15,8 @@
 package ebiten
 
 import (
+	"sync"
+
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
 )
 
@@ -74,3 +76,56 @@ func AppendMonitors(monitors []*MonitorType) []*MonitorType {
 	}
 	return monitors
 }
+
+var (
+	deviceScaleChangeCallback  func(scale float64)
+	deviceScaleChangeCallbackM sync.Mutex
+
+	// lastDeviceScaleFactor is accessed only from the game's Update.
+	lastDeviceScaleFactor float64
+)
+
+// SetDeviceScaleChangeCallback sets a callback function invoked when the device scale factor of the current monitor changes,
+// e.g. when the window is moved from a high-DPI monitor to a regular monitor.
+//
+// The callback is given the new device scale factor, which is the same as Monitor().DeviceScaleFactor().
+// This is useful to reallocate resolution-dependent resources like high-DPI offscreen images.
+//
+// The callback is invoked on the same goroutine as Game's Update, just before Update is called.
+// The callback is not invoked for the device scale factor at the time the callback is set.
+//
+// If f is nil, the current callback is removed.
+//
+// SetDeviceScaleChangeCallback is concurrent-safe.
+func SetDeviceScaleChangeCallback(f func(scale float64)) {
+	deviceScaleChangeCallbackM.Lock()
+	defer deviceScaleChangeCallbackM.Unlock()
+	deviceScaleChangeCallback = f
+}
+
+func notifyDeviceScaleChange() {
+	deviceScaleChangeCallbackM.Lock()
+	f := deviceScaleChangeCallback
+	deviceScaleChangeCallbackM.Unlock()
+
+	// Don't query the device scale factor every tick unless a callback is set.
+	if f == nil {
+		lastDeviceScaleFactor = 0
+		return
+	}
+
+	m := Monitor()
+	if m == nil {
+		return
+	}
+	s := m.DeviceScaleFactor()
+	if lastDeviceScaleFactor == s {
+		return
+	}
+	initial := lastDeviceScaleFactor == 0
+	lastDeviceScaleFactor = s
+	if initial {
+		return
+	}
+	f(s)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// NoiseType represents a type of a basis noise for GenerateNoise.
+type NoiseType int
+
+const (
+	// NoiseTypeValue represents a value noise, which interpolates random values at grid points.
+	NoiseTypeValue NoiseType = builtinshader.NoiseTypeValue
+
+	// NoiseTypePerlin represents a Perlin (gradient) noise.
+	NoiseTypePerlin NoiseType = builtinshader.NoiseTypePerlin
+
+	// NoiseTypeSimplex represents a simplex noise.
+	NoiseTypeSimplex NoiseType = builtinshader.NoiseTypeSimplex
+)
+
+// NoiseOptions represents options for GenerateNoise.
+type NoiseOptions struct {
+	// Type is a type of the basis noise.
+	// The default (zero) value is NoiseTypeValue.
+	Type NoiseType
+
+	// Seed is a seed of the noise.
+	// The same seed with the same options generates the same noise regardless of GPUs,
+	// except for very slight differences by floating point errors.
+	Seed int64
+
+	// Frequency is the number of noise cells per pixel.
+	// The default (zero) value is treated as 1/32.
+	Frequency float64
+
+	// Octaves is the number of layered noises.
+	// Each octave has the double frequency and the half amplitude of the previous octave.
+	// Octaves is capped at 8.
+	// The default (zero) value is treated as 1.
+	Octaves int
+}
+
+// GenerateNoise generates a new grayscale noise image with the given size on GPU.
+//
+// The generated image is opaque, and each pixel's color is in [0, 1].
+//
+// If options is nil, the default options are used.
+func GenerateNoise(width, height int, options *NoiseOptions) *Image {
+	if options == nil {
+		options = &NoiseOptions{}
+	}
+
+	freq := options.Frequency
+	if freq == 0 {
+		freq = 1.0 / 32
+	}
+	octaves := min(max(options.Octaves, 1), builtinshader.MaxNoiseOctaves)
+
+	// Derive the offset of the noise coordinate from the seed.
+	// Keep the offset small since a big coordinate loses the precision on GPU.
+	s := uint64(options.Seed)
+	ox := float32(splitMix64(&s)%(1<<16)) / (1 << 8)
+	oy := float32(splitMix64(&s)%(1<<16)) / (1 << 8)
+
+	img := NewImage(width, height)
+	shader := effectShader("noise")
+	op := &DrawRectShaderOptions{}
+	op.Blend = BlendCopy
+	op.Uniforms = map[string]any{
+		builtinshader.UniformNoiseType: int(options.Type),
+		builtinshader.UniformFrequency: float32(freq),
+		builtinshader.UniformOctaves:   octaves,
+		builtinshader.UniformOffset:    []float32{ox, oy},
+	}
+	img.DrawRectShader(width, height, shader, op)
+	return img
+}
+
+// splitMix64 returns a next pseudo-random number and updates the state.
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
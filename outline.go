@@ -0,0 +1,107 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// DrawOutlineOptions represents options for DrawWithOutlineEffect.
+type DrawOutlineOptions struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM GeoM
+
+	// ColorScale is a scale of color.
+	// ColorScale is applied to both the image and the outline.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// OutlineColor is the color of the outline.
+	// If OutlineColor is nil, white is used.
+	OutlineColor color.Color
+
+	// Thickness is the thickness of the outline in the source image's pixels.
+	// Thickness is capped at 8.
+	// The default (zero) value means 1.
+	Thickness int
+}
+
+// DrawWithOutlineEffect draws the given image on the image i with an outline around img's opaque region.
+//
+// The outline is drawn behind img, and extends outside img's bounds by Thickness.
+// This is useful for e.g. highlighting a selected sprite.
+//
+// If options is nil, the default options are used.
+//
+// When the image i is disposed, DrawWithOutlineEffect does nothing.
+// When img is disposed, DrawWithOutlineEffect panics.
+func (i *Image) DrawWithOutlineEffect(img *Image, options *DrawOutlineOptions) {
+	i.copyCheck()
+
+	if img.isDisposed() {
+		panic("ebiten: the given image to DrawWithOutlineEffect must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+
+	if options == nil {
+		options = &DrawOutlineOptions{}
+	}
+
+	t := options.Thickness
+	if t <= 0 {
+		t = 1
+	}
+	t = min(t, builtinshader.MaxOutlineThickness)
+
+	var clr [4]float32
+	if options.OutlineColor == nil {
+		clr = [4]float32{1, 1, 1, 1}
+	} else {
+		r, g, b, a := options.OutlineColor.RGBA()
+		clr = [4]float32{float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff, float32(a) / 0xffff}
+	}
+
+	// Copy the image with transparent margins so that the outline can extend outside the image's bounds.
+	b := img.Bounds()
+	w, h := b.Dx()+2*t, b.Dy()+2*t
+	tmp := theImagePool.get(w, h)
+	defer theImagePool.put(tmp)
+
+	op := &DrawImageOptions{}
+	op.GeoM.Translate(float64(t-b.Min.X), float64(t-b.Min.Y))
+	op.Blend = BlendCopy
+	tmp.DrawImage(img, op)
+
+	sop := &DrawRectShaderOptions{}
+	sop.GeoM.Translate(float64(-t), float64(-t))
+	sop.GeoM.Concat(options.GeoM)
+	sop.ColorScale = options.ColorScale
+	sop.Blend = options.Blend
+	sop.Images[0] = tmp
+	sop.Uniforms = map[string]any{
+		builtinshader.UniformOutlineColor: clr[:],
+		builtinshader.UniformThickness:    float32(t),
+	}
+	i.DrawRectShader(w, h, effectShader("outline"), sop)
+}
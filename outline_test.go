@@ -0,0 +1,58 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestImageDrawWithOutlineEffect(t *testing.T) {
+	src := ebiten.NewImage(4, 4)
+	src.Fill(color.RGBA{R: 0xff, A: 0xff})
+
+	dst := ebiten.NewImage(20, 20)
+	op := &ebiten.DrawOutlineOptions{
+		OutlineColor: color.RGBA{G: 0xff, A: 0xff},
+		Thickness:    2,
+	}
+	op.GeoM.Translate(8, 8)
+	dst.DrawWithOutlineEffect(src, op)
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	for _, tc := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{8, 8, red},
+		{11, 11, red},
+		{7, 9, green},
+		{6, 9, green},
+		{5, 9, color.RGBA{}},
+		{13, 9, green},
+		{14, 9, color.RGBA{}},
+		{9, 6, green},
+		{9, 14, color.RGBA{}},
+		{7, 7, green},
+		{6, 6, color.RGBA{}},
+	} {
+		if got := dst.At(tc.x, tc.y).(color.RGBA); got != tc.want {
+			t.Errorf("dst.At(%d, %d): got: %v, want: %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
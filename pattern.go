@@ -0,0 +1,142 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/graphicscommand"
+)
+
+// PatternPlacement represents a placement of an image for DrawImagePattern.
+type PatternPlacement struct {
+	// GeoM is a geometry matrix to draw the image at this placement.
+	// GeoM is applied before DrawImagePatternOptions.GeoM.
+	// The default (zero) value is identity, which draws the image at (0, 0).
+	GeoM GeoM
+
+	// ColorScale is a scale of color for this placement.
+	// ColorScale is applied with DrawImagePatternOptions.ColorScale.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+}
+
+// DrawImagePatternOptions represents options for DrawImagePattern.
+type DrawImagePatternOptions struct {
+	// GeoM is a geometry matrix applied to all the placements.
+	// The default (zero) value is identity.
+	GeoM GeoM
+
+	// ColorScale is a scale of color applied to all the placements.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend Blend
+
+	// Filter is a type of texture filter.
+	// The default (zero) value is FilterNearest.
+	Filter Filter
+
+	// DisableMipmaps disables mipmaps.
+	// See DrawTrianglesOptions.DisableMipmaps.
+	//
+	// The default (zero) value is false.
+	DisableMipmaps bool
+}
+
+// DrawImagePattern draws the given image on the image i repeatedly, once for each placement.
+//
+// DrawImagePattern is more efficient than calling DrawImage for each placement,
+// as all the placements are sent as one batch of vertices.
+// This is useful to render e.g. a brick wall or a grid with a per-tile variation.
+//
+// If options is nil, the default options are used.
+//
+// When the image i is disposed, DrawImagePattern does nothing.
+// When img is disposed, DrawImagePattern panics.
+func (i *Image) DrawImagePattern(img *Image, placements []PatternPlacement, options *DrawImagePatternOptions) {
+	i.copyCheck()
+
+	if img.isDisposed() {
+		panic("ebiten: the given image to DrawImagePattern must not be disposed")
+	}
+	if i.isDisposed() {
+		return
+	}
+	if len(placements) == 0 {
+		return
+	}
+
+	if options == nil {
+		options = &DrawImagePatternOptions{}
+	}
+
+	b := img.Bounds()
+	sx0, sy0 := float32(b.Min.X), float32(b.Min.Y)
+	sx1, sy1 := float32(b.Max.X), float32(b.Max.Y)
+	w, h := float64(b.Dx()), float64(b.Dy())
+
+	op := &DrawTrianglesOptions{
+		ColorScaleMode: ColorScaleModePremultipliedAlpha,
+		Blend:          options.Blend,
+		Filter:         options.Filter,
+		DisableMipmaps: options.DisableMipmaps,
+	}
+
+	// Split the placements so that the number of vertices doesn't exceed the maximum.
+	const maxPlacements = graphicscommand.MaxVertexCount / 4
+	size := min(len(placements), maxPlacements)
+	vs := make([]Vertex, 0, 4*size)
+	is := make([]uint32, 0, 6*size)
+
+	for len(placements) > 0 {
+		n := min(len(placements), maxPlacements)
+		vs = vs[:0]
+		is = is[:0]
+		for _, p := range placements[:n] {
+			geoM := p.GeoM
+			geoM.Concat(options.GeoM)
+
+			cs := p.ColorScale
+			cs.ScaleWithColorScale(options.ColorScale)
+			r, g, b, a := cs.elements()
+
+			idx := uint32(len(vs))
+			for _, c := range [4][2]float64{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+				dx, dy := geoM.Apply(c[0], c[1])
+				sx, sy := sx0, sy0
+				if c[0] != 0 {
+					sx = sx1
+				}
+				if c[1] != 0 {
+					sy = sy1
+				}
+				vs = append(vs, Vertex{
+					DstX:   float32(dx),
+					DstY:   float32(dy),
+					SrcX:   sx,
+					SrcY:   sy,
+					ColorR: r,
+					ColorG: g,
+					ColorB: b,
+					ColorA: a,
+				})
+			}
+			is = append(is, idx, idx+1, idx+2, idx+1, idx+2, idx+3)
+		}
+		i.DrawTriangles32(vs, is, img, op)
+		placements = placements[n:]
+	}
+}
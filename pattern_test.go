@@ -0,0 +1,66 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestImageDrawImagePattern(t *testing.T) {
+	const w, h = 16, 16
+
+	src := ebiten.NewImage(4, 4)
+	src.Fill(color.White)
+
+	// Use more placements than the maximum in one batch to test splitting.
+	const n = ebiten.MaxVertexCount/4 + 10
+	placements := make([]ebiten.PatternPlacement, n)
+	for i := range placements {
+		x := (i % 4) * 4
+		y := (i / 4 % 4) * 4
+		placements[i].GeoM.Translate(float64(x), float64(y))
+		// The tint of the later placements is visible.
+		if (i/4)%2 == 0 {
+			placements[i].ColorScale.Scale(1, 0, 0, 1)
+		} else {
+			placements[i].ColorScale.Scale(0, 0, 1, 1)
+		}
+	}
+
+	dst := ebiten.NewImage(w, h)
+	dst.DrawImagePattern(src, placements, nil)
+
+	// Emulate the result with DrawImage.
+	want := ebiten.NewImage(w, h)
+	for _, p := range placements {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM = p.GeoM
+		op.ColorScale = p.ColorScale
+		want.DrawImage(src, op)
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			got := dst.At(i, j)
+			want := want.At(i, j)
+			if got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"image"
+)
+
+// PingPong is a pair of offscreen images for ping-pong rendering, which is common for multi-pass post effects.
+//
+// An effect pass reads Front, writes to Back, and then calls Swap.
+//
+//	pp := ebiten.NewPingPong(w, h)
+//	defer pp.Deallocate()
+//	pp.Front().DrawImage(src, nil)
+//	for _, s := range shaders {
+//		pp.Back().Clear()
+//		op := &ebiten.DrawRectShaderOptions{}
+//		op.Images[0] = pp.Front()
+//		pp.Back().DrawRectShader(w, h, s, op)
+//		pp.Swap()
+//	}
+//	screen.DrawImage(pp.Front(), nil)
+//
+// The images are dedicated to the PingPong, and are deallocated by Deallocate.
+type PingPong struct {
+	front *Image
+	back  *Image
+}
+
+// NewPingPong creates a new PingPong with two cleared images with the given size.
+//
+// The images are unmanaged images. See NewImageOptions.Unmanaged.
+//
+// If width or height is less than 1 or more than device-dependent maximum size, NewPingPong panics.
+func NewPingPong(width, height int) *PingPong {
+	if width <= 0 {
+		panic("ebiten: width at NewPingPong must be positive")
+	}
+	if height <= 0 {
+		panic("ebiten: height at NewPingPong must be positive")
+	}
+	op := &NewImageOptions{
+		Unmanaged: true,
+	}
+	return &PingPong{
+		front: NewImageWithOptions(image.Rect(0, 0, width, height), op),
+		back:  NewImageWithOptions(image.Rect(0, 0, width, height), op),
+	}
+}
+
+// Front returns the image that has the result of the last pass.
+//
+// Front returns nil after Deallocate is called.
+func (p *PingPong) Front() *Image {
+	return p.front
+}
+
+// Back returns the image to be rendered in the next pass.
+// Back is not cleared automatically.
+//
+// Back returns nil after Deallocate is called.
+func (p *PingPong) Back() *Image {
+	return p.back
+}
+
+// Swap swaps the front and back images.
+func (p *PingPong) Swap() {
+	p.front, p.back = p.back, p.front
+}
+
+// Deallocate deallocates the images.
+// The images returned by Front and Back must not be used after Deallocate is called.
+//
+// Calling Deallocate multiple times is allowed.
+func (p *PingPong) Deallocate() {
+	if p.front != nil {
+		p.front.Deallocate()
+		p.front = nil
+	}
+	if p.back != nil {
+		p.back.Deallocate()
+		p.back = nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestPingPong(t *testing.T) {
+	const w, h = 16, 8
+
+	pp := ebiten.NewPingPong(w, h)
+	front, back := pp.Front(), pp.Back()
+	if front == back {
+		t.Fatalf("Front and Back must be different images")
+	}
+	for _, img := range []*ebiten.Image{front, back} {
+		if got, want := img.Bounds(), image.Rect(0, 0, w, h); got != want {
+			t.Errorf("Bounds(): got: %v, want: %v", got, want)
+		}
+	}
+
+	front.Fill(color.RGBA{R: 0xff, A: 0xff})
+	back.DrawImage(front, nil)
+	pp.Swap()
+	if pp.Front() != back || pp.Back() != front {
+		t.Errorf("Swap must swap Front and Back")
+	}
+	if got, want := pp.Front().At(0, 0), (color.RGBA{R: 0xff, A: 0xff}); got != want {
+		t.Errorf("Front().At(0, 0): got: %v, want: %v", got, want)
+	}
+
+	pp.Deallocate()
+	if pp.Front() != nil || pp.Back() != nil {
+		t.Errorf("Front and Back must be nil after Deallocate")
+	}
+	// Calling Deallocate twice is allowed.
+	pp.Deallocate()
+
+	// A new PingPong has its own cleared images.
+	pp = ebiten.NewPingPong(w, h)
+	defer pp.Deallocate()
+	for _, img := range []*ebiten.Image{pp.Front(), pp.Back()} {
+		if img == front || img == back {
+			t.Errorf("a new PingPong must not reuse the deallocated images")
+		}
+		if got, want := img.At(0, 0), (color.RGBA{}); got != want {
+			t.Errorf("At(0, 0): got: %v, want: %v", got, want)
+		}
+	}
+}
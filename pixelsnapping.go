@@ -0,0 +1,97 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
+)
+
+// PixelSnappingMode represents how positions are snapped to integer pixels.
+//
+// Modes can be combined with the bitwise OR operator.
+type PixelSnappingMode int
+
+const (
+	// PixelSnappingNone doesn't snap any positions.
+	PixelSnappingNone PixelSnappingMode = 0
+
+	// PixelSnappingSprites rounds the final vertex positions of DrawImage and DrawRectShader to integers.
+	// The rounding happens in the destination image's pixels after GeoM is applied,
+	// so a sprite moved by a fractional camera offset doesn't wobble.
+	PixelSnappingSprites PixelSnappingMode = 1 << 0
+
+	// PixelSnappingScreen rounds the offset of the game screen in the window to integer device pixels.
+	// This affects the GeoM given to FinalScreenDrawer.DrawFinalScreen.
+	PixelSnappingScreen PixelSnappingMode = 1 << 1
+
+	// PixelSnappingTriangles rounds the vertex positions of DrawTriangles and DrawTrianglesShader to integers.
+	// This also affects the vector package, which draws shapes with DrawTriangles,
+	// so curved shapes become less smooth.
+	PixelSnappingTriangles PixelSnappingMode = 1 << 2
+)
+
+var pixelSnappingMode atomic.Int32
+
+// SetPixelSnapping sets the pixel snapping mode.
+// The default mode is PixelSnappingNone.
+//
+// Pixel snapping is useful for pixel-art games, where fractional positions cause wobbles and blurs.
+//
+// PixelSnappingSprites snaps positions in the destination image's pixels.
+// For the screen image, these are the game's logical pixels given by Layout, not device pixels.
+// When the screen is scaled by the window size or the device scale factor, one logical pixel can be multiple
+// device pixels, and a snapped sprite still moves by one logical pixel at a time.
+// Use PixelSnappingScreen to also align the scaled screen itself with device pixels.
+//
+// Every vertex is snapped, so a scaled sprite's size can change by up to one pixel, and a rotated or skewed sprite
+// can be slightly distorted.
+//
+// The mode is global to the process and affects all the images, including offscreen images.
+//
+// SetPixelSnapping is concurrent-safe. The new mode applies to draw calls made after SetPixelSnapping returns.
+func SetPixelSnapping(mode PixelSnappingMode) {
+	pixelSnappingMode.Store(int32(mode))
+}
+
+// PixelSnapping returns the current pixel snapping mode.
+//
+// PixelSnapping is concurrent-safe.
+func PixelSnapping() PixelSnappingMode {
+	return PixelSnappingMode(pixelSnappingMode.Load())
+}
+
+// snapVertices rounds the destination positions of the vertices vs to integers if mode is enabled.
+func snapVertices(vs []float32, mode PixelSnappingMode) {
+	if PixelSnapping()&mode == 0 {
+		return
+	}
+	for i := 0; i < len(vs); i += graphics.VertexFloatCount {
+		vs[i] = float32(math.Round(float64(vs[i])))
+		vs[i+1] = float32(math.Round(float64(vs[i+1])))
+	}
+}
+
+// snapTranslation returns geoM whose translation is rounded to integers if mode is enabled.
+func snapTranslation(geoM GeoM, mode PixelSnappingMode) GeoM {
+	if PixelSnapping()&mode == 0 {
+		return geoM
+	}
+	geoM.SetElement(0, 2, math.Round(geoM.Element(0, 2)))
+	geoM.SetElement(1, 2, math.Round(geoM.Element(1, 2)))
+	return geoM
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/power"
+)
+
+// PowerState returns the power status of the device.
+//
+// onBattery reports whether the device is running on battery power.
+// lowPowerMode reports whether a power saving mode, like Windows' battery saver, is enabled.
+// ok reports whether the power status is available. If ok is false, the other values are false.
+//
+// PowerState is useful to reduce the frame rate or effects to save power.
+//
+// PowerState works on Windows and Linux so far. On Linux, lowPowerMode reflects the ACPI platform profile.
+// On the other environments, ok is always false.
+//
+// PowerState is concurrent-safe.
+func PowerState() (onBattery bool, lowPowerMode bool, ok bool) {
+	return power.State()
+}
@@ -20,6 +20,7 @@ import (
 	"image/color"
 	"io/fs"
 	"sync/atomic"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/clock"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphicsdriver"
@@ -149,6 +150,37 @@ func ActualFPS() float64 {
 	return clock.ActualFPS()
 }
 
+// FrameTimeStatistics represents statistics of the frame times over the recent frames.
+type FrameTimeStatistics struct {
+	// Min is the shortest frame time.
+	Min time.Duration
+
+	// Max is the longest frame time.
+	Max time.Duration
+
+	// Mean is the average frame time.
+	Mean time.Duration
+
+	// P95 is the 95th percentile of the frame times.
+	P95 time.Duration
+
+	// P99 is the 99th percentile of the frame times.
+	P99 time.Duration
+}
+
+// FrameTimeStats returns statistics of the frame times over the recent frames.
+// The window is the last 300 frames.
+//
+// Unlike ActualFPS, FrameTimeStats reveals stutters, which an average number of frames hides.
+// If no frame has been rendered yet, FrameTimeStats returns the zero value.
+//
+// This value is for measurement and/or debug, and your game logic should not rely on this value.
+//
+// FrameTimeStats is concurrent-safe.
+func FrameTimeStats() FrameTimeStatistics {
+	return FrameTimeStatistics(clock.CurrentFrameTimeStats())
+}
+
 // CurrentFPS returns the current number of FPS (frames per second), that represents
 // how many swapping buffer happens per second.
 //
@@ -176,6 +208,43 @@ func IsScreenClearedEveryFrame() bool {
 	return ui.Get().IsScreenClearedEveryFrame()
 }
 
+// SetScreenClearColor sets the color to fill the screen at the beginning of each frame,
+// before the game's screen image is drawn onto it.
+//
+// The color is visible outside of the game's screen image, e.g. the letterboxing bars when the aspect ratios of
+// the window and the game's screen don't match, or the gaps in fullscreen mode.
+// The color doesn't affect the game's screen image given to Draw.
+//
+// If clr is nil, the default behavior is used: the screen is cleared with transparent black (0, 0, 0, 0),
+// which is usually shown as black.
+//
+// SetScreenClearColor is concurrent-safe.
+func SetScreenClearColor(clr color.Color) {
+	if clr == nil {
+		ui.Get().ResetScreenClearColor()
+		return
+	}
+	cr, cg, cb, ca := clr.RGBA()
+	ui.Get().SetScreenClearColor(float32(cr)/0xffff, float32(cg)/0xffff, float32(cb)/0xffff, float32(ca)/0xffff)
+}
+
+// ScreenClearColor returns the color set by SetScreenClearColor.
+// If no color is set, ScreenClearColor returns nil.
+//
+// ScreenClearColor is concurrent-safe.
+func ScreenClearColor() color.Color {
+	r, g, b, a, ok := ui.Get().ScreenClearColor()
+	if !ok {
+		return nil
+	}
+	return color.RGBA64{
+		R: uint16(r*0xffff + 0.5),
+		G: uint16(g*0xffff + 0.5),
+		B: uint16(b*0xffff + 0.5),
+		A: uint16(a*0xffff + 0.5),
+	}
+}
+
 // SetScreenFilterEnabled enables/disables the use of the "screen" filter Ebitengine uses.
 //
 // The "screen" filter is a box filter from game to display resolution.
@@ -242,6 +311,10 @@ func RunGame(game Game) error {
 type RunGameOptions struct {
 	// GraphicsLibrary is a graphics library Ebitengine will use.
 	//
+	// GraphicsLibrary takes precedence over the environment variable EBITENGINE_GRAPHICS_LIBRARY.
+	// This is useful to choose the graphics library programmatically, e.g. for compatibility testing.
+	// If the graphics library is not available in the current environment, RunGameWithOptions returns an error.
+	//
 	// The default (zero) value is GraphicsLibraryAuto, which lets Ebitengine choose the graphics library.
 	GraphicsLibrary GraphicsLibrary
 
@@ -488,6 +561,8 @@ func SetRunnableOnUnfocused(runnableOnUnfocused bool) {
 //
 // BUG: DeviceScaleFactor value is not affected by SetWindowPosition before RunGame (#1575).
 //
+// To be notified of changes of the device scale factor, use SetDeviceScaleChangeCallback.
+//
 // Deprecated: as of v2.6. Use Monitor().DeviceScaleFactor() instead.
 func DeviceScaleFactor() float64 {
 	return Monitor().DeviceScaleFactor()
@@ -575,6 +650,19 @@ func ScheduleFrame() {
 	ui.Get().ScheduleFrame()
 }
 
+// FlushGraphicsCommands executes all the graphics commands queued so far without ending the current frame.
+//
+// FlushGraphicsCommands is useful to make the timing of GPU executions deterministic, e.g. for benchmarking,
+// or to interleave other rendering with Ebitengine's rendering.
+//
+// Ebitengine batches draw commands as much as possible, and FlushGraphicsCommands breaks the batching.
+// Calling FlushGraphicsCommands too often might reduce the performance.
+//
+// FlushGraphicsCommands must be called after the game starts, e.g. in Update or Draw.
+func FlushGraphicsCommands() error {
+	return ui.Get().FlushGraphicsCommands()
+}
+
 // TPS returns the current maximum TPS.
 //
 // TPS is concurrent-safe.
@@ -627,6 +715,33 @@ func SetTPS(tps int) {
 	clock.SetTPS(tps)
 }
 
+// UnfocusedTPS returns the maximum TPS used when the window is not focused.
+//
+// UnfocusedTPS returns a negative value if the unfocused TPS is not set.
+//
+// UnfocusedTPS is concurrent-safe.
+func UnfocusedTPS() int {
+	return clock.UnfocusedTPS()
+}
+
+// SetUnfocusedTPS sets the maximum TPS (ticks per second) used when the window is not focused.
+// When the window is focused again, the TPS specified by SetTPS is used.
+//
+// If tps is 0, Update is not called while the window is not focused.
+// If tps is greater than TPS, TPS is used.
+// If tps is negative, the unfocused TPS is not set and TPS is used regardless of the focus state.
+// The initial value is -1.
+//
+// SetUnfocusedTPS is effective only when the game runs on unfocused. See also SetRunnableOnUnfocused.
+//
+// SetUnfocusedTPS does nothing on mobiles and Windows so far.
+// On Windows, the window is always treated as focused (#987).
+//
+// SetUnfocusedTPS is concurrent-safe.
+func SetUnfocusedTPS(tps int) {
+	clock.SetUnfocusedTPS(tps)
+}
+
 // SetMaxTPS sets the maximum TPS (ticks per second),
 // that represents how many times updating function is called per second.
 //
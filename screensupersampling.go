@@ -0,0 +1,65 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+var (
+	screenSupersampling atomic.Bool
+	gameScreenImage     atomic.Pointer[ui.Image]
+)
+
+// SetScreenSupersampling enables or disables supersampling anti-aliasing of the whole game screen.
+//
+// If enabled is true, every draw onto the game screen image, including its sub-images, is rendered
+// as if AntiAlias in DrawTrianglesOptions were true.
+// Each draw call is rendered into a double-sized offscreen, i.e. 2x2 samples per pixel, and then downsampled
+// onto the screen.
+// This is not multisample anti-aliasing (MSAA) with a multisampled framebuffer: the cost is proportional to the
+// number of the draw calls, and the draw calls onto the screen are not batched efficiently.
+//
+// The default state is false.
+//
+// The screen supersampling is useful for vector or geometry-heavy games.
+// Pixel-art games should keep it disabled.
+//
+// Offscreen images are not affected. Use AntiAlias in DrawTrianglesOptions for them.
+//
+// SetScreenSupersampling is concurrent-safe. The state is applied to draw calls made after SetScreenSupersampling returns.
+func SetScreenSupersampling(enabled bool) {
+	screenSupersampling.Store(enabled)
+}
+
+// IsScreenSupersamplingEnabled reports whether supersampling anti-aliasing of the game screen is enabled.
+//
+// IsScreenSupersamplingEnabled is concurrent-safe.
+func IsScreenSupersamplingEnabled() bool {
+	return screenSupersampling.Load()
+}
+
+// antialias reports whether a draw onto i should use anti-alias rendering.
+func (i *Image) antialias(requested bool) bool {
+	if requested {
+		return true
+	}
+	if !IsScreenSupersamplingEnabled() {
+		return false
+	}
+	return i.image == gameScreenImage.Load()
+}
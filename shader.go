@@ -20,6 +20,7 @@ import (
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/internal/debug"
 	"github.com/hajimehoshi/ebiten/v2/internal/graphics"
 	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -31,6 +32,10 @@ import (
 type Shader struct {
 	shader *ui.Shader
 	unit   shaderir.Unit
+
+	// ir is the compiled program for CompiledSource.
+	// ir is kept only when debugging is enabled by the build tag ebitenginedebug.
+	ir *shaderir.Program
 }
 
 // NewShader compiles a shader program in the shading language Kage, and returns the result.
@@ -47,10 +52,14 @@ func newShader(src []byte, name string) (*Shader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Shader{
+	s := &Shader{
 		shader: ui.NewShader(ir, name),
 		unit:   ir.Unit,
-	}, nil
+	}
+	if debug.IsDebug {
+		s.ir = ir
+	}
+	return s, nil
 }
 
 // Dispose disposes the shader program.
@@ -87,17 +96,21 @@ func (s *Shader) appendUniforms(dst []uint32, uniforms map[string]any) []uint32
 }
 
 var (
-	builtinShadersForRead atomic.Pointer[[builtinshader.FilterCount][builtinshader.AddressCount][2]*Shader]
+	builtinShadersForRead atomic.Pointer[[builtinshader.FilterCount][builtinshader.AddressCount][2][2]*Shader]
 	builtinShadersM       sync.Mutex
 )
 
-func builtinShader(filter builtinshader.Filter, address builtinshader.Address, useColorM bool) *Shader {
+func builtinShader(filter builtinshader.Filter, address builtinshader.Address, useColorM bool, useVertexColors bool) *Shader {
 	var c int
 	if useColorM {
 		c = 1
 	}
+	var v int
+	if useVertexColors {
+		v = 1
+	}
 	if read := builtinShadersForRead.Load(); read != nil {
-		if s := (*read)[filter][address][c]; s != nil {
+		if s := (*read)[filter][address][c][v]; s != nil {
 			return s
 		}
 	}
@@ -107,14 +120,14 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 
 	// Double check in case another goroutine already created a shader.
 	if read := builtinShadersForRead.Load(); read != nil {
-		if s := (*read)[filter][address][c]; s != nil {
+		if s := (*read)[filter][address][c][v]; s != nil {
 			return s
 		}
 	}
 
 	var shader *Shader
 	if (filter == builtinshader.FilterNearest || filter == builtinshader.FilterLinear) &&
-		address == builtinshader.AddressUnsafe && !useColorM {
+		address == builtinshader.AddressUnsafe && !useColorM && useVertexColors {
 		switch filter {
 		case builtinshader.FilterNearest:
 			shader = &Shader{shader: ui.NearestFilterShader}
@@ -122,7 +135,12 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 			shader = &Shader{shader: ui.LinearFilterShader}
 		}
 	} else {
-		src := builtinshader.ShaderSource(filter, address, useColorM)
+		var src []byte
+		if useVertexColors {
+			src = builtinshader.ShaderSource(filter, address, useColorM)
+		} else {
+			src = builtinshader.ShaderSourceWithoutVertexColors(filter, address, useColorM)
+		}
 		var name string
 		switch filter {
 		case builtinshader.FilterNearest:
@@ -137,10 +155,15 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 			name += "-clamptozero"
 		case builtinshader.AddressRepeat:
 			name += "-repeat"
+		case builtinshader.AddressMirroredRepeat:
+			name += "-mirroredrepeat"
 		}
 		if useColorM {
 			name += "-colorm"
 		}
+		if !useVertexColors {
+			name += "-novertexcolors"
+		}
 		s, err := newShader(src, name)
 		if err != nil {
 			panic(fmt.Sprintf("ebiten: NewShader for a built-in shader failed: %v", err))
@@ -148,11 +171,38 @@ func builtinShader(filter builtinshader.Filter, address builtinshader.Address, u
 		shader = s
 	}
 
-	var shaders [builtinshader.FilterCount][builtinshader.AddressCount][2]*Shader
+	var shaders [builtinshader.FilterCount][builtinshader.AddressCount][2][2]*Shader
 	if ptr := builtinShadersForRead.Load(); ptr != nil {
 		shaders = *ptr
 	}
-	shaders[filter][address][c] = shader
+	shaders[filter][address][c][v] = shader
 	builtinShadersForRead.Store(&shaders)
 	return shader
 }
+
+var (
+	effectShaders  = map[string]*Shader{}
+	effectShadersM sync.Mutex
+)
+
+// effectShader returns a compiled shader for a built-in effect.
+// name is a canonical name of the built-in shader. See builtinshader.ShaderNames.
+// The shader is compiled at the first call for the given name and cached.
+func effectShader(name string) *Shader {
+	effectShadersM.Lock()
+	defer effectShadersM.Unlock()
+
+	if s, ok := effectShaders[name]; ok {
+		return s
+	}
+	src, ok := builtinshader.ShaderSourceByName(name)
+	if !ok {
+		panic(fmt.Sprintf("ebiten: unknown built-in shader: %s", name))
+	}
+	s, err := newShader([]byte(src), name)
+	if err != nil {
+		panic(fmt.Sprintf("ebiten: NewShader for a built-in shader %s failed: %v", name, err))
+	}
+	effectShaders[name] = s
+	return s
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"errors"
+)
+
+// ShaderTarget represents a target shading language of a backend.
+type ShaderTarget int
+
+const (
+	// ShaderTargetGLSL represents GLSL for desktop OpenGL.
+	ShaderTargetGLSL ShaderTarget = iota
+
+	// ShaderTargetGLSLES represents GLSL ES for OpenGL ES and WebGL.
+	ShaderTargetGLSLES
+
+	// ShaderTargetHLSL represents HLSL for DirectX.
+	ShaderTargetHLSL
+
+	// ShaderTargetMSL represents Metal Shading Language.
+	ShaderTargetMSL
+)
+
+// CompiledSource returns the shader source translated from Kage into the given target language.
+//
+// CompiledSource is for debugging, e.g. to investigate why a shader behaves differently among backends.
+// The result is the same as what the backend uses, but the format might be changed in the future.
+// For a target that has separated shader stages, the result includes all of them.
+//
+// CompiledSource works only with the build tag `ebitenginedebug`. Otherwise, CompiledSource returns an error.
+func (s *Shader) CompiledSource(target ShaderTarget) (string, error) {
+	if s.isDisposed() {
+		return "", errors.New("ebiten: the shader is disposed")
+	}
+	return compiledShaderSource(s.ir, target)
+}
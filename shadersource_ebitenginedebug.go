@@ -0,0 +1,44 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ebitenginedebug || ebitendebug
+
+package ebiten
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/glsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/hlsl"
+	"github.com/hajimehoshi/ebiten/v2/internal/shaderir/msl"
+)
+
+func compiledShaderSource(ir *shaderir.Program, target ShaderTarget) (string, error) {
+	switch target {
+	case ShaderTargetGLSL:
+		vs, fs := glsl.Compile(ir, glsl.GLSLVersionDefault)
+		return "// Vertex shader\n" + vs + "\n// Fragment shader\n" + fs, nil
+	case ShaderTargetGLSLES:
+		vs, fs := glsl.Compile(ir, glsl.GLSLVersionES300)
+		return "// Vertex shader\n" + vs + "\n// Fragment shader\n" + fs, nil
+	case ShaderTargetHLSL:
+		vs, ps, _ := hlsl.Compile(ir)
+		return "// Vertex shader\n" + vs + "\n// Pixel shader\n" + ps, nil
+	case ShaderTargetMSL:
+		return msl.Compile(ir), nil
+	default:
+		return "", fmt.Errorf("ebiten: unexpected shader target: %d", target)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//go:build ebitenginedebug || ebitendebug
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestShaderCompiledSource(t *testing.T) {
+	s, err := ebiten.NewShader([]byte(`//kage:unit pixels
+
+package main
+
+var Foo float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	return vec4(Foo)
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Deallocate()
+
+	srcs := map[string]ebiten.ShaderTarget{}
+	for _, target := range []ebiten.ShaderTarget{ebiten.ShaderTargetGLSL, ebiten.ShaderTargetGLSLES, ebiten.ShaderTargetHLSL, ebiten.ShaderTargetMSL} {
+		src, err := s.CompiledSource(target)
+		if err != nil {
+			t.Errorf("CompiledSource(%d) failed: %v", target, err)
+			continue
+		}
+		if src == "" {
+			t.Errorf("CompiledSource(%d) must not be empty", target)
+		}
+		// Each target has its own language.
+		if t0, ok := srcs[src]; ok {
+			t.Errorf("CompiledSource(%d) and CompiledSource(%d) must be different", t0, target)
+		}
+		srcs[src] = target
+	}
+
+	if _, err := s.CompiledSource(ebiten.ShaderTarget(-1)); err == nil {
+		t.Errorf("CompiledSource with an invalid target must return an error")
+	}
+}
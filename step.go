@@ -0,0 +1,67 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/internal/ui"
+)
+
+// StartGame initializes the game without entering the game loop, so that the caller can drive the loop by Step.
+//
+// StartGame is an alternative to RunGameWithOptions for applications that need to control the game loop by themselves,
+// e.g. to integrate Ebitengine with another framework's loop.
+// The game always runs in the single thread mode regardless of options.SingleThread.
+//
+// After StartGame succeeds, call Step repeatedly, and then call EndGame to terminate the game.
+// StartGame, Step, and EndGame must be called on the same OS thread. On macOS, this must be the main thread.
+//
+// StartGame works only on desktops. On the other environments, StartGame returns an error.
+func StartGame(game Game, options *RunGameOptions) error {
+	initializeWindowPositionIfNeeded(WindowSize())
+
+	op := toUIRunOptions(options)
+	op.SingleThread = true
+	// This is necessary to change the result of IsScreenTransparent.
+	screenTransparent.Store(op.ScreenTransparent)
+	g := newGameForUI(game, op.ScreenTransparent)
+
+	if err := ui.Get().StartStepping(g, op); err != nil {
+		isRunGameEnded_.Store(true)
+		return err
+	}
+	return nil
+}
+
+// Step advances the game started by StartGame by one iteration of the game loop.
+// Step might call the game's Update zero or more times, and calls Draw once when needed.
+//
+// If the game's Update returns an error, or the window is closed, Step returns a non-nil error.
+// Termination is returned as it is in this case, and the caller should call EndGame.
+//
+// If the game is not started by StartGame, Step returns an error.
+func Step() error {
+	return ui.Get().Step()
+}
+
+// EndGame terminates the game started by StartGame.
+//
+// If the game is not started by StartGame, or is already ended, EndGame does nothing and returns nil.
+func EndGame() error {
+	stopped, err := ui.Get().StopStepping()
+	if stopped {
+		isRunGameEnded_.Store(true)
+	}
+	return err
+}
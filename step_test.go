@@ -0,0 +1,37 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ebiten_test
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestStepWithoutStartGame(t *testing.T) {
+	if err := ebiten.Step(); err == nil {
+		t.Errorf("Step without StartGame must return an error")
+	}
+}
+
+func TestEndGameWithoutStartGame(t *testing.T) {
+	if err := ebiten.EndGame(); err != nil {
+		t.Errorf("EndGame without StartGame must return nil but: %v", err)
+	}
+	// Calling EndGame twice must also be safe.
+	if err := ebiten.EndGame(); err != nil {
+		t.Errorf("EndGame without StartGame must return nil but: %v", err)
+	}
+}
@@ -400,6 +400,11 @@ func (g *GoTextFace) appendVectorPathForLine(path *vector.Path, line string, ori
 	}
 }
 
+// drawGlyphsForLine implements Face.
+func (g *GoTextFace) drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions) {
+	drawGlyphs(dst, g.appendGlyphsForLine(nil, line, 0, originX, originY), options)
+}
+
 // direction implements Face.
 func (g *GoTextFace) direction() Direction {
 	return g.Direction
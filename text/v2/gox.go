@@ -250,6 +250,11 @@ func (g *GoXFace) direction() Direction {
 func (g *GoXFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
 }
 
+// drawGlyphsForLine implements Face.
+func (g *GoXFace) drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions) {
+	drawGlyphs(dst, g.appendGlyphsForLine(nil, line, 0, originX, originY), options)
+}
+
 // Metrics implements Face.
 func (g *GoXFace) private() {
 }
@@ -111,16 +111,23 @@ func Draw(dst *ebiten.Image, text string, face Face, options *DrawOptions) {
 		drawOp = options.DrawImageOptions
 	}
 
-	geoM := drawOp.GeoM
+	forEachLine(text, face, &layoutOp, func(line string, indexOffset int, originX, originY float64) {
+		face.drawGlyphsForLine(dst, line, originX, originY, &drawOp)
+	})
+}
 
-	for _, g := range AppendGlyphs(nil, text, face, &layoutOp) {
+// drawGlyphs draws the glyphs' images.
+// options.GeoM is applied after each image is translated to the glyph's position.
+func drawGlyphs(dst *ebiten.Image, glyphs []Glyph, options *ebiten.DrawImageOptions) {
+	op := *options
+	for _, g := range glyphs {
 		if g.Image == nil {
 			continue
 		}
-		drawOp.GeoM.Reset()
-		drawOp.GeoM.Translate(g.X, g.Y)
-		drawOp.GeoM.Concat(geoM)
-		dst.DrawImage(g.Image, &drawOp)
+		op.GeoM.Reset()
+		op.GeoM.Translate(g.X, g.Y)
+		op.GeoM.Concat(options.GeoM)
+		dst.DrawImage(g.Image, &op)
 	}
 }
 
@@ -15,6 +15,7 @@
 package text
 
 import (
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -66,6 +67,11 @@ func (l *LimitedFace) appendVectorPathForLine(path *vector.Path, line string, or
 	l.face.appendVectorPathForLine(path, l.unicodeRanges.filter(line), originX, originY)
 }
 
+// drawGlyphsForLine implements Face.
+func (l *LimitedFace) drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions) {
+	l.face.drawGlyphsForLine(dst, l.unicodeRanges.filter(line), originX, originY, options)
+}
+
 // direction implements Face.
 func (l *LimitedFace) direction() Direction {
 	return l.face.direction()
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"unicode/utf8"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -144,6 +145,23 @@ func (m *MultiFace) appendVectorPathForLine(path *vector.Path, line string, orig
 	}
 }
 
+// drawGlyphsForLine implements Face.
+func (m *MultiFace) drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions) {
+	for _, c := range m.splitText(line) {
+		if c.faceIndex == -1 {
+			continue
+		}
+		f := m.faces[c.faceIndex]
+		t := line[c.textStartIndex:c.textEndIndex]
+		f.drawGlyphsForLine(dst, t, originX, originY, options)
+		if a := f.advance(t); f.direction().isHorizontal() {
+			originX += a
+		} else {
+			originY += a
+		}
+	}
+}
+
 // direction implements Face.
 func (m *MultiFace) direction() Direction {
 	if len(m.faces) == 0 {
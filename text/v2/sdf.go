@@ -0,0 +1,362 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"sync"
+
+	"github.com/go-text/typesetting/font"
+	"github.com/go-text/typesetting/font/opentype"
+	"golang.org/x/image/math/fixed"
+	gvector "golang.org/x/image/vector"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var _ Face = (*SDFFace)(nil)
+
+// SDFFace is a Face implementation rendering glyphs with signed distance fields (SDF).
+//
+// An SDFFace rasterizes each glyph as a distance field only once at the base size,
+// and renders it at any size with a shader.
+// Thus, glyphs stay crisp when the text is scaled up, and changing Size gradually is efficient unlike GoTextFace.
+//
+// SDFFace is rendered correctly only with Draw, including when it is a part of a composite face like MultiFace.
+// Glyph images returned by AppendGlyphs are distance field images at the base size,
+// and are not suitable to be drawn with DrawImage directly.
+//
+// Draw samples the distance field bilinearly only when DrawImageOptions.Filter is FilterLinear.
+// With the other filters, scaled glyphs look blocky like other images drawn with FilterNearest.
+//
+// Very thin features of glyphs might be lost when the base size is small.
+type SDFFace struct {
+	// Source is the font face source.
+	Source *GoTextFaceSource
+
+	// Direction is the rendering direction.
+	// The default (zero) value is left-to-right horizontal.
+	Direction Direction
+
+	// Size is the font size in pixels.
+	Size float64
+
+	baseSize float64
+	spread   int
+
+	glyphImageCache *cache[font.GID, *sdfGlyphImage]
+
+	addr *SDFFace
+}
+
+type sdfGlyphImage struct {
+	image *ebiten.Image
+
+	// x and y are the position of the image relative to the glyph's origin at the base size.
+	x float64
+	y float64
+}
+
+// NewSDFFace creates a new SDFFace from the source.
+//
+// baseSize is the font size in pixels to rasterize distance fields.
+// A larger base size keeps finer details of glyphs, but consumes more memory.
+// The initial Size is baseSize.
+func NewSDFFace(source *GoTextFaceSource, baseSize float64) *SDFFace {
+	if baseSize <= 0 {
+		panic(fmt.Sprintf("text: baseSize must be positive but %f", baseSize))
+	}
+	s := &SDFFace{
+		Source:   source,
+		Size:     baseSize,
+		baseSize: baseSize,
+		spread:   max(4, int(math.Ceil(baseSize/8))),
+		// Distance fields don't depend on the size or subpixel positions,
+		// so one image per glyph is enough unlike GoTextFace.
+		glyphImageCache: newCache[font.GID, *sdfGlyphImage](128),
+	}
+	s.addr = s
+	return s
+}
+
+func (s *SDFFace) copyCheck() {
+	if s.addr != s {
+		panic("text: illegal use of non-zero SDFFace copied by value")
+	}
+}
+
+func (s *SDFFace) goTextFace() *GoTextFace {
+	return &GoTextFace{
+		Source:    s.Source,
+		Direction: s.Direction,
+		Size:      s.Size,
+	}
+}
+
+// Metrics implements Face.
+func (s *SDFFace) Metrics() Metrics {
+	return s.goTextFace().Metrics()
+}
+
+// advance implements Face.
+func (s *SDFFace) advance(text string) float64 {
+	return s.goTextFace().advance(text)
+}
+
+// hasGlyph implements Face.
+func (s *SDFFace) hasGlyph(r rune) bool {
+	return s.goTextFace().hasGlyph(r)
+}
+
+// appendGlyphsForLine implements Face.
+func (s *SDFFace) appendGlyphsForLine(glyphs []Glyph, line string, indexOffset int, originX, originY float64) []Glyph {
+	s.copyCheck()
+
+	f := s.goTextFace()
+	scale := s.Size / s.baseSize
+
+	origin := fixed.Point26_6{
+		X: float64ToFixed26_6(originX),
+		Y: float64ToFixed26_6(originY),
+	}
+	_, gs := s.Source.shape(line, f)
+	for _, glyph := range gs {
+		o := origin.Add(fixed.Point26_6{
+			X: glyph.shapingGlyph.XOffset,
+			Y: -glyph.shapingGlyph.YOffset,
+		})
+
+		// Append a glyph even if the image is nil.
+		// This is necessary to return index information for control characters.
+		g := Glyph{
+			StartIndexInBytes: indexOffset + glyph.startIndex,
+			EndIndexInBytes:   indexOffset + glyph.endIndex,
+			GID:               uint32(glyph.shapingGlyph.GlyphID),
+			X:                 fixed26_6ToFloat64(o.X),
+			Y:                 fixed26_6ToFloat64(o.Y),
+			OriginX:           fixed26_6ToFloat64(origin.X),
+			OriginY:           fixed26_6ToFloat64(origin.Y),
+			OriginOffsetX:     fixed26_6ToFloat64(glyph.shapingGlyph.XOffset),
+			OriginOffsetY:     fixed26_6ToFloat64(-glyph.shapingGlyph.YOffset),
+		}
+		if img := s.glyphImage(glyph); img != nil {
+			g.Image = img.image
+			g.X += img.x * scale
+			g.Y += img.y * scale
+		}
+		glyphs = append(glyphs, g)
+
+		origin = origin.Add(fixed.Point26_6{
+			X: glyph.shapingGlyph.XAdvance,
+			Y: -glyph.shapingGlyph.YAdvance,
+		})
+	}
+
+	return glyphs
+}
+
+func (s *SDFFace) glyphImage(glyph glyph) *sdfGlyphImage {
+	if s.Size <= 0 {
+		return nil
+	}
+
+	return s.glyphImageCache.getOrCreate(glyph.shapingGlyph.GlyphID, func() (*sdfGlyphImage, bool) {
+		// The segments are scaled linearly by the size. Get the segments at the base size.
+		k := float32(s.baseSize / s.Size)
+		segs := make([]opentype.Segment, len(glyph.scaledSegments))
+		for i, seg := range glyph.scaledSegments {
+			segs[i] = seg
+			for j := range seg.Args {
+				segs[i].Args[j].X *= k
+				segs[i].Args[j].Y *= k
+			}
+		}
+		return segmentsToSDFImage(segs, s.spread), true
+	})
+}
+
+// appendVectorPathForLine implements Face.
+func (s *SDFFace) appendVectorPathForLine(path *vector.Path, line string, originX, originY float64) {
+	s.goTextFace().appendVectorPathForLine(path, line, originX, originY)
+}
+
+// drawGlyphsForLine implements Face.
+func (s *SDFFace) drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions) {
+	shader := ensureSDFShader()
+	scale := s.Size / s.baseSize
+
+	filter := 0
+	if options.Filter == ebiten.FilterLinear {
+		filter = 1
+	}
+	// ColorM is deprecated, but is still honoured as DrawImage does.
+	var body [16]float32
+	var translation [4]float32
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			body[4*j+i] = float32(options.ColorM.Element(i, j))
+		}
+		translation[i] = float32(options.ColorM.Element(i, 4))
+	}
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.ColorScale = options.ColorScale
+	op.CompositeMode = options.CompositeMode
+	op.Blend = options.Blend
+	op.ColorMask = options.ColorMask
+	op.Uniforms = map[string]any{
+		builtinshader.UniformFilter:            filter,
+		builtinshader.UniformColorMBody:        body[:],
+		builtinshader.UniformColorMTranslation: translation[:],
+	}
+
+	for _, g := range s.appendGlyphsForLine(nil, line, 0, originX, originY) {
+		if g.Image == nil {
+			continue
+		}
+		op.GeoM.Reset()
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(g.X, g.Y)
+		op.GeoM.Concat(options.GeoM)
+		op.Images[0] = g.Image
+		b := g.Image.Bounds()
+		dst.DrawRectShader(b.Dx(), b.Dy(), shader, op)
+	}
+}
+
+// direction implements Face.
+func (s *SDFFace) direction() Direction {
+	return s.Direction
+}
+
+// private implements Face.
+func (s *SDFFace) private() {
+}
+
+// segmentsToSDFImage rasterizes the segments and creates a distance field image.
+// spread is the maximum distance in pixels that the distance field represents.
+func segmentsToSDFImage(segs []opentype.Segment, spread int) *sdfGlyphImage {
+	if len(segs) == 0 {
+		return nil
+	}
+
+	b := segmentsToBounds(segs)
+	minX, minY := b.Min.X.Floor()-spread, b.Min.Y.Floor()-spread
+	maxX, maxY := b.Max.X.Ceil()+spread, b.Max.Y.Ceil()+spread
+	w, h := maxX-minX, maxY-minY
+	if w <= 2*spread || h <= 2*spread {
+		return nil
+	}
+
+	biasX := float32(-minX)
+	biasY := float32(-minY)
+
+	rast := gvector.NewRasterizer(w, h)
+	rast.DrawOp = draw.Src
+	for _, seg := range segs {
+		switch seg.Op {
+		case opentype.SegmentOpMoveTo:
+			rast.MoveTo(seg.Args[0].X+biasX, seg.Args[0].Y+biasY)
+		case opentype.SegmentOpLineTo:
+			rast.LineTo(seg.Args[0].X+biasX, seg.Args[0].Y+biasY)
+		case opentype.SegmentOpQuadTo:
+			rast.QuadTo(
+				seg.Args[0].X+biasX, seg.Args[0].Y+biasY,
+				seg.Args[1].X+biasX, seg.Args[1].Y+biasY,
+			)
+		case opentype.SegmentOpCubeTo:
+			rast.CubeTo(
+				seg.Args[0].X+biasX, seg.Args[0].Y+biasY,
+				seg.Args[1].X+biasX, seg.Args[1].Y+biasY,
+				seg.Args[2].X+biasX, seg.Args[2].Y+biasY,
+			)
+		}
+	}
+	rast.ClosePath()
+
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+	rast.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+	inside := func(x, y int) bool {
+		if x < 0 || y < 0 || x >= w || y >= h {
+			return false
+		}
+		return mask.Pix[y*mask.Stride+x] >= 0x80
+	}
+
+	// Calculate the distance to the nearest pixel in the opposite state within the spread.
+	// The distance field is 0.5 at the edges, and changes by 0.5/spread per pixel.
+	pix := make([]byte, 4*w*h)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			in := inside(i, j)
+			d2 := spread * spread
+			for y := max(j-spread, 0); y <= min(j+spread, h-1); y++ {
+				for x := max(i-spread, 0); x <= min(i+spread, w-1); x++ {
+					if inside(x, y) == in {
+						continue
+					}
+					if dd := (x-i)*(x-i) + (y-j)*(y-j); dd < d2 {
+						d2 = dd
+					}
+				}
+			}
+			d := math.Sqrt(float64(d2)) - 0.5
+			if !in {
+				d = -d
+			}
+			v := byte(min(max((0.5+d/float64(2*spread))*0xff+0.5, 0), 0xff))
+			idx := 4 * (j*w + i)
+			pix[idx] = v
+			pix[idx+1] = v
+			pix[idx+2] = v
+			pix[idx+3] = v
+		}
+	}
+
+	img := ebiten.NewImage(w, h)
+	img.WritePixels(pix)
+	return &sdfGlyphImage{
+		image: img,
+		x:     float64(minX),
+		y:     float64(minY),
+	}
+}
+
+var (
+	sdfShader  *ebiten.Shader
+	sdfShaderM sync.Mutex
+)
+
+func ensureSDFShader() *ebiten.Shader {
+	sdfShaderM.Lock()
+	defer sdfShaderM.Unlock()
+
+	if sdfShader != nil {
+		return sdfShader
+	}
+	src, _ := builtinshader.ShaderSourceByName("sdf")
+	s, err := ebiten.NewShader([]byte(src))
+	if err != nil {
+		panic(fmt.Sprintf("text: NewShader for the SDF shader failed: %v", err))
+	}
+	sdfShader = s
+	return sdfShader
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+func TestSDFFaceAdvance(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := text.NewSDFFace(src, 16)
+	a0 := text.Advance("Hello", f)
+	f.Size = 64
+	a1 := text.Advance("Hello", f)
+	if got, want := a1, a0*4; math.Abs(got-want) > 1 {
+		t.Errorf("got: %f, want: %f", got, want)
+	}
+}
+
+func TestSDFFaceDraw(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := text.NewSDFFace(src, 16)
+	f.Size = 32
+
+	dst := ebiten.NewImage(64, 64)
+	text.Draw(dst, "I", f, nil)
+
+	var count int
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			if _, _, _, a := dst.At(i, j).RGBA(); a == 0xffff {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("no opaque pixels were rendered")
+	}
+}
+
+func TestSDFFaceDrawInMultiFace(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := text.NewSDFFace(src, 16)
+	f.Size = 32
+	mf, err := text.NewMultiFace(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An SDFFace in a MultiFace must be rendered in the same way as the SDFFace itself.
+	dst0 := ebiten.NewImage(64, 64)
+	text.Draw(dst0, "I", f, nil)
+	dst1 := ebiten.NewImage(64, 64)
+	text.Draw(dst1, "I", mf, nil)
+
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			if got, want := dst1.At(i, j), dst0.At(i, j); got != want {
+				t.Errorf("At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestSDFFaceDrawWithColorM(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := text.NewSDFFace(src, 16)
+	f.Size = 32
+
+	dst := ebiten.NewImage(64, 64)
+	op := &text.DrawOptions{}
+	op.ColorM.Scale(0, 1, 0, 1)
+	text.Draw(dst, "I", f, op)
+
+	var count int
+	for j := 0; j < 64; j++ {
+		for i := 0; i < 64; i++ {
+			r, g, b, a := dst.At(i, j).RGBA()
+			if r != 0 || b != 0 {
+				t.Fatalf("At(%d, %d): red and blue must be 0 but (%d, %d, %d, %d)", i, j, r, g, b, a)
+			}
+			if g == 0xffff && a == 0xffff {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("no opaque green pixels were rendered")
+	}
+}
+
+func TestSDFFaceDrawWithFilter(t *testing.T) {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := text.NewSDFFace(src, 8)
+	f.Size = 64
+
+	// When a glyph is magnified, the results must depend on the filter.
+	dst0 := ebiten.NewImage(128, 128)
+	op := &text.DrawOptions{}
+	op.Filter = ebiten.FilterNearest
+	text.Draw(dst0, "O", f, op)
+	dst1 := ebiten.NewImage(128, 128)
+	op.Filter = ebiten.FilterLinear
+	text.Draw(dst1, "O", f, op)
+
+	var diff bool
+	for j := 0; j < 128 && !diff; j++ {
+		for i := 0; i < 128; i++ {
+			if dst0.At(i, j) != dst1.At(i, j) {
+				diff = true
+				break
+			}
+		}
+	}
+	if !diff {
+		t.Errorf("the results with FilterNearest and FilterLinear must differ")
+	}
+}
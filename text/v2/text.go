@@ -38,6 +38,7 @@ type Face interface {
 
 	appendGlyphsForLine(glyphs []Glyph, line string, indexOffset int, originX, originY float64) []Glyph
 	appendVectorPathForLine(path *vector.Path, line string, originX, originY float64)
+	drawGlyphsForLine(dst *ebiten.Image, line string, originX, originY float64, options *ebiten.DrawImageOptions)
 
 	direction() Direction
 
@@ -0,0 +1,154 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transition provides scene transition effects between two images.
+package transition
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/internal/builtinshader"
+)
+
+// Options represents options for the transition functions.
+type Options struct {
+	// GeoM is a geometry matrix to draw.
+	// The default (zero) value is identity, which draws the result at (0, 0).
+	GeoM ebiten.GeoM
+
+	// ColorScale is a scale of color.
+	// The default (zero) value is identity, which is (1, 1, 1, 1).
+	ColorScale ebiten.ColorScale
+
+	// Blend is a blending way of the source color and the destination color.
+	// The default (zero) value is the regular alpha blending.
+	Blend ebiten.Blend
+}
+
+// Fade draws a cross-fade from the image from to the image to onto dst.
+//
+// progress is the progress of the transition in [0, 1].
+// When progress is 0, the result is from, and when progress is 1, the result is to.
+//
+// from and to must have the same size. Otherwise, Fade panics.
+func Fade(dst, from, to *ebiten.Image, progress float64, options *Options) {
+	draw(dst, from, to, nil, builtinshader.TransitionTypeFade, progress, options)
+}
+
+// WipeLeft draws a wipe from the image from to the image to onto dst.
+// The image to appears from the right edge, and the boundary moves to the left edge.
+//
+// progress is the progress of the transition in [0, 1].
+// When progress is 0, the result is from, and when progress is 1, the result is to.
+//
+// from and to must have the same size. Otherwise, WipeLeft panics.
+func WipeLeft(dst, from, to *ebiten.Image, progress float64, options *Options) {
+	draw(dst, from, to, nil, builtinshader.TransitionTypeWipeLeft, progress, options)
+}
+
+// CircleReveal draws a transition from the image from to the image to onto dst.
+// The image to appears in a circle growing from the center.
+//
+// progress is the progress of the transition in [0, 1].
+// When progress is 0, the result is from, and when progress is 1, the result is to.
+//
+// from and to must have the same size. Otherwise, CircleReveal panics.
+func CircleReveal(dst, from, to *ebiten.Image, progress float64, options *Options) {
+	draw(dst, from, to, nil, builtinshader.TransitionTypeCircleReveal, progress, options)
+}
+
+// Dissolve draws a dissolve from the image from to the image to onto dst.
+// The pixels of from are replaced with the pixels of to in the order determined by a noise.
+//
+// progress is the progress of the transition in [0, 1].
+// When progress is 0, the result is from, and when progress is 1, the result is to.
+//
+// The noise image is generated on GPU for the size of from, and is reused while the size is the same.
+//
+// from and to must have the same size. Otherwise, Dissolve panics.
+func Dissolve(dst, from, to *ebiten.Image, progress float64, options *Options) {
+	draw(dst, from, to, noiseImage(from.Bounds().Size()), builtinshader.TransitionTypeDissolve, progress, options)
+}
+
+func draw(dst, from, to, noise *ebiten.Image, transitionType int, progress float64, options *Options) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	fs, ts := from.Bounds().Size(), to.Bounds().Size()
+	if fs != ts {
+		panic(fmt.Sprintf("transition: the sizes of the images must be the same but %v and %v", fs, ts))
+	}
+
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM = options.GeoM
+	op.ColorScale = options.ColorScale
+	op.Blend = options.Blend
+	op.Images[0] = from
+	op.Images[1] = to
+	op.Images[2] = noise
+	op.Uniforms = map[string]any{
+		builtinshader.UniformTransitionType: transitionType,
+		builtinshader.UniformProgress:       float32(min(max(progress, 0), 1)),
+	}
+	dst.DrawRectShader(fs.X, fs.Y, ensureShader(), op)
+}
+
+var (
+	shader  *ebiten.Shader
+	shaderM sync.Mutex
+)
+
+func ensureShader() *ebiten.Shader {
+	shaderM.Lock()
+	defer shaderM.Unlock()
+
+	if shader != nil {
+		return shader
+	}
+	src, _ := builtinshader.ShaderSourceByName("transition")
+	s, err := ebiten.NewShader([]byte(src))
+	if err != nil {
+		panic(fmt.Sprintf("transition: NewShader for a built-in shader failed: %v", err))
+	}
+	shader = s
+	return shader
+}
+
+var (
+	noiseImg  *ebiten.Image
+	noiseImgM sync.Mutex
+)
+
+// noiseImage returns a noise image with the given size.
+// Only the last noise image is cached so that the memory usage is bounded.
+func noiseImage(size image.Point) *ebiten.Image {
+	noiseImgM.Lock()
+	defer noiseImgM.Unlock()
+
+	if noiseImg != nil && noiseImg.Bounds().Size() == size {
+		return noiseImg
+	}
+	if noiseImg != nil {
+		noiseImg.Deallocate()
+	}
+	noiseImg = ebiten.GenerateNoise(size.X, size.Y, &ebiten.NoiseOptions{
+		Frequency: 1.0 / 16,
+		Octaves:   3,
+	})
+	return noiseImg
+}
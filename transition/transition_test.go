@@ -0,0 +1,93 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transition_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+	"github.com/hajimehoshi/ebiten/v2/transition"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func TestTransitions(t *testing.T) {
+	const w, h = 16, 16
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	from := ebiten.NewImage(w, h)
+	from.Fill(red)
+	to := ebiten.NewImage(w, h)
+	to.Fill(blue)
+
+	for _, tc := range []struct {
+		name string
+		f    func(dst, from, to *ebiten.Image, progress float64, options *transition.Options)
+	}{
+		{"Fade", transition.Fade},
+		{"WipeLeft", transition.WipeLeft},
+		{"CircleReveal", transition.CircleReveal},
+		{"Dissolve", transition.Dissolve},
+	} {
+		for _, progress := range []float64{0, 1} {
+			dst := ebiten.NewImage(w, h)
+			tc.f(dst, from, to, progress, nil)
+
+			want := red
+			if progress == 1 {
+				want = blue
+			}
+			for j := 0; j < h; j++ {
+				for i := 0; i < w; i++ {
+					if got := dst.At(i, j); got != want {
+						t.Errorf("%s(%v): dst.At(%d, %d): got: %v, want: %v", tc.name, progress, i, j, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWipeLeft(t *testing.T) {
+	const w, h = 16, 16
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	from := ebiten.NewImage(w, h)
+	from.Fill(red)
+	to := ebiten.NewImage(w, h)
+	to.Fill(blue)
+
+	dst := ebiten.NewImage(w, h)
+	transition.WipeLeft(dst, from, to, 0.25, nil)
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			want := red
+			if i >= w*3/4 {
+				want = blue
+			}
+			if got := dst.At(i, j); got != want {
+				t.Errorf("dst.At(%d, %d): got: %v, want: %v", i, j, got, want)
+			}
+		}
+	}
+}
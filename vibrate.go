@@ -80,3 +80,49 @@ func VibrateGamepad(gamepadID GamepadID, options *VibrateGamepadOptions) {
 	}
 	g.Vibrate(options.Duration, options.StrongMagnitude, options.WeakMagnitude)
 }
+
+// GamepadTriggerEffect represents a force feedback effect of a gamepad's adaptive trigger.
+type GamepadTriggerEffect struct {
+	// Type is the type of the effect.
+	Type GamepadTriggerEffectType
+
+	// StartPosition is the position of the trigger where the effect starts.
+	// The value is in between 0 (released) and 1 (fully pressed).
+	StartPosition float64
+
+	// EndPosition is the position of the trigger where the effect ends.
+	// The value is in between 0 (released) and 1 (fully pressed).
+	//
+	// EndPosition is used only for GamepadTriggerEffectTypeWeapon.
+	EndPosition float64
+
+	// Strength is the strength of the resistance.
+	// The value is in between 0 and 1.
+	Strength float64
+}
+
+// ErrGamepadTriggerEffectNotSupported is returned by SetGamepadTriggerEffect when the gamepad's triggers don't have force feedback.
+var ErrGamepadTriggerEffectNotSupported = gamepad.ErrTriggerEffectNotSupported
+
+// SetGamepadTriggerEffect sets the force feedback effect of the specified adaptive trigger of the gamepad.
+// The effect lasts until another effect is set.
+//
+// SetGamepadTriggerEffect works only for DualSense on Linux so far.
+// On Linux, the effect is sent via hidraw, and writing it usually requires a udev rule to grant the permission.
+//
+// SetGamepadTriggerEffect returns ErrGamepadTriggerEffectNotSupported when the gamepad is not connected,
+// the gamepad doesn't have adaptive triggers, or the platform doesn't support it.
+//
+// SetGamepadTriggerEffect is concurrent-safe.
+func SetGamepadTriggerEffect(gamepadID GamepadID, trigger GamepadTrigger, effect *GamepadTriggerEffect) error {
+	g := gamepad.Get(gamepadID)
+	if g == nil {
+		return ErrGamepadTriggerEffectNotSupported
+	}
+	return g.SetTriggerEffect(trigger, gamepad.TriggerEffect{
+		Type:          effect.Type,
+		StartPosition: effect.StartPosition,
+		EndPosition:   effect.EndPosition,
+		Strength:      effect.Strength,
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package video
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// splitMJPEG splits a Motion JPEG stream, which is a concatenation of JPEG images, into JPEG images.
+// The returned slices share the memory with data.
+func splitMJPEG(data []byte) ([][]byte, error) {
+	var frames [][]byte
+	for len(data) > 0 {
+		n, err := jpegLength(data)
+		if err != nil {
+			return nil, fmt.Errorf("video: frame %d: %w", len(frames), err)
+		}
+		frames = append(frames, data[:n:n])
+		data = data[n:]
+	}
+	return frames, nil
+}
+
+// jpegLength returns the length of the first JPEG image in data.
+func jpegLength(data []byte) (int, error) {
+	// SOI
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return 0, errors.New("SOI marker not found")
+	}
+
+	i := 2
+	for {
+		if i+1 >= len(data) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if data[i] != 0xff {
+			return 0, fmt.Errorf("a marker not found at %d", i)
+		}
+
+		m := data[i+1]
+		switch {
+		case m == 0xff:
+			// A fill byte.
+			i++
+			continue
+		case m == 0xd9:
+			// EOI
+			return i + 2, nil
+		case m == 0x01 || 0xd0 <= m && m <= 0xd7:
+			// TEM and RSTn don't have a length.
+			i += 2
+			continue
+		}
+
+		if i+3 >= len(data) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		i += 2 + (int(data[i+2])<<8 | int(data[i+3]))
+
+		// SOS is followed by entropy-coded data. Skip it until the next marker.
+		// 0xff in the data is always followed by 0x00 (stuffing) or RSTn.
+		if m == 0xda {
+			for {
+				if i+1 >= len(data) {
+					return 0, io.ErrUnexpectedEOF
+				}
+				if data[i] == 0xff && data[i+1] != 0 && (data[i+1] < 0xd0 || 0xd7 < data[i+1]) {
+					break
+				}
+				i++
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package video provides a basic video player that renders video frames to an ebiten.Image.
+//
+// So far, only Motion JPEG (a concatenation of JPEG images) is supported.
+package video
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// PlayerOptions represents options for a Player.
+type PlayerOptions struct {
+	// FPS is the frame rate of the video.
+	// FPS must be positive.
+	FPS float64
+
+	// AudioPlayer is an audio player played together with the video.
+	//
+	// If AudioPlayer is not nil, Play, Pause and SetPosition are also applied to AudioPlayer,
+	// and the video's position follows AudioPlayer's position.
+	// The default (zero) value is nil, which means the video has no audio.
+	AudioPlayer *audio.Player
+}
+
+// Player is a video player.
+type Player struct {
+	frames      [][]byte
+	width       int
+	height      int
+	fps         float64
+	audioPlayer *audio.Player
+
+	playing   bool
+	startTime time.Time
+	position  time.Duration
+
+	image      *ebiten.Image
+	rgba       *image.RGBA
+	frameIndex int
+
+	m sync.Mutex
+}
+
+// NewPlayerFromMJPEG creates a new video player from a Motion JPEG stream, which is a concatenation of JPEG images.
+//
+// NewPlayerFromMJPEG reads all the data from src, but decodes a frame only when the frame is shown.
+// All the frames must have the same size.
+//
+// The player is paused at the beginning.
+func NewPlayerFromMJPEG(src io.Reader, options *PlayerOptions) (*Player, error) {
+	if options == nil || options.FPS <= 0 {
+		return nil, errors.New("video: FPS must be positive")
+	}
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	frames, err := splitMJPEG(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, errors.New("video: no frames")
+	}
+
+	var width, height int
+	for i, f := range frames {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(f))
+		if err != nil {
+			return nil, fmt.Errorf("video: frame %d: %w", i, err)
+		}
+		if i == 0 {
+			width, height = cfg.Width, cfg.Height
+			continue
+		}
+		if cfg.Width != width || cfg.Height != height {
+			return nil, fmt.Errorf("video: frame %d: the size (%d, %d) doesn't match with the first frame's size (%d, %d)", i, cfg.Width, cfg.Height, width, height)
+		}
+	}
+
+	return &Player{
+		frames:      frames,
+		width:       width,
+		height:      height,
+		fps:         options.FPS,
+		audioPlayer: options.AudioPlayer,
+		frameIndex:  -1,
+	}, nil
+}
+
+// Play starts or resumes playing the video.
+//
+// Play is concurrent-safe.
+func (p *Player) Play() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.audioPlayer != nil {
+		p.audioPlayer.Play()
+		return
+	}
+	if p.playing {
+		return
+	}
+	if p.position >= p.duration() {
+		p.position = 0
+	}
+	p.playing = true
+	p.startTime = time.Now().Add(-p.position)
+}
+
+// Pause pauses the video.
+//
+// Pause is concurrent-safe.
+func (p *Player) Pause() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.audioPlayer != nil {
+		p.audioPlayer.Pause()
+		return
+	}
+	if !p.playing {
+		return
+	}
+	p.position = p.currentPosition()
+	p.playing = false
+}
+
+// IsPlaying reports whether the video is playing.
+// IsPlaying returns false after the video reaches the end.
+//
+// IsPlaying is concurrent-safe.
+func (p *Player) IsPlaying() bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.audioPlayer != nil {
+		return p.audioPlayer.IsPlaying() && p.audioPlayer.Position() < p.duration()
+	}
+	return p.playing && p.currentPosition() < p.duration()
+}
+
+// Position returns the current position of the video.
+//
+// Position is concurrent-safe.
+func (p *Player) Position() time.Duration {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.currentPosition()
+}
+
+// SetPosition sets the position of the video.
+//
+// SetPosition is concurrent-safe.
+func (p *Player) SetPosition(position time.Duration) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	position = min(max(position, 0), p.duration())
+	if p.audioPlayer != nil {
+		return p.audioPlayer.SetPosition(position)
+	}
+	p.position = position
+	if p.playing {
+		p.startTime = time.Now().Add(-position)
+	}
+	return nil
+}
+
+// Duration returns the total duration of the video.
+//
+// Duration is concurrent-safe.
+func (p *Player) Duration() time.Duration {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.duration()
+}
+
+// Size returns the size of the video frames.
+//
+// Size is concurrent-safe.
+func (p *Player) Size() (width, height int) {
+	return p.width, p.height
+}
+
+// CurrentFrame returns the image of the frame at the current position.
+//
+// The returned image is reused and updated by later CurrentFrame calls, and must not be modified.
+// If a frame fails to be decoded, the previous frame is kept.
+//
+// CurrentFrame is concurrent-safe.
+func (p *Player) CurrentFrame() *ebiten.Image {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.image == nil {
+		p.image = ebiten.NewImage(p.width, p.height)
+		p.rgba = image.NewRGBA(image.Rect(0, 0, p.width, p.height))
+	}
+
+	idx := int(p.currentPosition().Seconds() * p.fps)
+	idx = min(max(idx, 0), len(p.frames)-1)
+	if idx == p.frameIndex {
+		return p.image
+	}
+	p.frameIndex = idx
+
+	img, err := jpeg.Decode(bytes.NewReader(p.frames[idx]))
+	if err != nil {
+		return p.image
+	}
+	draw.Draw(p.rgba, p.rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	p.image.WritePixels(p.rgba.Pix)
+	return p.image
+}
+
+// Close releases the resources of the player.
+// Close doesn't close the audio player.
+//
+// Close is concurrent-safe.
+func (p *Player) Close() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.image != nil {
+		p.image.Deallocate()
+		p.image = nil
+	}
+	p.rgba = nil
+	p.frameIndex = -1
+	p.playing = false
+	return nil
+}
+
+func (p *Player) duration() time.Duration {
+	return time.Duration(float64(len(p.frames)) / p.fps * float64(time.Second))
+}
+
+func (p *Player) currentPosition() time.Duration {
+	if p.audioPlayer != nil {
+		return min(p.audioPlayer.Position(), p.duration())
+	}
+	if !p.playing {
+		return p.position
+	}
+	return min(time.Since(p.startTime), p.duration())
+}
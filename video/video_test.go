@@ -0,0 +1,119 @@
+// Copyright 2025 The Ebitengine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package video_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	t "github.com/hajimehoshi/ebiten/v2/internal/testing"
+	"github.com/hajimehoshi/ebiten/v2/video"
+)
+
+func TestMain(m *testing.M) {
+	t.MainWithRunLoop(m)
+}
+
+func encodeMJPEG(t *testing.T, colors []color.RGBA, w, h int) []byte {
+	var buf bytes.Buffer
+	for _, c := range colors {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for j := 0; j < h; j++ {
+			for i := 0; i < w; i++ {
+				img.SetRGBA(i, j, c)
+			}
+		}
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestPlayerFromMJPEG(t *testing.T) {
+	colors := []color.RGBA{
+		{0xff, 0, 0, 0xff},
+		{0, 0xff, 0, 0xff},
+		{0, 0, 0xff, 0xff},
+	}
+	const (
+		w = 16
+		h = 8
+	)
+	data := encodeMJPEG(t, colors, w, h)
+
+	p, err := video.NewPlayerFromMJPEG(bytes.NewReader(data), &video.PlayerOptions{
+		FPS: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = p.Close()
+	}()
+
+	if got, want := p.Duration(), 300*time.Millisecond; got != want {
+		t.Errorf("Duration(): got: %v, want: %v", got, want)
+	}
+	if gotW, gotH := p.Size(); gotW != w || gotH != h {
+		t.Errorf("Size(): got: (%d, %d), want: (%d, %d)", gotW, gotH, w, h)
+	}
+	if p.IsPlaying() {
+		t.Errorf("IsPlaying(): got: true, want: false")
+	}
+
+	for i, want := range colors {
+		if err := p.SetPosition(time.Duration(i)*100*time.Millisecond + 50*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		img := p.CurrentFrame()
+		got := img.At(w/2, h/2).(color.RGBA)
+		const delta = 8
+		if abs(int(got.R)-int(want.R)) > delta || abs(int(got.G)-int(want.G)) > delta || abs(int(got.B)-int(want.B)) > delta || got.A != want.A {
+			t.Errorf("frame %d: got: %v, want: %v", i, got, want)
+		}
+	}
+}
+
+func TestPlayerFromMJPEGErrors(t *testing.T) {
+	if _, err := video.NewPlayerFromMJPEG(bytes.NewReader(nil), &video.PlayerOptions{FPS: 30}); err == nil {
+		t.Errorf("NewPlayerFromMJPEG with empty data must return an error")
+	}
+
+	data := encodeMJPEG(t, []color.RGBA{{0xff, 0xff, 0xff, 0xff}}, 4, 4)
+	if _, err := video.NewPlayerFromMJPEG(bytes.NewReader(data), nil); err == nil {
+		t.Errorf("NewPlayerFromMJPEG without FPS must return an error")
+	}
+
+	mixed := append(data, encodeMJPEG(t, []color.RGBA{{0xff, 0xff, 0xff, 0xff}}, 8, 8)...)
+	if _, err := video.NewPlayerFromMJPEG(bytes.NewReader(mixed), &video.PlayerOptions{FPS: 30}); err == nil {
+		t.Errorf("NewPlayerFromMJPEG with frames of different sizes must return an error")
+	}
+
+	if _, err := video.NewPlayerFromMJPEG(bytes.NewReader(data[:len(data)-2]), &video.PlayerOptions{FPS: 30}); err == nil {
+		t.Errorf("NewPlayerFromMJPEG with a truncated frame must return an error")
+	}
+}
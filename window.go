@@ -16,6 +16,8 @@ package ebiten
 
 import (
 	"image"
+	"math"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hajimehoshi/ebiten/v2/internal/ui"
@@ -197,6 +199,70 @@ func SetWindowSize(width, height int) {
 	ui.Get().Window().SetSize(width, height)
 }
 
+var (
+	windowResizeCallback  func(width, height int)
+	windowResizeCallbackM sync.Mutex
+
+	// lastWindowWidth and lastWindowHeight are accessed only from the game's Update.
+	lastWindowWidth  int
+	lastWindowHeight int
+)
+
+// SetWindowResizeCallback sets a callback function invoked when the window size changes on desktops.
+//
+// The callback is given the new window size in device pixels, unlike WindowSize.
+// This is independent from Layout: the callback reacts to changes of the window, not of the logical screen.
+//
+// The callback is invoked on the same goroutine as Game's Update, just before Update is called.
+// The callback is not invoked for the window size at the time the callback is set.
+//
+// If f is nil, the current callback is removed.
+//
+// SetWindowResizeCallback works only on desktops.
+// On the other platforms, the callback is never invoked.
+//
+// SetWindowResizeCallback is concurrent-safe.
+func SetWindowResizeCallback(f func(width, height int)) {
+	windowResizeCallbackM.Lock()
+	defer windowResizeCallbackM.Unlock()
+	windowResizeCallback = f
+}
+
+func notifyWindowResize() {
+	windowResizeCallbackM.Lock()
+	f := windowResizeCallback
+	windowResizeCallbackM.Unlock()
+
+	// Don't query the window size every tick unless a callback is set.
+	if f == nil {
+		lastWindowWidth = 0
+		lastWindowHeight = 0
+		return
+	}
+
+	w, h := WindowSize()
+	if w == 0 || h == 0 {
+		return
+	}
+	s := 1.0
+	if m := Monitor(); m != nil {
+		s = m.DeviceScaleFactor()
+	}
+	w = int(math.Ceil(float64(w) * s))
+	h = int(math.Ceil(float64(h) * s))
+
+	if lastWindowWidth == w && lastWindowHeight == h {
+		return
+	}
+	initial := lastWindowWidth == 0 && lastWindowHeight == 0
+	lastWindowWidth = w
+	lastWindowHeight = h
+	if initial {
+		return
+	}
+	f(w, h)
+}
+
 // WindowSizeLimits returns the limitation of the window size on desktops.
 // A negative value indicates the size is not limited.
 //
@@ -351,3 +417,29 @@ func IsWindowMousePassthrough() bool {
 func RequestAttention() {
 	ui.Get().Window().RequestAttention()
 }
+
+// SetWindowOpacity sets the opacity of the whole window including its decorations.
+// opacity is clamped to [0, 1], where 0 is fully transparent and 1 is fully opaque. The default value is 1.
+//
+// SetWindowOpacity is different from SetScreenTransparent, which makes only the transparent pixels of the screen transparent.
+// The result of using both is undefined.
+//
+// SetWindowOpacity works only on desktops.
+// SetWindowOpacity does nothing if the platform is not a desktop.
+//
+// SetWindowOpacity is concurrent-safe.
+func SetWindowOpacity(opacity float64) {
+	if opacity != opacity {
+		return
+	}
+	ui.Get().Window().SetOpacity(min(max(opacity, 0), 1))
+}
+
+// WindowOpacity returns the opacity of the whole window.
+//
+// WindowOpacity always returns 1 if the platform doesn't support the window opacity, e.g. the platform is not a desktop.
+//
+// WindowOpacity is concurrent-safe.
+func WindowOpacity() float64 {
+	return ui.Get().Window().Opacity()
+}